@@ -1,8 +1,9 @@
 package migrate
 
 import (
-	"log"
+	"os"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/logging"
 	"github.com/cesc1802/onboarding-and-volunteer-service/migration"
 	"github.com/cesc1802/share-module/config"
 	"github.com/cesc1802/share-module/system"
@@ -18,18 +19,20 @@ var up = &cobra.Command{
 	Use:   "up",
 	Short: "This will be use to migrate new change",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := logging.New(os.Stdout)
 
 		cfg, err := config.LoadAppConfig(".")
 		if err != nil {
-			log.Fatalln(err)
+			logger.Error("cannot load config", "error", err)
 			return err
 		}
 		sys := system.New(cfg, cmd.Parent().Name())
 
 		if err := sys.MigrateDB(migration.FS); err != nil {
-			log.Fatalln(err)
+			logger.Error("migration failed", "error", err)
 			return err
 		}
+		logger.Info("migrations applied")
 		return nil
 	},
 }
@@ -38,7 +41,7 @@ var down = &cobra.Command{
 	Use:   "down",
 	Short: "This will be use to rollback new change",
 	Run: func(cmd *cobra.Command, args []string) {
-		log.Println("run rollback")
+		logging.New(os.Stdout).Info("run rollback")
 	},
 }
 
@@ -1,10 +1,12 @@
 package cmd
 
 import (
-	"log"
+	"log/slog"
+	"os"
 
 	migrate "github.com/cesc1802/onboarding-and-volunteer-service/cmd/migration"
 	"github.com/cesc1802/onboarding-and-volunteer-service/cmd/server"
+	"github.com/cesc1802/onboarding-and-volunteer-service/cmd/worker"
 	"github.com/spf13/cobra"
 )
 
@@ -17,10 +19,12 @@ var rootCmd = &cobra.Command{
 func init() {
 	server.RegisterServer(rootCmd)
 	migrate.RegisterMigrate(rootCmd)
+	worker.RegisterWorker(rootCmd)
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		log.Fatalf("application cannot start %v", err)
+		slog.Error("application cannot start", "error", err)
+		os.Exit(1)
 	}
 }
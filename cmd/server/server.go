@@ -2,8 +2,12 @@ package server
 
 import (
 	"context"
+	"os"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/logging"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/tracing"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/worker"
 	"github.com/cesc1802/share-module/config"
 	"github.com/cesc1802/share-module/system"
 	"github.com/spf13/cobra"
@@ -12,12 +16,26 @@ import (
 type Module struct{}
 
 func (Module) Startup(ctx context.Context, mono system.Service) (err error) {
-	return Root(ctx, mono)
+	timeout, err := worker.ShutdownTimeoutFromEnv()
+	if err != nil {
+		return err
+	}
+	workers := worker.NewManager(timeout)
+	if err := Root(ctx, mono, workers); err != nil {
+		return err
+	}
+	go workers.Run(ctx)
+	return nil
 }
 
-func Root(ctx context.Context, mono system.Service) error {
-	feature.RegisterHandlerV1(mono)
-	return nil
+func Root(ctx context.Context, mono system.Service, workers *worker.Manager) error {
+	shutdownTracing, err := tracing.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	// added first so it is stopped last and flushes the spans of the other workers
+	workers.Add(worker.Worker{Name: "tracing", Stop: shutdownTracing})
+	return feature.RegisterHandlerV1(feature.WithLogger(mono, logging.New(os.Stdout)), workers)
 }
 
 var serverCmd = &cobra.Command{
@@ -29,14 +47,23 @@ var serverCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		timeout, err := worker.ShutdownTimeoutFromEnv()
+		if err != nil {
+			return err
+		}
 		sys := system.New(cfg, cmd.Parent().Name())
+		workers := worker.NewManager(timeout)
 
-		if err := Root(sys.Waiter().Context(), sys); err != nil {
+		if err := Root(sys.Waiter().Context(), sys, workers); err != nil {
 			return err
 		}
 
+		// on SIGTERM the web server stops accepting connections while the workers drain the
+		// requests in flight and stop the background jobs
 		sys.Waiter().Add(
-			sys.WaitForWeb)
+			worker.CancelOnSignal(sys.Waiter().CancelFunc()),
+			sys.WaitForWeb,
+			workers.Run)
 
 		return sys.Waiter().Wait()
 	},
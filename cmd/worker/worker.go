@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"os"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/logging"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/tracing"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/worker"
+	"github.com/cesc1802/share-module/config"
+	"github.com/cesc1802/share-module/system"
+	"github.com/spf13/cobra"
+)
+
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "Starting background workers without the HTTP server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		cfg, err := config.LoadAppConfig(".")
+		if err != nil {
+			return err
+		}
+		timeout, err := worker.ShutdownTimeoutFromEnv()
+		if err != nil {
+			return err
+		}
+		sys := system.New(cfg, cmd.Parent().Name())
+		workers := worker.NewManager(timeout)
+
+		shutdownTracing, err := tracing.Setup(sys.Waiter().Context())
+		if err != nil {
+			return err
+		}
+		workers.Add(worker.Worker{Name: "tracing", Stop: shutdownTracing})
+		if err := feature.RegisterWorkersV1(feature.WithLogger(sys, logging.New(os.Stdout)), workers); err != nil {
+			return err
+		}
+
+		sys.Waiter().Add(
+			worker.CancelOnSignal(sys.Waiter().CancelFunc()),
+			workers.Run)
+
+		return sys.Waiter().Wait()
+	},
+}
+
+func RegisterWorker(root *cobra.Command) {
+	root.AddCommand(workerCmd)
+}
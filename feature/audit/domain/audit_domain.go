@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// AuditLog records one event published on the event bus, Payload is the event as JSON
+type AuditLog struct {
+	ID        int       `gorm:"primaryKey"`
+	Event     string    `gorm:"not null"`
+	Payload   string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"encoding/json"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/event"
+)
+
+// AuditRepository writes the audit trail of the event bus.
+type AuditRepository struct{}
+
+// NewAuditRepository creates a new instance of AuditRepository.
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{}
+}
+
+// Record stores e in the transaction that published it, so the trail holds exactly the committed changes.
+func (r *AuditRepository) Record(tx *event.Tx, e event.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return tx.DB().Create(&domain.AuditLog{Event: e.Name(), Payload: string(payload)}).Error
+}
@@ -23,3 +23,13 @@ type User struct {
 	CreatedAt          time.Time `gorm:"autoCreateTime"`
 	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
 }
+
+// UserInvite is a single-use token an imported user chooses their password with
+type UserInvite struct {
+	ID        int `gorm:"primaryKey"`
+	UserID    int `gorm:"index"`
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -38,3 +38,9 @@ type RegisterUserRequest struct {
 type RegisterUserResponse struct {
 	Message string `json:"message"`
 }
+
+type AcceptInviteRequest struct {
+	Token      string `json:"token" binding:"required"`
+	Password   string `json:"password" binding:"required"`
+	RePassword string `json:"re_password" binding:"required"`
+}
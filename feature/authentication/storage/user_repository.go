@@ -1,26 +1,38 @@
 package storage
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/event"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AuthenticationSrore interface {
-	GetUserByEmail(email string, password string) (*domain.User, string)
-	RegisterUser(request *dto.RegisterUserRequest) (*dto.RegisterUserResponse, error)
+	GetUserByEmail(ctx context.Context, email string, password string) (*domain.User, string)
+	RegisterUser(ctx context.Context, request *dto.RegisterUserRequest) (*dto.RegisterUserResponse, error)
+	AcceptInvite(ctx context.Context, tokenHash string, password string, now time.Time) error
 }
 
+// ErrInviteInvalid is returned when no unused and unexpired invite has the token
+var ErrInviteInvalid = errors.New("invite is invalid or expired")
+
 type AuthenticationRepository struct {
-	db *gorm.DB
+	db  *gorm.DB
+	bus *event.Bus
 }
 
-func NewAuthenticationRepository(db *gorm.DB) *AuthenticationRepository {
-	return &AuthenticationRepository{db: db}
+func NewAuthenticationRepository(db *gorm.DB, bus *event.Bus) *AuthenticationRepository {
+	return &AuthenticationRepository{db: db, bus: bus}
 }
-func (r *AuthenticationRepository) GetUserByEmail(email string, password string) (*domain.User, string) {
+func (r *AuthenticationRepository) GetUserByEmail(ctx context.Context, email string, password string) (*domain.User, string) {
 	var user domain.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := uow.Conn(ctx, r.db).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err.Error()
 	}
@@ -33,7 +45,7 @@ func (r *AuthenticationRepository) GetUserByEmail(email string, password string)
 	return &user, ""
 }
 
-func (r *AuthenticationRepository) RegisterUser(request *dto.RegisterUserRequest) (*dto.RegisterUserResponse, error) {
+func (r *AuthenticationRepository) RegisterUser(ctx context.Context, request *dto.RegisterUserRequest) (*dto.RegisterUserResponse, error) {
 	user := domain.User{
 		Email:    request.Email,
 		Name:     request.Name,
@@ -41,7 +53,13 @@ func (r *AuthenticationRepository) RegisterUser(request *dto.RegisterUserRequest
 		Status:   1,
 	}
 
-	if err := r.db.Create(&user).Error; err != nil {
+	err := r.bus.InTx(ctx, func(tx *event.Tx) error {
+		if err := tx.DB().Create(&user).Error; err != nil {
+			return err
+		}
+		return tx.Publish(event.UserRegistered{UserID: user.ID, Email: user.Email, UserName: user.Name})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -50,3 +68,24 @@ func (r *AuthenticationRepository) RegisterUser(request *dto.RegisterUserRequest
 	}
 	return response, nil
 }
+
+// AcceptInvite sets the password of the user invited with tokenHash and marks the invite used, the invite row is
+// locked so a token is redeemed once
+func (r *AuthenticationRepository) AcceptInvite(ctx context.Context, tokenHash string, password string, now time.Time) error {
+	return uow.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var invite domain.UserInvite
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			First(&invite).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInviteInvalid
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.User{}).Where("id = ?", invite.UserID).Update("password", password).Error; err != nil {
+			return err
+		}
+		return tx.Model(&invite).Update("used_at", now).Error
+	})
+}
@@ -30,7 +30,7 @@ func (h *AuthenticationHandler) Login(c *gin.Context) {
 		return
 	}
 
-	resp, msg := h.usecase.Login(req)
+	resp, msg := h.usecase.Login(c.Request.Context(), req)
 	if msg != "" {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
 		return
@@ -54,7 +54,7 @@ func (h *AuthenticationHandler) Register(c *gin.Context) {
 		return
 	}
 
-	resp, msg := h.usecase.RegisterUser(req)
+	resp, msg := h.usecase.RegisterUser(c.Request.Context(), req)
 	if msg != "" {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
 		return
@@ -62,3 +62,27 @@ func (h *AuthenticationHandler) Register(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// AcceptInvite godoc
+// @Summary Accept invite
+// @Description Set the password of a user created by an import with the invite token of the import report
+// @Produce json
+// @Tags authentication
+// @Param acceptInviteRequest body dto.AcceptInviteRequest true "Accept Invite Request"
+// @Success 200 {object} dto.RegisterUserResponse{}
+// @Router /api/v1/auth/accept-invite [post]
+func (h *AuthenticationHandler) AcceptInvite(c *gin.Context) {
+	var req dto.AcceptInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, msg := h.usecase.AcceptInvite(c.Request.Context(), req)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
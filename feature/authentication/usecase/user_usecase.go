@@ -1,6 +1,10 @@
 package usecase
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
 	"github.com/golang-jwt/jwt/v4"
@@ -8,21 +12,30 @@ import (
 )
 
 type UserUsecaseInterface interface {
-	Login(req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, string)
-	RegisterUser(req dto.RegisterUserRequest) (*dto.RegisterUserResponse, string)
+	Login(ctx context.Context, req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, string)
+	RegisterUser(ctx context.Context, req dto.RegisterUserRequest) (*dto.RegisterUserResponse, string)
+	AcceptInvite(ctx context.Context, req dto.AcceptInviteRequest) (*dto.RegisterUserResponse, string)
+}
+
+// LoginObserver counts login attempts
+type LoginObserver interface {
+	ObserveLogin(success bool)
 }
 
 type UserUsecase struct {
 	repo      storage.AuthenticationSrore
 	secretKey string
+	observer  LoginObserver
 }
 
-func NewUserUsecase(repo storage.AuthenticationSrore, secretKey string) *UserUsecase {
+func NewUserUsecase(repo storage.AuthenticationSrore, secretKey string, observer LoginObserver) *UserUsecase {
 	return &UserUsecase{repo: repo,
-		secretKey: secretKey}
+		secretKey: secretKey,
+		observer:  observer}
 }
-func (u *UserUsecase) Login(req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, string) {
-	user, msg := u.repo.GetUserByEmail(req.Email, req.Password)
+func (u *UserUsecase) Login(ctx context.Context, req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, string) {
+	user, msg := u.repo.GetUserByEmail(ctx, req.Email, req.Password)
+	u.observer.ObserveLogin(user != nil)
 	if user != nil {
 		claims := jwt.MapClaims{
 			"userId": user.ID,
@@ -42,17 +55,33 @@ func (u *UserUsecase) Login(req dto.LoginUserRequest) (*dto.LoginUserTokenRespon
 	return nil, msg
 }
 
-func (u *UserUsecase) RegisterUser(req dto.RegisterUserRequest) (*dto.RegisterUserResponse, string) {
+func (u *UserUsecase) RegisterUser(ctx context.Context, req dto.RegisterUserRequest) (*dto.RegisterUserResponse, string) {
 	// check existed user
-	user, _ := u.repo.GetUserByEmail(req.Email, "")
+	user, _ := u.repo.GetUserByEmail(ctx, req.Email, "")
 	if user != nil {
 		return nil, "User existed"
 	}
 	// register user
-	registerUser, err := u.repo.RegisterUser(&req)
+	registerUser, err := u.repo.RegisterUser(ctx, &req)
 	if err != nil {
 		return nil, "Register failed"
 	}
 
 	return registerUser, ""
 }
+
+// AcceptInvite lets a user created by an import choose their password with the token of the import report
+func (u *UserUsecase) AcceptInvite(ctx context.Context, req dto.AcceptInviteRequest) (*dto.RegisterUserResponse, string) {
+	if req.Password != req.RePassword {
+		return nil, "Passwords do not match"
+	}
+	sum := sha256.Sum256([]byte(req.Token))
+	err := u.repo.AcceptInvite(ctx, hex.EncodeToString(sum[:]), req.Password, time.Now())
+	if errors.Is(err, storage.ErrInviteInvalid) {
+		return nil, "Invite is invalid or expired"
+	}
+	if err != nil {
+		return nil, "Accepting the invite failed"
+	}
+	return &dto.RegisterUserResponse{Message: "Password set successfully"}, ""
+}
@@ -0,0 +1,95 @@
+// Package cache keeps rarely changing data, such as the reference data lists, out of the database.
+//
+// Values are cached as JSON for a TTL, in an in-process LRU or in Redis when REDIS_URL is set so
+// that every instance of the service shares them. The usecases delete their keys when they change
+// the data. With the LRU an instance only drops its own copy, the others serve theirs until the TTL.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// DefaultTTL is how long a value is cached when CACHE_TTL is not set
+	DefaultTTL = 5 * time.Minute
+	// DefaultSize is how many values the LRU holds when CACHE_SIZE is not set
+	DefaultSize = 1000
+)
+
+// Cache stores values under keys until they expire or are deleted.
+type Cache interface {
+	// Get returns the value of key, false when it is not cached
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// FromEnv returns a Redis cache when REDIS_URL is set and an LRU otherwise, CACHE_TTL sets how
+// long values are kept and CACHE_SIZE how many the LRU holds.
+func FromEnv() (Cache, error) {
+	ttl := DefaultTTL
+	if value := os.Getenv("CACHE_TTL"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		ttl = parsed
+	}
+	url := os.Getenv("REDIS_URL")
+	if url == "" {
+		size := DefaultSize
+		if value := os.Getenv("CACHE_SIZE"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, err
+			}
+			size = parsed
+		}
+		return NewLRU(size, ttl), nil
+	}
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return NewRedis(redis.NewClient(options), ttl), nil
+}
+
+// Load returns the value cached under key, or the one load returns which is then cached.
+// A failing cache is logged and bypassed, it must not fail the request.
+func Load[T any](ctx context.Context, c Cache, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	data, ok, err := c.Get(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, "cannot read the cache", "key", key, "error", err)
+	}
+	if ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+		slog.WarnContext(ctx, "cannot decode the cached value", "key", key, "error", err)
+	}
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+	if data, err := json.Marshal(value); err != nil {
+		slog.WarnContext(ctx, "cannot encode the value to cache", "key", key, "error", err)
+	} else if err := c.Set(ctx, key, data); err != nil {
+		slog.WarnContext(ctx, "cannot write the cache", "key", key, "error", err)
+	}
+	return value, nil
+}
+
+// Invalidate deletes keys once their data changed, a failure is logged as the value expires anyway.
+func Invalidate(ctx context.Context, c Cache, keys ...string) {
+	if err := c.Delete(ctx, keys...); err != nil {
+		slog.WarnContext(ctx, "cannot invalidate the cache", "keys", keys, "error", err)
+	}
+}
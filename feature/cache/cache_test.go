@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertGet(t *testing.T, c Cache, key string, want string) {
+	t.Helper()
+	value, ok, err := c.Get(context.Background(), key)
+	assert.NoError(t, err)
+	if want == "" {
+		assert.False(t, ok, key)
+		return
+	}
+	assert.True(t, ok, key)
+	assert.Equal(t, want, string(value))
+}
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	lru := NewLRU(2, time.Minute)
+	lru.now = func() time.Time { return now }
+
+	lru.Set(ctx, "countries", []byte("a"))
+	lru.Set(ctx, "roles", []byte("b"))
+	assertGet(t, lru, "countries", "a")
+	// roles is the least recently used
+	lru.Set(ctx, "departments", []byte("c"))
+	assertGet(t, lru, "roles", "")
+	assertGet(t, lru, "countries", "a")
+	assertGet(t, lru, "departments", "c")
+
+	lru.Delete(ctx, "countries", "unknown")
+	assertGet(t, lru, "countries", "")
+
+	now = now.Add(time.Minute)
+	assertGet(t, lru, "departments", "")
+	assert.Zero(t, lru.order.Len())
+}
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	cache := NewRedis(client, time.Minute)
+
+	assertGet(t, cache, "countries", "")
+	assert.NoError(t, cache.Set(ctx, "countries", []byte("a")))
+	assertGet(t, cache, "countries", "a")
+	assert.Equal(t, time.Minute, server.TTL("cache:countries"))
+
+	assert.NoError(t, cache.Delete(ctx, "countries"))
+	assertGet(t, cache, "countries", "")
+
+	assert.NoError(t, cache.Set(ctx, "roles", []byte("b")))
+	server.FastForward(time.Minute)
+	assertGet(t, cache, "roles", "")
+	server.Close()
+	_, _, err := cache.Get(ctx, "roles")
+	assert.Error(t, err)
+}
+
+type failingCache struct{}
+
+func (failingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+
+func (failingCache) Set(ctx context.Context, key string, value []byte) error {
+	return errors.New("connection refused")
+}
+
+func (failingCache) Delete(ctx context.Context, keys ...string) error {
+	return errors.New("connection refused")
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10, time.Minute)
+	loads := 0
+	load := func(ctx context.Context) ([]string, error) {
+		loads++
+		return []string{"Vietnam", "France"}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		countries, err := Load(ctx, lru, "countries", load)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Vietnam", "France"}, countries)
+	}
+	assert.Equal(t, 1, loads)
+
+	Invalidate(ctx, lru, "countries")
+	Load(ctx, lru, "countries", load)
+	assert.Equal(t, 2, loads)
+
+	// errors are not cached
+	_, err := Load(ctx, lru, "roles", func(ctx context.Context) ([]string, error) { return nil, errors.New("timeout") })
+	assert.EqualError(t, err, "timeout")
+	assertGet(t, lru, "roles", "")
+
+	// a failing cache is bypassed
+	countries, err := Load(ctx, failingCache{}, "countries", load)
+	assert.NoError(t, err)
+	assert.Len(t, countries, 2)
+	Invalidate(ctx, failingCache{}, "countries")
+}
+
+func TestJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/countries", func(c *gin.Context) {
+		JSON(c, http.StatusOK, []string{"Vietnam"})
+	})
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/countries", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("")
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `["Vietnam"]`, w.Body.String())
+	assert.Len(t, etag, 34)
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+
+	for _, header := range []string{etag, `"other", W/` + etag, "*"} {
+		w = get(header)
+		assert.Equal(t, http.StatusNotModified, w.Code, header)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+	}
+
+	w = get(`"stale"`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `["Vietnam"]`, w.Body.String())
+}
@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix keeps the cached values apart from the other keys of the Redis database
+const keyPrefix = "cache:"
+
+// Redis keeps the values in Redis, shared by every instance of the service.
+type Redis struct {
+	client redis.Cmdable
+	ttl    time.Duration
+}
+
+// NewRedis creates a Redis cache on client, values are kept for ttl.
+func NewRedis(client redis.Cmdable, ttl time.Duration) *Redis {
+	return &Redis{client: client, ttl: ttl}
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.client.Get(ctx, keyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte) error {
+	return r.client.Set(ctx, keyPrefix+key, value, r.ttl).Err()
+}
+
+func (r *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, keyPrefix+key)
+	}
+	return r.client.Del(ctx, prefixed...).Err()
+}
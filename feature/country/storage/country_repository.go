@@ -1,17 +1,19 @@
 package storage
 
 import (
+	"context"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
 	"gorm.io/gorm"
 )
 
 // CountryRepositoryInterface defines the methods that any repository implementation must provide.
 type CountryRepositoryInterface interface {
-	Create(country *domain.Country) error
-	GetByID(id uint) (*domain.Country, error)
-	Update(country *domain.Country) error
-	Delete(id uint) error
-	GetAll() ([]domain.Country, error)
+	Create(ctx context.Context, country *domain.Country) error
+	GetByID(ctx context.Context, id uint) (*domain.Country, error)
+	Update(ctx context.Context, country *domain.Country) error
+	Delete(ctx context.Context, id uint) error
+	GetAll(ctx context.Context) ([]domain.Country, error)
 }
 
 // CountryRepository handles the CRUD operations with the database.
@@ -25,29 +27,29 @@ func NewCountryRepository(db *gorm.DB) *CountryRepository {
 }
 
 // Create inserts a new country record into the database.
-func (r *CountryRepository) Create(country *domain.Country) error {
-	return r.DB.Create(country).Error
+func (r *CountryRepository) Create(ctx context.Context, country *domain.Country) error {
+	return uow.Conn(ctx, r.DB).Create(country).Error
 }
 
-func (r *CountryRepository) GetAll() ([]domain.Country, error) {
+func (r *CountryRepository) GetAll(ctx context.Context) ([]domain.Country, error) {
 	var countries []domain.Country
-	err := r.DB.Find(&countries).Error
+	err := uow.Conn(ctx, r.DB).Find(&countries).Error
 	return countries, err
 }
 
 // GetByID retrieves a country record by its ID from the database.
-func (r *CountryRepository) GetByID(id uint) (*domain.Country, error) {
+func (r *CountryRepository) GetByID(ctx context.Context, id uint) (*domain.Country, error) {
 	var country domain.Country
-	err := r.DB.First(&country, id).Error
+	err := uow.Conn(ctx, r.DB).First(&country, id).Error
 	return &country, err
 }
 
 // Update updates a country record in the database.
-func (r *CountryRepository) Update(country *domain.Country) error {
-	return r.DB.Save(country).Error
+func (r *CountryRepository) Update(ctx context.Context, country *domain.Country) error {
+	return uow.Conn(ctx, r.DB).Save(country).Error
 }
 
 // Delete deletes a country record from the database.
-func (r *CountryRepository) Delete(id uint) error {
-	return r.DB.Delete(&domain.Country{}, id).Error
+func (r *CountryRepository) Delete(ctx context.Context, id uint) error {
+	return uow.Conn(ctx, r.DB).Delete(&domain.Country{}, id).Error
 }
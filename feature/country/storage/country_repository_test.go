@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -47,7 +48,7 @@ func TestCountryRepository_Create(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err = repo.Create(country)
+	err = repo.Create(context.Background(), country)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -77,7 +78,7 @@ func TestCountryRepository_GetByID(t *testing.T) {
 		WithArgs(countryID).
 		WillReturnRows(rows)
 
-	result, err := repo.GetByID(countryID)
+	result, err := repo.GetByID(context.Background(), countryID)
 	assert.NoError(t, err)
 	assert.Equal(t, country, result)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -106,7 +107,7 @@ func TestCountryRepository_Update(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err = repo.Update(country)
+	err = repo.Update(context.Background(), country)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -131,7 +132,7 @@ func TestCountryRepository_Delete(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err = repo.Delete(countryID)
+	err = repo.Delete(context.Background(), countryID)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/cache"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/usecase"
 	"github.com/gin-gonic/gin"
@@ -36,7 +37,7 @@ func (h *CountryHandler) CreateCountry(c *gin.Context) {
 		return
 	}
 
-	err := h.usecase.CreateCountry(input)
+	err := h.usecase.CreateCountry(c.Request.Context(), input)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -51,16 +52,19 @@ func (h *CountryHandler) CreateCountry(c *gin.Context) {
 // @Description Get all countries
 // @Produce json
 // @Tags country
+// @Param If-None-Match header string false "ETag of a previous response"
 // @Success 200 {array} domain.Country
+// @Header 200 {string} ETag "Hash of the body"
+// @Success 304 "Not modified since the ETag of If-None-Match"
 // @Router /api/v1/countries [get]
 func (h *CountryHandler) GetAllCountries(c *gin.Context) {
-	countries, err := h.usecase.GetAll()
+	countries, err := h.usecase.GetAll(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, countries)
+	cache.JSON(c, http.StatusOK, countries)
 }
 
 // GetCountryByID handles the HTTP GET request to retrieve a country by its ID.
@@ -79,7 +83,7 @@ func (h *CountryHandler) GetCountryByID(c *gin.Context) {
 		return
 	}
 
-	country, err := h.usecase.GetCountryByID(uint(id))
+	country, err := h.usecase.GetCountryByID(c.Request.Context(), uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Country not found"})
 		return
@@ -112,7 +116,7 @@ func (h *CountryHandler) UpdateCountry(c *gin.Context) {
 		return
 	}
 
-	if err := h.usecase.UpdateCountry(uint(id), input); err != nil {
+	if err := h.usecase.UpdateCountry(c.Request.Context(), uint(id), input); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -135,7 +139,7 @@ func (h *CountryHandler) DeleteCountry(c *gin.Context) {
 		return
 	}
 
-	err = h.usecase.DeleteCountry(uint(id))
+	err = h.usecase.DeleteCountry(c.Request.Context(), uint(id))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/cache"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/storage"
@@ -8,35 +10,43 @@ import (
 
 // CountryUsecaseInterface defines the methods that any use case implementation must provide.
 type CountryUsecaseInterface interface {
-	CreateCountry(input dto.CountryCreateDTO) error
-	GetCountryByID(id uint) (*dto.CountryResponseDTO, error)
-	UpdateCountry(id uint, input dto.CountryUpdateDTO) error
-	DeleteCountry(id uint) error
-	GetAll() ([]domain.Country, error)
+	CreateCountry(ctx context.Context, input dto.CountryCreateDTO) error
+	GetCountryByID(ctx context.Context, id uint) (*dto.CountryResponseDTO, error)
+	UpdateCountry(ctx context.Context, id uint, input dto.CountryUpdateDTO) error
+	DeleteCountry(ctx context.Context, id uint) error
+	GetAll(ctx context.Context) ([]domain.Country, error)
 }
 
+// allCountriesKey caches the country list, changing a country deletes it
+const allCountriesKey = "countries:all"
+
 // CountryUsecase handles the business logic for countries.
 type CountryUsecase struct {
 	CountryRepo storage.CountryRepositoryInterface
+	cache       cache.Cache
 }
 
-// NewCountryUsecase creates a new instance of CountryUsecase.
-func NewCountryUsecase(CountryRepo storage.CountryRepositoryInterface) *CountryUsecase {
-	return &CountryUsecase{CountryRepo: CountryRepo}
+// NewCountryUsecase creates a new instance of CountryUsecase, the country list is kept in cache.
+func NewCountryUsecase(CountryRepo storage.CountryRepositoryInterface, cache cache.Cache) *CountryUsecase {
+	return &CountryUsecase{CountryRepo: CountryRepo, cache: cache}
 }
 
 // CreateCountry creates a new country using the provided DTO.
-func (u *CountryUsecase) CreateCountry(input dto.CountryCreateDTO) error {
+func (u *CountryUsecase) CreateCountry(ctx context.Context, input dto.CountryCreateDTO) error {
 	country := &domain.Country{
 		Name:   input.Name,
 		Status: input.Status,
 	}
-	err := u.CountryRepo.Create(country)
-	return err
+	if err := u.CountryRepo.Create(ctx, country); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, u.cache, allCountriesKey)
+	return nil
 }
 
-func (u *CountryUsecase) GetAll() ([]domain.Country, error) {
-	countries, err := u.CountryRepo.GetAll()
+// GetAll returns every country, from the cache while it holds them.
+func (u *CountryUsecase) GetAll(ctx context.Context) ([]domain.Country, error) {
+	countries, err := cache.Load(ctx, u.cache, allCountriesKey, u.CountryRepo.GetAll)
 	if err != nil {
 		return nil, err
 	}
@@ -44,8 +54,8 @@ func (u *CountryUsecase) GetAll() ([]domain.Country, error) {
 }
 
 // GetCountryByID retrieves a country by its ID.
-func (u *CountryUsecase) GetCountryByID(id uint) (*dto.CountryResponseDTO, error) {
-	country, err := u.CountryRepo.GetByID(id)
+func (u *CountryUsecase) GetCountryByID(ctx context.Context, id uint) (*dto.CountryResponseDTO, error) {
+	country, err := u.CountryRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -57,17 +67,25 @@ func (u *CountryUsecase) GetCountryByID(id uint) (*dto.CountryResponseDTO, error
 }
 
 // UpdateCountry updates a country using the provided DTO.
-func (u *CountryUsecase) UpdateCountry(id uint, input dto.CountryUpdateDTO) error {
-	country, err := u.CountryRepo.GetByID(id)
+func (u *CountryUsecase) UpdateCountry(ctx context.Context, id uint, input dto.CountryUpdateDTO) error {
+	country, err := u.CountryRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 	country.Name = input.Name
 	country.Status = input.Status
-	return u.CountryRepo.Update(country)
+	if err := u.CountryRepo.Update(ctx, country); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, u.cache, allCountriesKey)
+	return nil
 }
 
 // DeleteCountry deletes a country by its ID.
-func (u *CountryUsecase) DeleteCountry(id uint) error {
-	return u.CountryRepo.Delete(id)
+func (u *CountryUsecase) DeleteCountry(ctx context.Context, id uint) error {
+	if err := u.CountryRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, u.cache, allCountriesKey)
+	return nil
 }
@@ -1,9 +1,11 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/cache"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/dto"
 	"github.com/stretchr/testify/assert"
@@ -16,32 +18,44 @@ type MockCountryRepository struct {
 }
 
 // Create is a mock method for creating a country.
-func (m *MockCountryRepository) Create(country *domain.Country) error {
+func (m *MockCountryRepository) Create(ctx context.Context, country *domain.Country) error {
 	args := m.Called(country)
 	return args.Error(0)
 }
 
+// GetAll is a mock method for listing the countries.
+func (m *MockCountryRepository) GetAll(ctx context.Context) ([]domain.Country, error) {
+	args := m.Called()
+	countries, _ := args.Get(0).([]domain.Country)
+	return countries, args.Error(1)
+}
+
 // GetByID is a mock method for getting a country by ID.
-func (m *MockCountryRepository) GetByID(id uint) (*domain.Country, error) {
+func (m *MockCountryRepository) GetByID(ctx context.Context, id uint) (*domain.Country, error) {
 	args := m.Called(id)
-	return args.Get(0).(*domain.Country), args.Error(1)
+	country, _ := args.Get(0).(*domain.Country)
+	return country, args.Error(1)
 }
 
 // Update is a mock method for updating a country.
-func (m *MockCountryRepository) Update(country *domain.Country) error {
+func (m *MockCountryRepository) Update(ctx context.Context, country *domain.Country) error {
 	args := m.Called(country)
 	return args.Error(0)
 }
 
 // Delete is a mock method for deleting a country.
-func (m *MockCountryRepository) Delete(id uint) error {
+func (m *MockCountryRepository) Delete(ctx context.Context, id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
+func newCountryCache() cache.Cache {
+	return cache.NewLRU(cache.DefaultSize, cache.DefaultTTL)
+}
+
 func TestCreateCountry(t *testing.T) {
 	mockRepo := new(MockCountryRepository)
-	usecase := NewCountryUsecase(mockRepo)
+	usecase := NewCountryUsecase(mockRepo, newCountryCache())
 
 	input := dto.CountryCreateDTO{
 		Name: "TestCountry",
@@ -54,7 +68,7 @@ func TestCreateCountry(t *testing.T) {
 
 	mockRepo.On("Create", expectedCountry).Return(nil)
 
-	err := usecase.CreateCountry(input)
+	err := usecase.CreateCountry(context.Background(), input)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
@@ -62,25 +76,26 @@ func TestCreateCountry(t *testing.T) {
 
 func TestGetCountryByID(t *testing.T) {
 	mockRepo := new(MockCountryRepository)
-	usecase := NewCountryUsecase(mockRepo)
+	usecase := NewCountryUsecase(mockRepo, newCountryCache())
 
 	expectedCountry := &domain.Country{
-		Id:   1,
-		Name: "TestCountry",
+		Id:     1,
+		Name:   "TestCountry",
+		Status: 1,
 	}
 
 	mockRepo.On("GetByID", uint(1)).Return(expectedCountry, nil)
 
-	country, err := usecase.GetCountryByID(1)
+	country, err := usecase.GetCountryByID(context.Background(), 1)
 
 	assert.NoError(t, err)
-	assert.Equal(t, expectedCountry, country)
+	assert.Equal(t, &dto.CountryResponseDTO{Name: "TestCountry", Status: 1}, country)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestUpdateCountry(t *testing.T) {
 	mockRepo := new(MockCountryRepository)
-	usecase := NewCountryUsecase(mockRepo)
+	usecase := NewCountryUsecase(mockRepo, newCountryCache())
 
 	input := dto.CountryUpdateDTO{
 		Name: "UpdatedCountry",
@@ -100,18 +115,18 @@ func TestUpdateCountry(t *testing.T) {
 	mockRepo.On("GetByID", uint(1)).Return(existingCountry, nil)
 	mockRepo.On("Update", updatedCountry).Return(nil)
 
-	err := usecase.UpdateCountry(1, input)
+	err := usecase.UpdateCountry(context.Background(), 1, input)
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestDeleteCountry(t *testing.T) {
 	mockRepo := new(MockCountryRepository)
-	usecase := NewCountryUsecase(mockRepo)
+	usecase := NewCountryUsecase(mockRepo, newCountryCache())
 
 	mockRepo.On("Delete", uint(1)).Return(nil)
 
-	err := usecase.DeleteCountry(1)
+	err := usecase.DeleteCountry(context.Background(), 1)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
@@ -119,13 +134,79 @@ func TestDeleteCountry(t *testing.T) {
 
 func TestGetCountryByID_NotFound(t *testing.T) {
 	mockRepo := new(MockCountryRepository)
-	usecase := NewCountryUsecase(mockRepo)
+	usecase := NewCountryUsecase(mockRepo, newCountryCache())
 
 	mockRepo.On("GetByID", uint(1)).Return(nil, errors.New("not found"))
 
-	country, err := usecase.GetCountryByID(1)
+	country, err := usecase.GetCountryByID(context.Background(), 1)
 
 	assert.Error(t, err)
 	assert.Nil(t, country)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestGetAllCountries_Cached(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockCountryRepository)
+	usecase := NewCountryUsecase(mockRepo, newCountryCache())
+
+	countries := []domain.Country{{Id: 1, Name: "Vietnam", Status: 1}}
+	mockRepo.On("GetAll").Return(countries, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		result, err := usecase.GetAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, countries, result)
+	}
+	mockRepo.AssertNumberOfCalls(t, "GetAll", 1)
+}
+
+func TestGetAllCountries_InvalidatedOnChange(t *testing.T) {
+	ctx := context.Background()
+	existing := &domain.Country{Id: 1, Name: "Vietnam", Status: 1}
+	changes := map[string]func(u *CountryUsecase, repo *MockCountryRepository) error{
+		"create": func(u *CountryUsecase, repo *MockCountryRepository) error {
+			repo.On("Create", mock.Anything).Return(nil)
+			return u.CreateCountry(ctx, dto.CountryCreateDTO{Name: "France", Status: 1})
+		},
+		"update": func(u *CountryUsecase, repo *MockCountryRepository) error {
+			repo.On("GetByID", uint(1)).Return(existing, nil)
+			repo.On("Update", mock.Anything).Return(nil)
+			return u.UpdateCountry(ctx, 1, dto.CountryUpdateDTO{Name: "Viet Nam", Status: 1})
+		},
+		"delete": func(u *CountryUsecase, repo *MockCountryRepository) error {
+			repo.On("Delete", uint(1)).Return(nil)
+			return u.DeleteCountry(ctx, 1)
+		},
+	}
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(MockCountryRepository)
+			usecase := NewCountryUsecase(mockRepo, newCountryCache())
+			mockRepo.On("GetAll").Return([]domain.Country{*existing}, nil)
+
+			_, err := usecase.GetAll(ctx)
+			assert.NoError(t, err)
+			assert.NoError(t, change(usecase, mockRepo))
+			_, err = usecase.GetAll(ctx)
+			assert.NoError(t, err)
+
+			mockRepo.AssertNumberOfCalls(t, "GetAll", 2)
+		})
+	}
+}
+
+func TestGetAllCountries_ErrorNotCached(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockCountryRepository)
+	usecase := NewCountryUsecase(mockRepo, newCountryCache())
+
+	mockRepo.On("GetAll").Return(nil, errors.New("timeout")).Once()
+	mockRepo.On("GetAll").Return([]domain.Country{{Id: 1, Name: "Vietnam"}}, nil).Once()
+
+	_, err := usecase.GetAll(ctx)
+	assert.EqualError(t, err, "timeout")
+	countries, err := usecase.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, countries, 1)
+}
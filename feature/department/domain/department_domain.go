@@ -7,9 +7,43 @@ import (
 // Department struct that interacts with databases (GORM)
 type Department struct {
 	Id        uint      `gorm:"primaryKey" json:"id"`
+	ParentID  *uint     `gorm:"index" json:"parent_id"`
 	Name      string    `gorm:"size:255;not null;unique" json:"name"`
 	Address   string    `json:"location"`
 	Status    uint      `gorm:"not null" json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// DepartmentManager links a volunteer's user account to a department they manage
+type DepartmentManager struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	DepartmentID uint      `gorm:"index;not null" json:"department_id"`
+	UserID       int       `gorm:"index;not null" json:"user_id"`
+	VolunteerID  int       `gorm:"not null" json:"volunteer_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Subtree returns the ids of the given roots and all their descendants
+func Subtree(departments []Department, roots []uint) []uint {
+	children := make(map[uint][]uint)
+	for _, department := range departments {
+		if department.ParentID != nil {
+			children[*department.ParentID] = append(children[*department.ParentID], department.Id)
+		}
+	}
+	seen := make(map[uint]bool)
+	var ids []uint
+	queue := append([]uint{}, roots...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+		queue = append(queue, children[id]...)
+	}
+	return ids
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtree(t *testing.T) {
+	parent := func(id uint) *uint { return &id }
+	departments := []Department{
+		{Id: 1},
+		{Id: 2, ParentID: parent(1)},
+		{Id: 3, ParentID: parent(2)},
+		{Id: 4, ParentID: parent(1)},
+		{Id: 5},
+		{Id: 6, ParentID: parent(5)},
+	}
+
+	assert.ElementsMatch(t, []uint{1, 2, 3, 4}, Subtree(departments, []uint{1}))
+	assert.ElementsMatch(t, []uint{2, 3}, Subtree(departments, []uint{2}))
+	assert.ElementsMatch(t, []uint{2, 3, 5, 6}, Subtree(departments, []uint{2, 5, 3}))
+	assert.Empty(t, Subtree(departments, nil))
+}
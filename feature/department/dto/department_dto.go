@@ -2,14 +2,30 @@ package dto
 
 // DepartmentCreateDTO represents the data transfer object for creating a department.
 type DepartmentCreateDTO struct {
-	Name    string `json:"name" binding:"required"`
-	Address string `json:"location" binding:"required"`
-	Status  uint   `json:"status" binding:"required"`
+	ParentID *uint  `json:"parent_id"`
+	Name     string `json:"name" binding:"required"`
+	Address  string `json:"location" binding:"required"`
+	Status   uint   `json:"status" binding:"required"`
 }
 
 // DepartmentUpdateDTO represents the data transfer object for updating a department.
 type DepartmentUpdateDTO struct {
-	Name    string `json:"name" binding:"required"`
-	Address string `json:"location" binding:"required"`
-	Status  uint   `json:"status" binding:"required"`
+	ParentID *uint  `json:"parent_id"`
+	Name     string `json:"name" binding:"required"`
+	Address  string `json:"location" binding:"required"`
+	Status   uint   `json:"status" binding:"required"`
+}
+
+// DepartmentTreeDTO represents a department with its sub-departments.
+type DepartmentTreeDTO struct {
+	Id       uint                 `json:"id"`
+	Name     string               `json:"name"`
+	Address  string               `json:"location"`
+	Status   uint                 `json:"status"`
+	Children []*DepartmentTreeDTO `json:"children"`
+}
+
+// DepartmentManagerCreateDTO represents the data transfer object for assigning a manager.
+type DepartmentManagerCreateDTO struct {
+	VolunteerID int `json:"volunteer_id" binding:"required"`
 }
@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"context"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
 	"gorm.io/gorm"
 )
 
@@ -16,29 +18,87 @@ func NewDepartmentRepository(db *gorm.DB) *DepartmentRepository {
 }
 
 // Create inserts a new department record into the database.
-func (r *DepartmentRepository) Create(department *domain.Department) error {
-	return r.DB.Create(department).Error
+func (r *DepartmentRepository) Create(ctx context.Context, department *domain.Department) error {
+	return uow.Conn(ctx, r.DB).Create(department).Error
 }
 
-func (r *DepartmentRepository) GetAll() ([]domain.Department, error) {
+func (r *DepartmentRepository) GetAll(ctx context.Context) ([]domain.Department, error) {
 	var departments []domain.Department
-	err := r.DB.Find(&departments).Error
+	err := uow.Conn(ctx, r.DB).Find(&departments).Error
 	return departments, err
 }
 
 // GetByID retrieves a department record by its ID from the database.
-func (r *DepartmentRepository) GetByID(id uint) (*domain.Department, error) {
+func (r *DepartmentRepository) GetByID(ctx context.Context, id uint) (*domain.Department, error) {
 	var department domain.Department
-	err := r.DB.First(&department, id).Error
+	err := uow.Conn(ctx, r.DB).First(&department, id).Error
 	return &department, err
 }
 
 // Update updates a department record in the database.
-func (r *DepartmentRepository) Update(department *domain.Department) error {
-	return r.DB.Save(department).Error
+func (r *DepartmentRepository) Update(ctx context.Context, department *domain.Department) error {
+	return uow.Conn(ctx, r.DB).Save(department).Error
 }
 
-// Delete deletes a department record from the database.
-func (r *DepartmentRepository) Delete(id uint) error {
-	return r.DB.Delete(&domain.Department{}, id).Error
+// Delete deletes a department record and its manager assignments from the database.
+func (r *DepartmentRepository) Delete(ctx context.Context, id uint) error {
+	return uow.Conn(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("department_id = ?", id).Delete(&domain.DepartmentManager{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Department{}, id).Error
+	})
+}
+
+// HasChildren reports whether a department has sub-departments.
+func (r *DepartmentRepository) HasChildren(ctx context.Context, id uint) (bool, error) {
+	var count int64
+	err := uow.Conn(ctx, r.DB).Model(&domain.Department{}).Where("parent_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// FindVolunteerUserID returns the user account of an active volunteer.
+func (r *DepartmentRepository) FindVolunteerUserID(ctx context.Context, volunteerID int) (int, error) {
+	var userIDs []int
+	err := uow.Conn(ctx, r.DB).Table("volunteer_details").
+		Where("id = ? AND status = ?", volunteerID, 1).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(userIDs) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return userIDs[0], nil
+}
+
+// CreateManager inserts a new department manager record into the database.
+func (r *DepartmentRepository) CreateManager(ctx context.Context, manager *domain.DepartmentManager) error {
+	return uow.Conn(ctx, r.DB).Create(manager).Error
+}
+
+// DeleteManager removes a manager from a department.
+func (r *DepartmentRepository) DeleteManager(ctx context.Context, departmentID uint, userID int) error {
+	result := uow.Conn(ctx, r.DB).Where("department_id = ? AND user_id = ?", departmentID, userID).Delete(&domain.DepartmentManager{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListManagers retrieves the managers of a department.
+func (r *DepartmentRepository) ListManagers(ctx context.Context, departmentID uint) ([]domain.DepartmentManager, error) {
+	var managers []domain.DepartmentManager
+	err := uow.Conn(ctx, r.DB).Where("department_id = ?", departmentID).Order("id").Find(&managers).Error
+	return managers, err
+}
+
+// ListManagedDepartmentIDs retrieves the departments a user was directly assigned to manage.
+func (r *DepartmentRepository) ListManagedDepartmentIDs(ctx context.Context, userID int) ([]uint, error) {
+	var ids []uint
+	err := uow.Conn(ctx, r.DB).Model(&domain.DepartmentManager{}).Where("user_id = ?", userID).Pluck("department_id", &ids).Error
+	return ids, err
 }
@@ -1,12 +1,15 @@
 package transport
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/cache"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // DepartmentHandler handles the HTTP requests for departments.
@@ -28,15 +31,24 @@ func NewDepartmentHandler(usecase *usecase.DepartmentUsecase) *DepartmentHandler
 // @Tags department
 // @Param department body dto.DepartmentCreateDTO true "Department data"
 // @Success 201 {object} domain.Department
+// @Security bearerToken
 // @Router /api/v1/departments [post]
 func (h *DepartmentHandler) CreateDepartment(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	var input dto.DepartmentCreateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	department, err := h.usecase.CreateDepartment(input)
+	department, err := h.usecase.CreateDepartment(c.Request.Context(), input)
+	if errors.Is(err, usecase.ErrParentNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -51,16 +63,19 @@ func (h *DepartmentHandler) CreateDepartment(c *gin.Context) {
 // @Description Get all departments
 // @Produce json
 // @Tags department
+// @Param If-None-Match header string false "ETag of a previous response"
 // @Success 200 {array} domain.Department
+// @Header 200 {string} ETag "Hash of the body"
+// @Success 304 "Not modified since the ETag of If-None-Match"
 // @Router /api/v1/departments [get]
 func (h *DepartmentHandler) GetAllDepartments(c *gin.Context) {
-	departments, err := h.usecase.GetAllDepartments()
+	departments, err := h.usecase.GetAllDepartments(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, departments)
+	cache.JSON(c, http.StatusOK, departments)
 }
 
 // GetDepartmentByID handles the HTTP GET request to retrieve a department by its ID.
@@ -79,7 +94,7 @@ func (h *DepartmentHandler) GetDepartmentByID(c *gin.Context) {
 		return
 	}
 
-	department, err := h.usecase.GetDepartmentByID(uint(id))
+	department, err := h.usecase.GetDepartmentByID(c.Request.Context(), uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Department not found"})
 		return
@@ -98,8 +113,13 @@ func (h *DepartmentHandler) GetDepartmentByID(c *gin.Context) {
 // @Param id path int true "Department ID"
 // @Param department body dto.DepartmentUpdateDTO true "Department data"
 // @Success 200 {object} domain.Department
+// @Security bearerToken
 // @Router /api/v1/departments/{id} [put]
 func (h *DepartmentHandler) UpdateDepartment(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
@@ -112,7 +132,11 @@ func (h *DepartmentHandler) UpdateDepartment(c *gin.Context) {
 		return
 	}
 
-	department, err := h.usecase.UpdateDepartment(uint(id), input)
+	department, err := h.usecase.UpdateDepartment(c.Request.Context(), uint(id), input)
+	if errors.Is(err, usecase.ErrParentNotFound) || errors.Is(err, usecase.ErrParentCycle) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -129,15 +153,24 @@ func (h *DepartmentHandler) UpdateDepartment(c *gin.Context) {
 // @Tags department
 // @Param id path int true "Department ID"
 // @Success 204
+// @Security bearerToken
 // @Router /api/v1/departments/{id} [delete]
 func (h *DepartmentHandler) DeleteDepartment(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
 		return
 	}
 
-	err = h.usecase.DeleteDepartment(uint(id))
+	err = h.usecase.DeleteDepartment(c.Request.Context(), uint(id))
+	if errors.Is(err, usecase.ErrHasChildren) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -145,3 +178,136 @@ func (h *DepartmentHandler) DeleteDepartment(c *gin.Context) {
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// GetDepartmentTree godoc
+// @Summary Get department tree
+// @Description Get all departments nested under their parent departments
+// @Produce json
+// @Tags department
+// @Success 200 {array} dto.DepartmentTreeDTO
+// @Router /api/v1/departments/tree [get]
+func (h *DepartmentHandler) GetDepartmentTree(c *gin.Context) {
+	tree, err := h.usecase.GetDepartmentTree(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// GetManagers godoc
+// @Summary Get department managers
+// @Description Get the volunteers managing a department
+// @Produce json
+// @Tags department
+// @Param id path int true "Department ID"
+// @Success 200 {array} domain.DepartmentManager
+// @Router /api/v1/departments/{id}/managers [get]
+func (h *DepartmentHandler) GetManagers(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		return
+	}
+
+	managers, err := h.usecase.GetManagers(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, managers)
+}
+
+// AssignManager godoc
+// @Summary Assign department manager
+// @Description Make an active volunteer a manager of the department and its sub-departments
+// @Accept json
+// @Produce json
+// @Tags department
+// @Param id path int true "Department ID"
+// @Param manager body dto.DepartmentManagerCreateDTO true "Manager data"
+// @Success 201 {object} domain.DepartmentManager
+// @Security bearerToken
+// @Router /api/v1/departments/{id}/managers [post]
+func (h *DepartmentHandler) AssignManager(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		return
+	}
+
+	var input dto.DepartmentManagerCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	manager, err := h.usecase.AssignManager(c.Request.Context(), uint(id), input)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Department not found"})
+		return
+	}
+	if errors.Is(err, usecase.ErrVolunteerInvalid) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, manager)
+}
+
+// RemoveManager godoc
+// @Summary Remove department manager
+// @Description Remove a manager from the department
+// @Produce json
+// @Tags department
+// @Param id path int true "Department ID"
+// @Param userId path int true "Manager user ID"
+// @Success 204
+// @Security bearerToken
+// @Router /api/v1/departments/{id}/managers/{userId} [delete]
+func (h *DepartmentHandler) RemoveManager(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	err = h.usecase.RemoveManager(c.Request.Context(), uint(id), userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Manager not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func checkAdminRole(c *gin.Context) error {
+	roleId, exists := c.Get("roleId")
+	if !exists || roleId.(int) != 1 {
+		return errors.New("forbidden: only admins can perform this action")
+	}
+	return nil
+}
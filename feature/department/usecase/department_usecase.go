@@ -1,55 +1,204 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/cache"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/storage"
 )
 
+var (
+	ErrParentNotFound   = errors.New("parent department not found")
+	ErrParentCycle      = errors.New("a department cannot be moved under itself or its sub-departments")
+	ErrHasChildren      = errors.New("department has sub-departments")
+	ErrVolunteerInvalid = errors.New("volunteer not found or inactive")
+)
+
+// allDepartmentsKey caches the department list the list and the tree are built from,
+// changing a department deletes it
+const allDepartmentsKey = "departments:all"
+
 // DepartmentUsecase handles the business logic for departments.
 type DepartmentUsecase struct {
-	repo *storage.DepartmentRepository
+	repo  *storage.DepartmentRepository
+	cache cache.Cache
 }
 
-// NewDepartmentUsecase creates a new instance of DepartmentUsecase.
-func NewDepartmentUsecase(repo *storage.DepartmentRepository) *DepartmentUsecase {
-	return &DepartmentUsecase{repo: repo}
+// NewDepartmentUsecase creates a new instance of DepartmentUsecase, the department list is kept in cache.
+func NewDepartmentUsecase(repo *storage.DepartmentRepository, cache cache.Cache) *DepartmentUsecase {
+	return &DepartmentUsecase{repo: repo, cache: cache}
 }
 
 // CreateDepartment creates a new department using the provided DTO.
-func (u *DepartmentUsecase) CreateDepartment(input dto.DepartmentCreateDTO) (*domain.Department, error) {
+func (u *DepartmentUsecase) CreateDepartment(ctx context.Context, input dto.DepartmentCreateDTO) (*domain.Department, error) {
+	if input.ParentID != nil {
+		if _, err := u.repo.GetByID(ctx, *input.ParentID); err != nil {
+			return nil, ErrParentNotFound
+		}
+	}
 	department := &domain.Department{
-		Name:    input.Name,
-		Address: input.Address,
-		Status:  input.Status,
+		ParentID: input.ParentID,
+		Name:     input.Name,
+		Address:  input.Address,
+		Status:   input.Status,
+	}
+	if err := u.repo.Create(ctx, department); err != nil {
+		return department, err
 	}
-	err := u.repo.Create(department)
-	return department, err
+	cache.Invalidate(ctx, u.cache, allDepartmentsKey)
+	return department, nil
 }
 
-func (u *DepartmentUsecase) GetAllDepartments() ([]domain.Department, error) {
-	return u.repo.GetAll()
+// GetAllDepartments returns every department, from the cache while it holds them.
+func (u *DepartmentUsecase) GetAllDepartments(ctx context.Context) ([]domain.Department, error) {
+	return cache.Load(ctx, u.cache, allDepartmentsKey, u.repo.GetAll)
 }
 
 // GetDepartmentByID retrieves a department by its ID.
-func (u *DepartmentUsecase) GetDepartmentByID(id uint) (*domain.Department, error) {
-	return u.repo.GetByID(id)
+func (u *DepartmentUsecase) GetDepartmentByID(ctx context.Context, id uint) (*domain.Department, error) {
+	return u.repo.GetByID(ctx, id)
 }
 
 // UpdateDepartment updates a department using the provided DTO.
-func (u *DepartmentUsecase) UpdateDepartment(id uint, input dto.DepartmentUpdateDTO) (*domain.Department, error) {
-	department, err := u.repo.GetByID(id)
+func (u *DepartmentUsecase) UpdateDepartment(ctx context.Context, id uint, input dto.DepartmentUpdateDTO) (*domain.Department, error) {
+	department, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if input.ParentID != nil {
+		if err := u.checkParent(ctx, id, *input.ParentID); err != nil {
+			return nil, err
+		}
+	}
+	department.ParentID = input.ParentID
 	department.Name = input.Name
 	department.Address = input.Address
 	department.Status = input.Status
-	err = u.repo.Update(department)
-	return department, err
+	if err := u.repo.Update(ctx, department); err != nil {
+		return department, err
+	}
+	cache.Invalidate(ctx, u.cache, allDepartmentsKey)
+	return department, nil
+}
+
+// DeleteDepartment deletes a department by its ID, departments with sub-departments are kept.
+func (u *DepartmentUsecase) DeleteDepartment(ctx context.Context, id uint) error {
+	hasChildren, err := u.repo.HasChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hasChildren {
+		return ErrHasChildren
+	}
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, u.cache, allDepartmentsKey)
+	return nil
+}
+
+// GetDepartmentTree returns all departments nested under their parents.
+func (u *DepartmentUsecase) GetDepartmentTree(ctx context.Context) ([]*dto.DepartmentTreeDTO, error) {
+	departments, err := u.GetAllDepartments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildTree(departments), nil
 }
 
-// DeleteDepartment deletes a department by its ID.
-func (u *DepartmentUsecase) DeleteDepartment(id uint) error {
-	return u.repo.Delete(id)
+// AssignManager makes an active volunteer a manager of the department.
+func (u *DepartmentUsecase) AssignManager(ctx context.Context, departmentID uint, input dto.DepartmentManagerCreateDTO) (*domain.DepartmentManager, error) {
+	if _, err := u.repo.GetByID(ctx, departmentID); err != nil {
+		return nil, err
+	}
+	userID, err := u.repo.FindVolunteerUserID(ctx, input.VolunteerID)
+	if err != nil {
+		return nil, ErrVolunteerInvalid
+	}
+	manager := &domain.DepartmentManager{
+		DepartmentID: departmentID,
+		UserID:       userID,
+		VolunteerID:  input.VolunteerID,
+	}
+	err = u.repo.CreateManager(ctx, manager)
+	return manager, err
+}
+
+// RemoveManager removes a manager from the department.
+func (u *DepartmentUsecase) RemoveManager(ctx context.Context, departmentID uint, userID int) error {
+	return u.repo.DeleteManager(ctx, departmentID, userID)
+}
+
+// GetManagers retrieves the managers of a department.
+func (u *DepartmentUsecase) GetManagers(ctx context.Context, departmentID uint) ([]domain.DepartmentManager, error) {
+	return u.repo.ListManagers(ctx, departmentID)
+}
+
+// ManagedDepartmentIDs returns every department a user manages, including sub-departments.
+func (u *DepartmentUsecase) ManagedDepartmentIDs(ctx context.Context, userID int) ([]int, error) {
+	roots, err := u.repo.ListManagedDepartmentIDs(ctx, userID)
+	if err != nil || len(roots) == 0 {
+		return nil, err
+	}
+	departments, err := u.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int
+	for _, id := range domain.Subtree(departments, roots) {
+		ids = append(ids, int(id))
+	}
+	return ids, nil
+}
+
+// checkParent rejects a parent that does not exist or would create a cycle.
+func (u *DepartmentUsecase) checkParent(ctx context.Context, id uint, parentID uint) error {
+	departments, err := u.repo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, department := range departments {
+		if department.Id == parentID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrParentNotFound
+	}
+	for _, descendant := range domain.Subtree(departments, []uint{id}) {
+		if descendant == parentID {
+			return ErrParentCycle
+		}
+	}
+	return nil
+}
+
+func buildTree(departments []domain.Department) []*dto.DepartmentTreeDTO {
+	nodes := make(map[uint]*dto.DepartmentTreeDTO, len(departments))
+	for _, department := range departments {
+		nodes[department.Id] = &dto.DepartmentTreeDTO{
+			Id:       department.Id,
+			Name:     department.Name,
+			Address:  department.Address,
+			Status:   department.Status,
+			Children: []*dto.DepartmentTreeDTO{},
+		}
+	}
+	roots := []*dto.DepartmentTreeDTO{}
+	for _, department := range departments {
+		node := nodes[department.Id]
+		if department.ParentID != nil {
+			if parent, ok := nodes[*department.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
 }
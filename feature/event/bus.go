@@ -0,0 +1,151 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"gorm.io/gorm"
+)
+
+// Bus dispatches events to the features subscribed to them.
+//
+// Sync subscribers run inside the transaction of the publisher, an error rolls the whole
+// transaction back, so they are for side effects that must be atomic with the change,
+// like creating the volunteer of an approved request. They may publish further events on the same Tx.
+//
+// Async subscribers run in their own goroutine once the transaction committed, the outermost
+// one when it runs inside a unit of work, and never when it rolled back, so they are for notifications, webhooks and anything that may be slow or fail.
+// They run in no particular order and a failure is theirs to log.
+type Bus struct {
+	db      *gorm.DB
+	mu      sync.RWMutex
+	sync    map[string][]func(tx *Tx, e Event) error
+	all     []func(tx *Tx, e Event) error
+	async   map[string][]func(e Event)
+	running sync.WaitGroup
+}
+
+// NewBus creates a Bus whose transactions run on db.
+func NewBus(db *gorm.DB) *Bus {
+	return &Bus{
+		db:    db,
+		sync:  map[string][]func(tx *Tx, e Event) error{},
+		async: map[string][]func(e Event){},
+	}
+}
+
+// Subscribe runs handler inside the publishing transaction for every event of type E.
+func Subscribe[E Event](b *Bus, handler func(tx *Tx, e E) error) {
+	var zero E
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sync[zero.Name()] = append(b.sync[zero.Name()], func(tx *Tx, e Event) error {
+		return handler(tx, e.(E))
+	})
+}
+
+// SubscribeAsync runs handler after commit for every event of type E.
+func SubscribeAsync[E Event](b *Bus, handler func(e E)) {
+	var zero E
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.async[zero.Name()] = append(b.async[zero.Name()], func(e Event) {
+		handler(e.(E))
+	})
+}
+
+// SubscribeAll runs handler inside the publishing transaction for every event, after the typed subscribers.
+func (b *Bus) SubscribeAll(handler func(tx *Tx, e Event) error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.all = append(b.all, handler)
+}
+
+// InTx runs fn in a database transaction, joining the unit of work of ctx if any.
+// The events fn publishes reach the async subscribers once it committed.
+func (b *Bus) InTx(ctx context.Context, fn func(tx *Tx) error) error {
+	return uow.Do(ctx, b.db, func(ctx context.Context) error {
+		tx := &Tx{bus: b, ctx: ctx}
+		if err := fn(tx); err != nil {
+			return err
+		}
+		uow.AfterCommit(ctx, func() {
+			for _, e := range tx.published {
+				b.dispatch(e)
+			}
+		})
+		return nil
+	})
+}
+
+// Wait blocks until the running async subscribers returned.
+func (b *Bus) Wait() {
+	b.running.Wait()
+}
+
+// Drain waits for the running async subscribers until ctx is done, as the Stop of a worker.
+func (b *Bus) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("async subscribers still running: %w", ctx.Err())
+	}
+}
+
+func (b *Bus) dispatch(e Event) {
+	b.mu.RLock()
+	handlers := b.async[e.Name()]
+	b.mu.RUnlock()
+	for _, handler := range handlers {
+		b.running.Add(1)
+		go func(handler func(e Event)) {
+			defer b.running.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("%s subscriber panicked: %v", e.Name(), r)
+				}
+			}()
+			handler(e)
+		}(handler)
+	}
+}
+
+// Tx is a transaction events can be published in.
+type Tx struct {
+	bus       *Bus
+	ctx       context.Context
+	published []Event
+}
+
+// DB is the transaction to run queries in.
+func (t *Tx) DB() *gorm.DB {
+	return uow.Conn(t.ctx, t.bus.db)
+}
+
+// Context carries the transaction, repositories called with it join the transaction.
+func (t *Tx) Context() context.Context {
+	return t.ctx
+}
+
+// Publish runs the sync subscribers of e, their first error is returned and should roll the transaction back.
+func (t *Tx) Publish(e Event) error {
+	t.bus.mu.RLock()
+	handlers := append(append([]func(tx *Tx, e Event) error{}, t.bus.sync[e.Name()]...), t.bus.all...)
+	t.bus.mu.RUnlock()
+	t.published = append(t.published, e)
+	for _, handler := range handlers {
+		if err := handler(t, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
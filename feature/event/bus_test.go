@@ -0,0 +1,88 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to setup mock db: %v", err)
+	}
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return gormDB, mock
+}
+
+func TestInTxDispatchesAsyncAfterCommit(t *testing.T) {
+	db, mock := setupMockDB(t)
+	bus := NewBus(db)
+	var seen []string
+	Subscribe(bus, func(tx *Tx, e RequestApproved) error {
+		seen = append(seen, "sync")
+		return tx.Publish(VolunteerCreated{UserID: e.UserID})
+	})
+	bus.SubscribeAll(func(tx *Tx, e Event) error {
+		seen = append(seen, "all "+e.Name())
+		return nil
+	})
+	created := make(chan VolunteerCreated, 1)
+	SubscribeAsync(bus, func(e VolunteerCreated) { created <- e })
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	err := bus.InTx(context.Background(), func(tx *Tx) error {
+		return tx.Publish(RequestApproved{RequestID: 1, UserID: 4})
+	})
+	bus.Wait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sync", "all " + NameVolunteerCreated, "all " + NameRequestApproved}, seen)
+	assert.Equal(t, VolunteerCreated{UserID: 4}, <-created)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInTxRollsBackOnSubscriberError(t *testing.T) {
+	db, mock := setupMockDB(t)
+	bus := NewBus(db)
+	failure := errors.New("User has no department")
+	Subscribe(bus, func(tx *Tx, e RequestApproved) error { return failure })
+	called := false
+	SubscribeAsync(bus, func(e RequestApproved) { called = true })
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	err := bus.InTx(context.Background(), func(tx *Tx) error {
+		return tx.Publish(RequestApproved{RequestID: 1})
+	})
+	bus.Wait()
+
+	assert.ErrorIs(t, err, failure)
+	assert.False(t, called)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDrainWaitsForAsyncSubscribers(t *testing.T) {
+	bus := NewBus(nil)
+	release := make(chan struct{})
+	SubscribeAsync(bus, func(e VolunteerCreated) { <-release })
+	bus.dispatch(VolunteerCreated{UserID: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bus.Drain(ctx), context.DeadlineExceeded)
+
+	close(release)
+	assert.NoError(t, bus.Drain(context.Background()))
+}
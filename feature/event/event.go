@@ -0,0 +1,84 @@
+package event
+
+import "time"
+
+// Event is a fact published on the Bus, Name identifies its type
+type Event interface {
+	Name() string
+}
+
+// Event names
+const (
+	NameRequestApproved        = "request.approved"
+	NameRequestRejected        = "request.rejected"
+	NameUserRegistered         = "user.registered"
+	NameVolunteerCreated       = "volunteer.created"
+	NameVolunteerStatusChanged = "volunteer.status_changed"
+	NameVolunteerDeleted       = "volunteer.deleted"
+)
+
+// RequestApproved is published when a reviewer approves a request,
+// DepartmentID is the department of the requester at that time
+type RequestApproved struct {
+	RequestID    int       `json:"request_id"`
+	UserID       int       `json:"user_id"`
+	Type         string    `json:"type"`
+	VerifierID   int       `json:"verifier_id"`
+	DepartmentID *int      `json:"department_id"`
+	SubmittedAt  time.Time `json:"submitted_at"`
+	DecidedAt    time.Time `json:"decided_at"`
+}
+
+func (RequestApproved) Name() string { return NameRequestApproved }
+
+// RequestRejected is published when a reviewer rejects a request
+type RequestRejected struct {
+	RequestID   int       `json:"request_id"`
+	UserID      int       `json:"user_id"`
+	Type        string    `json:"type"`
+	VerifierID  int       `json:"verifier_id"`
+	SubmittedAt time.Time `json:"submitted_at"`
+	DecidedAt   time.Time `json:"decided_at"`
+}
+
+func (RequestRejected) Name() string { return NameRequestRejected }
+
+// UserRegistered is published when someone signs up
+type UserRegistered struct {
+	UserID   int    `json:"user_id"`
+	Email    string `json:"email"`
+	UserName string `json:"name"`
+}
+
+func (UserRegistered) Name() string { return NameUserRegistered }
+
+// VolunteerCreated is published when a user becomes a volunteer, by approval, import or directly
+type VolunteerCreated struct {
+	VolunteerID  int `json:"volunteer_id"`
+	UserID       int `json:"user_id"`
+	DepartmentID int `json:"department_id"`
+	Status       int `json:"status"`
+}
+
+func (VolunteerCreated) Name() string { return NameVolunteerCreated }
+
+// VolunteerStatusChanged is published when a volunteer is activated (status 1) or deactivated
+type VolunteerStatusChanged struct {
+	VolunteerID    int `json:"volunteer_id"`
+	UserID         int `json:"user_id"`
+	DepartmentID   int `json:"department_id"`
+	Status         int `json:"status"`
+	PreviousStatus int `json:"previous_status"`
+}
+
+func (VolunteerStatusChanged) Name() string { return NameVolunteerStatusChanged }
+
+// VolunteerDeleted is published when a volunteer record is removed
+type VolunteerDeleted struct {
+	VolunteerID  int `json:"volunteer_id"`
+	UserID       int `json:"user_id"`
+	DepartmentID int `json:"department_id"`
+	Status       int `json:"status"`
+}
+
+func (VolunteerDeleted) Name() string { return NameVolunteerDeleted }
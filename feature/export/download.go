@@ -0,0 +1,49 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Download answers with the file run writes in format, as an attachment named after name and
+// today's date. An error before the first byte is answered with a 500, after it the connection
+// is dropped so the client sees a failed download instead of a short file.
+func Download(c *gin.Context, name, format string, run func(w io.Writer) error) {
+	contentType, err := ContentType(format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if err := run(c.Writer); err != nil {
+		if !c.Writer.Written() {
+			c.Header("Content-Type", "")
+			c.Header("Content-Disposition", "")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "export failed while streaming", "export", name, "error", err)
+		c.Abort()
+		dropConnection(c)
+	}
+}
+
+// dropConnection closes the client connection under a response already started. Gin recovers
+// http.ErrAbortHandler like any panic and ends the response cleanly, so the connection is
+// hijacked and closed instead, which leaves the chunked body unterminated.
+func dropConnection(c *gin.Context) {
+	conn, _, err := c.Writer.Hijack()
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "could not drop the connection", "error", err)
+		return
+	}
+	conn.Close()
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func downloadServer(run func(w io.Writer) error) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.GET("/export", func(c *gin.Context) {
+		Download(c, "rows", c.DefaultQuery("format", CSV), run)
+	})
+	return httptest.NewServer(r)
+}
+
+func TestDownload_ErrorBeforeWriting(t *testing.T) {
+	server := downloadServer(func(w io.Writer) error {
+		return errors.New("connection lost")
+	})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/export")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Content-Disposition"))
+}
+
+func TestDownload_ErrorWhileStreamingDropsConnection(t *testing.T) {
+	server := downloadServer(func(w io.Writer) error {
+		return Write(CSV, w, []string{"id"}, func(write func([]string) error) error {
+			for i := 0; i < 1000; i++ {
+				if err := write([]string{"a row long enough to fill the response buffer"}); err != nil {
+					return err
+				}
+			}
+			return errors.New("connection lost")
+		})
+	})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/export")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_, err = io.ReadAll(resp.Body)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestDownload_UnsupportedFormat(t *testing.T) {
+	server := downloadServer(func(w io.Writer) error { return nil })
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/export?format=pdf")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
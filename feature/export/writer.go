@@ -0,0 +1,135 @@
+// Package export streams tables as CSV or XLSX files, one row at a time, so an export never
+// holds the whole result in memory.
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Export formats
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+var ErrFormat = errors.New("unsupported export format, use csv or xlsx")
+
+// ContentType returns the media type of format
+func ContentType(format string) (string, error) {
+	switch format {
+	case CSV:
+		return "text/csv", nil
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", nil
+	default:
+		return "", ErrFormat
+	}
+}
+
+// TableWriter receives an export one row at a time, Close finishes the document and Abort drops it.
+// One of them must be called to release the writer.
+type TableWriter interface {
+	WriteRow(values []string) error
+	Close() error
+	Abort()
+}
+
+// NewTableWriter returns a writer for format that outputs to w
+func NewTableWriter(format string, w io.Writer) (TableWriter, error) {
+	switch format {
+	case CSV:
+		return &csvTableWriter{writer: csv.NewWriter(w)}, nil
+	case XLSX:
+		file := excelize.NewFile()
+		stream, err := file.NewStreamWriter("Sheet1")
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return &xlsxTableWriter{file: file, stream: stream, out: w}, nil
+	default:
+		return nil, ErrFormat
+	}
+}
+
+// Write outputs header then every row produced by stream in format
+func Write(format string, w io.Writer, header []string, stream func(write func([]string) error) error) error {
+	table, err := NewTableWriter(format, w)
+	if err != nil {
+		return err
+	}
+	if err := table.WriteRow(header); err != nil {
+		table.Abort()
+		return err
+	}
+	if err := stream(table.WriteRow); err != nil {
+		table.Abort()
+		return err
+	}
+	return table.Close()
+}
+
+type csvTableWriter struct {
+	writer *csv.Writer
+}
+
+func (t *csvTableWriter) WriteRow(values []string) error {
+	return t.writer.Write(escapeFormulas(values))
+}
+
+func (t *csvTableWriter) Close() error {
+	t.writer.Flush()
+	return t.writer.Error()
+}
+
+func (t *csvTableWriter) Abort() {}
+
+// xlsxTableWriter uses the excelize stream writer, which spills rows to a temporary file instead of memory
+type xlsxTableWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	out    io.Writer
+	row    int
+}
+
+func (t *xlsxTableWriter) WriteRow(values []string) error {
+	t.row++
+	cell, err := excelize.CoordinatesToCellName(1, t.row)
+	if err != nil {
+		return err
+	}
+	cells := make([]interface{}, len(values))
+	for i, value := range escapeFormulas(values) {
+		cells[i] = value
+	}
+	return t.stream.SetRow(cell, cells)
+}
+
+func (t *xlsxTableWriter) Close() error {
+	defer t.file.Close()
+	if err := t.stream.Flush(); err != nil {
+		return err
+	}
+	return t.file.Write(t.out)
+}
+
+func (t *xlsxTableWriter) Abort() {
+	t.file.Close()
+}
+
+// escapeFormulas prefixes with a quote the values a spreadsheet would run as a formula
+func escapeFormulas(values []string) []string {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+			value = "'" + value
+		}
+		escaped[i] = value
+	}
+	return escaped
+}
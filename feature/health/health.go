@@ -0,0 +1,180 @@
+// Package health serves the liveness and readiness probes.
+//
+// /livez only tells the process is serving, restarting it would not fix a database outage.
+// /readyz checks the database answers and every embedded migration was applied, so traffic
+// is held back from an instance started before `migrate up` ran.
+package health
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+
+	checkTimeout = 2 * time.Second
+
+	// DefaultMigrationTable is the goose version table `migrate up` records the applied migrations in,
+	// MIGRATION_TABLE overrides it
+	DefaultMigrationTable = "goose_db_version"
+)
+
+var migrationVersion = regexp.MustCompile(`^(\d+)_.*\.sql$`)
+var tableName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Check is the result of one readiness check
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the body of /readyz, Status is ok only when every check is
+type Report struct {
+	Status string  `json:"status"`
+	Checks []Check `json:"checks"`
+}
+
+type Checker struct {
+	db             *gorm.DB
+	migrations     fs.FS
+	migrationTable string
+}
+
+// NewChecker checks db against the migrations embedded in the binary.
+func NewChecker(db *gorm.DB, migrations fs.FS) *Checker {
+	table := os.Getenv("MIGRATION_TABLE")
+	if !tableName.MatchString(table) {
+		table = DefaultMigrationTable
+	}
+	return &Checker{db: db, migrations: migrations, migrationTable: table}
+}
+
+// Ready runs every readiness check.
+func (c *Checker) Ready(ctx context.Context) Report {
+	report := Report{Status: StatusOK}
+	for _, check := range []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{"database", c.checkDatabase},
+		{"migrations", c.checkMigrations},
+	} {
+		result := Check{Name: check.name, Status: StatusOK}
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		if err := check.run(checkCtx); err != nil {
+			result.Status = StatusFail
+			result.Error = err.Error()
+			report.Status = StatusFail
+		}
+		cancel()
+		report.Checks = append(report.Checks, result)
+	}
+	return report
+}
+
+func (c *Checker) checkDatabase(ctx context.Context) error {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (c *Checker) checkMigrations(ctx context.Context) error {
+	versions, err := c.embeddedVersions()
+	if err != nil {
+		return err
+	}
+	// goose appends a row per up and down, a version is applied when its latest row says so
+	var applied []int64
+	err = c.db.WithContext(ctx).Table(c.migrationTable+" AS v").
+		Where("v.is_applied = ?", true).
+		Where("v.id = (?)", c.db.Table(c.migrationTable).Select("MAX(id)").Where("version_id = v.version_id")).
+		Pluck("v.version_id", &applied).Error
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", c.migrationTable, err)
+	}
+	done := make(map[int64]bool, len(applied))
+	for _, version := range applied {
+		done[version] = true
+	}
+	pending := 0
+	for _, version := range versions {
+		if !done[version] {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d pending migrations", pending)
+	}
+	return nil
+}
+
+func (c *Checker) embeddedVersions() ([]int64, error) {
+	files, err := fs.Glob(c.migrations, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	var versions []int64
+	for _, file := range files {
+		match := migrationVersion.FindStringSubmatch(path.Base(file))
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+type Handler struct {
+	checker *Checker
+}
+
+func NewHandler(checker *Checker) *Handler {
+	return &Handler{checker: checker}
+}
+
+// Livez godoc
+// @Summary Liveness probe
+// @Description Answers as long as the process serves requests, it does not check dependencies
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /livez [get]
+func (h *Handler) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": StatusOK})
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Checks the database answers and has every migration applied
+// @Tags health
+// @Produce json
+// @Success 200 {object} Report
+// @Failure 503 {object} Report
+// @Router /readyz [get]
+func (h *Handler) Readyz(c *gin.Context) {
+	report := h.checker.Ready(c.Request.Context())
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
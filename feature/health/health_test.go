@@ -0,0 +1,65 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+var migrations = fstest.MapFS{
+	"000001_init.sql":      {Data: []byte("CREATE TABLE roles (id INT)")},
+	"000002_skills.sql":    {Data: []byte("CREATE TABLE skills (id INT)")},
+	"000003_webhooks.sql":  {Data: []byte("CREATE TABLE webhooks (id INT)")},
+	"migration.go":         {Data: []byte("package migration")},
+	"notes_without_id.sql": {Data: []byte("-- ignored")},
+}
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("failed to setup mock db: %v", err)
+	}
+	// gorm pings on open
+	mock.ExpectPing()
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return gormDB, mock
+}
+
+func TestReady(t *testing.T) {
+	db, mock := setupMockDB(t)
+	checker := NewChecker(db, migrations)
+
+	mock.ExpectPing()
+	mock.ExpectQuery("SELECT `v`.`version_id` FROM goose_db_version AS v").
+		WillReturnRows(sqlmock.NewRows([]string{"version_id"}).AddRow(1).AddRow(2).AddRow(3))
+	report := checker.Ready(context.Background())
+
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Equal(t, []Check{{Name: "database", Status: StatusOK}, {Name: "migrations", Status: StatusOK}}, report.Checks)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReady_PendingMigrationsAndDatabaseDown(t *testing.T) {
+	db, mock := setupMockDB(t)
+	checker := NewChecker(db, migrations)
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	mock.ExpectQuery("goose_db_version").
+		WillReturnRows(sqlmock.NewRows([]string{"version_id"}).AddRow(1))
+	report := checker.Ready(context.Background())
+
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, Check{Name: "database", Status: StatusFail, Error: "connection refused"}, report.Checks[0])
+	assert.Equal(t, Check{Name: "migrations", Status: StatusFail, Error: "2 pending migrations"}, report.Checks[1])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
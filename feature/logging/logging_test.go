@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return slog.New(NewRedactHandler(&contextHandler{handler}))
+}
+
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		record := map[string]any{}
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestRedactEmails(t *testing.T) {
+	assert.Equal(t, "sent to j***@example.com and b***@mail.example.org", RedactEmails("sent to jane.doe@example.com and bob@mail.example.org"))
+	assert.Equal(t, "no email here", RedactEmails("no email here"))
+}
+
+func TestRedactHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf).With("Email", "jane@example.com")
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	logger.InfoContext(ctx, "registered jane@example.com",
+		"identity_number", "A1234567",
+		"user", slog.GroupValue(slog.String("number", "B7654321"), slog.String("note", "contact jane@example.com")),
+		"error", errors.New("duplicate email jane@example.com"),
+		"user_id", 4,
+	)
+
+	record := lines(t, &buf)[0]
+	assert.Equal(t, "registered j***@example.com", record["msg"])
+	assert.Equal(t, Redacted, record["Email"])
+	assert.Equal(t, Redacted, record["identity_number"])
+	assert.Equal(t, map[string]any{"number": Redacted, "note": "contact j***@example.com"}, record["user"])
+	assert.Equal(t, "duplicate email j***@example.com", record["error"])
+	assert.Equal(t, float64(4), record["user_id"])
+	assert.Equal(t, "req-1", record["request_id"])
+}
+
+func TestContextHandler_Span(t *testing.T) {
+	var buf bytes.Buffer
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	newTestLogger(&buf).InfoContext(ctx, "in a span")
+	newTestLogger(&buf).InfoContext(context.Background(), "outside a span")
+
+	records := lines(t, &buf)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", records[0]["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", records[0]["span_id"])
+	assert.NotContains(t, records[1], "trace_id")
+}
+
+func TestGormLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := &GormLogger{logger: newTestLogger(&buf), level: gormlogger.Info, slowThreshold: 100 * time.Millisecond}
+	ctx := WithRequestID(context.Background(), "req-2")
+	sql := func() (string, int64) { return "SELECT * FROM users WHERE email = ?", 1 }
+
+	l.Trace(ctx, time.Now(), sql, nil)
+	l.Trace(ctx, time.Now().Add(-time.Second), sql, nil)
+	l.Trace(ctx, time.Now(), sql, errors.New("connection refused"))
+	l.LogMode(gormlogger.Warn).Trace(ctx, time.Now(), sql, nil)
+
+	records := lines(t, &buf)
+	assert.Len(t, records, 3)
+	assert.Equal(t, "query", records[0]["msg"])
+	assert.Equal(t, "DEBUG", records[0]["level"])
+	assert.Equal(t, "slow query", records[1]["msg"])
+	assert.Equal(t, "WARN", records[1]["level"])
+	assert.Equal(t, "query failed", records[2]["msg"])
+	assert.Equal(t, "req-2", records[2]["request_id"])
+
+	filtered, params := l.ParamsFilter(ctx, "SELECT * FROM users WHERE email = ?", "jane@example.com")
+	assert.Equal(t, "SELECT * FROM users WHERE email = ?", filtered)
+	assert.Nil(t, params)
+}
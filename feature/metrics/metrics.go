@@ -0,0 +1,114 @@
+// Package metrics exposes the Prometheus metrics of the service on /metrics.
+package metrics
+
+import (
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/event"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+const namespace = "onboarding"
+
+// Metrics owns the registry served on /metrics and the collectors the features feed.
+type Metrics struct {
+	registry     *prometheus.Registry
+	httpDuration *prometheus.HistogramVec
+	logins       *prometheus.CounterVec
+	decisions    *prometheus.HistogramVec
+}
+
+// New registers the Go runtime, process, DB pool and request queue collectors next to the service metrics.
+func New(db *gorm.DB) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of the HTTP requests by route, method and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_logins_total",
+			Help:      "Login attempts by result (success or failure).",
+		}, []string{"result"}),
+		decisions: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_decision_seconds",
+			Help:      "Time from submission to decision of the requests by type and outcome.",
+			// one minute to four weeks
+			Buckets: prometheus.ExponentialBuckets(60, 4, 9),
+		}, []string{"type", "outcome"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		newQueueCollector(db),
+		m.httpDuration,
+		m.logins,
+		m.decisions,
+	)
+	// the pool stats need the database/sql handle, GORM has none on a prepared-statement pool
+	if sqlDB, err := db.DB(); err == nil {
+		m.registry.MustRegister(collectors.NewDBStatsCollector(sqlDB, "main"))
+	}
+	// both outcomes show up at zero before the first login
+	m.logins.WithLabelValues("success")
+	m.logins.WithLabelValues("failure")
+	return m
+}
+
+// Middleware times every request under its route pattern, so /users/1 and /users/2 share one series.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.httpDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler serves the registry in the Prometheus text format. A failing collector, like the
+// request queue while the database is down, is logged and skipped instead of failing the scrape.
+func (m *Metrics) Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorLog:      slog.NewLogLogger(slog.Default().Handler(), slog.LevelError),
+		ErrorHandling: promhttp.ContinueOnError,
+	}))
+}
+
+// ObserveLogin counts a login attempt.
+func (m *Metrics) ObserveLogin(success bool) {
+	result := "failure"
+	if success {
+		result = "success"
+	}
+	m.logins.WithLabelValues(result).Inc()
+}
+
+// OnRequestApproved records the decision latency of an approved request.
+func (m *Metrics) OnRequestApproved(e event.RequestApproved) {
+	m.observeDecision(e.Type, "approved", e.SubmittedAt, e.DecidedAt)
+}
+
+// OnRequestRejected records the decision latency of a rejected request.
+func (m *Metrics) OnRequestRejected(e event.RequestRejected) {
+	m.observeDecision(e.Type, "rejected", e.SubmittedAt, e.DecidedAt)
+}
+
+func (m *Metrics) observeDecision(requestType string, outcome string, submittedAt time.Time, decidedAt time.Time) {
+	if submittedAt.IsZero() || decidedAt.Before(submittedAt) {
+		return
+	}
+	m.decisions.WithLabelValues(requestType, outcome).Observe(decidedAt.Sub(submittedAt).Seconds())
+}
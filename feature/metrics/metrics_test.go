@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/event"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to setup mock db: %v", err)
+	}
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return gormDB, mock
+}
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, mock := setupMockDB(t)
+	m := New(db)
+	router := gin.New()
+	router.Use(m.Middleware())
+	router.GET("/users/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.GET("/metrics", m.Handler())
+
+	for _, path := range []string{"/users/1", "/users/2", "/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	m.ObserveLogin(true)
+	m.ObserveLogin(false)
+	m.ObserveLogin(false)
+	submitted := time.Now().Add(-2 * time.Hour)
+	m.OnRequestApproved(event.RequestApproved{Type: "verification", SubmittedAt: submitted, DecidedAt: submitted.Add(time.Hour)})
+	m.OnRequestRejected(event.RequestRejected{Type: "verification"})
+
+	assert.Equal(t, 2, testutil.CollectAndCount(m.httpDuration))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.logins.WithLabelValues("success")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.logins.WithLabelValues("failure")))
+	// the rejection without a submission time is not observed
+	assert.Equal(t, 1, testutil.CollectAndCount(m.decisions))
+
+	mock.ExpectQuery("SELECT TRIM\\(type\\) AS type").
+		WillReturnRows(sqlmock.NewRows([]string{"type", "pending", "oldest_age_seconds"}).
+			AddRow("registration", 4, 3600).
+			AddRow("verification", 1, 60))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := recorder.Body.String()
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, body, `onboarding_http_request_duration_seconds_count{method="GET",route="/users/:id",status="204"} 2`)
+	assert.Contains(t, body, `onboarding_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `onboarding_request_queue_pending{type="registration"} 4`)
+	assert.Contains(t, body, `onboarding_request_queue_oldest_age_seconds{type="verification"} 60`)
+	assert.Contains(t, body, `go_sql_open_connections{db_name="main"}`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
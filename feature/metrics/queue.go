@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+const queueScrapeTimeout = 5 * time.Second
+
+// queueCollector reads the pending requests at scrape time, the queue changes outside this
+// process too (other instances, imports) so counting it here would drift
+type queueCollector struct {
+	db        *gorm.DB
+	pending   *prometheus.Desc
+	oldestAge *prometheus.Desc
+}
+
+type queueRow struct {
+	Type             string
+	Pending          int64
+	OldestAgeSeconds float64
+}
+
+func newQueueCollector(db *gorm.DB) *queueCollector {
+	return &queueCollector{
+		db: db,
+		pending: prometheus.NewDesc(prometheus.BuildFQName(namespace, "request_queue", "pending"),
+			"Requests waiting for a decision by type.", []string{"type"}, nil),
+		oldestAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, "request_queue", "oldest_age_seconds"),
+			"Age of the oldest request waiting for a decision by type.", []string{"type"}, nil),
+	}
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pending
+	ch <- c.oldestAge
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), queueScrapeTimeout)
+	defer cancel()
+	var rows []queueRow
+	err := c.db.WithContext(ctx).Table("requests").
+		Select("TRIM(type) AS type, COUNT(*) AS pending, TIMESTAMPDIFF(SECOND, MIN(created_at), NOW()) AS oldest_age_seconds").
+		Where("status = 0").
+		Group("TRIM(type)").
+		Scan(&rows).Error
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.pending, err)
+		return
+	}
+	for _, row := range rows {
+		ch <- prometheus.MustNewConstMetric(c.pending, prometheus.GaugeValue, float64(row.Pending), row.Type)
+		ch <- prometheus.MustNewConstMetric(c.oldestAge, prometheus.GaugeValue, row.OldestAgeSeconds, row.Type)
+	}
+}
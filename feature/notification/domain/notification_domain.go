@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Notification types
+const (
+	TypeRequestDecided = "request_decided"
+	TypeComment        = "comment"
+	TypeTransfer       = "transfer"
+	TypeShiftAssigned  = "shift_assigned"
+	TypeSLAReminder    = "sla_reminder"
+	TypeSLABreached    = "sla_breached"
+)
+
+// Resource types a notification can point at
+const (
+	ResourceRequest  = "request"
+	ResourceTransfer = "transfer"
+	ResourceShift    = "shift"
+)
+
+// Notification is a message for one user, ReadAt is set once the user has seen it
+type Notification struct {
+	ID           int    `gorm:"primaryKey"`
+	UserID       int    `gorm:"index"`
+	Type         string `gorm:"not null"`
+	Title        string `gorm:"not null"`
+	Body         string
+	ResourceType string
+	ResourceID   int
+	ReadAt       *time.Time
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
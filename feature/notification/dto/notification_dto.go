@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// NotificationResponseDTO is a notification as listed and streamed to its user.
+type NotificationResponseDTO struct {
+	ID           int        `json:"id"`
+	Type         string     `json:"type"`
+	Title        string     `json:"title"`
+	Body         string     `json:"body"`
+	ResourceType string     `json:"resource_type,omitempty"`
+	ResourceID   int        `json:"resource_id,omitempty"`
+	Read         bool       `json:"read"`
+	ReadAt       *time.Time `json:"read_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// NotificationFilter pages through notifications newest first, Before is the id to continue from.
+type NotificationFilter struct {
+	UnreadOnly bool
+	Before     int
+	Limit      int
+}
+
+// NotificationListDTO is one page of notifications, NextBefore is empty on the last page.
+type NotificationListDTO struct {
+	Notifications []NotificationResponseDTO `json:"notifications"`
+	Unread        int64                     `json:"unread"`
+	NextBefore    *int                      `json:"next_before"`
+}
+
+// NotificationMarkReadDTO lists the notifications to mark as read.
+type NotificationMarkReadDTO struct {
+	IDs []int `json:"ids" binding:"required,min=1,max=500,dive,gt=0"`
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"gorm.io/gorm"
+)
+
+type NotificationRepositoryInterface interface {
+	Create(ctx context.Context, notifications []domain.Notification) error
+	List(ctx context.Context, userID int, filter dto.NotificationFilter) ([]domain.Notification, error)
+	CountUnread(ctx context.Context, userID int) (int64, error)
+	MarkRead(ctx context.Context, userID int, ids []int) error
+	MarkAllRead(ctx context.Context, userID int) error
+}
+
+// NotificationRepository stores the notifications of every user.
+type NotificationRepository struct {
+	DB *gorm.DB
+}
+
+// NewNotificationRepository creates a new instance of NotificationRepository.
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{DB: db}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notifications []domain.Notification) error {
+	return uow.Conn(ctx, r.DB).Create(&notifications).Error
+}
+
+// List returns the notifications of the user newest first.
+func (r *NotificationRepository) List(ctx context.Context, userID int, filter dto.NotificationFilter) ([]domain.Notification, error) {
+	var notifications []domain.Notification
+	db := uow.Conn(ctx, r.DB).Where("user_id = ?", userID)
+	if filter.UnreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+	if filter.Before > 0 {
+		db = db.Where("id < ?", filter.Before)
+	}
+	err := db.Order("id DESC").Limit(filter.Limit).Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID int) (int64, error) {
+	var count int64
+	err := uow.Conn(ctx, r.DB).Model(&domain.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkRead only touches notifications of the user, ids of other users are ignored.
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID int, ids []int) error {
+	return uow.Conn(ctx, r.DB).Model(&domain.Notification{}).
+		Where("user_id = ? AND id IN ? AND read_at IS NULL", userID, ids).
+		Update("read_at", time.Now()).Error
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID int) error {
+	return uow.Conn(ctx, r.DB).Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
@@ -0,0 +1,160 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// streamKeepAlive is how often an idle stream sends a ping so proxies keep it open
+const streamKeepAlive = 25 * time.Second
+
+// NotificationHandler handles the HTTP requests for notifications.
+type NotificationHandler struct {
+	usecase usecase.NotificationUsecaseInterface
+}
+
+// NewNotificationHandler creates a new instance of NotificationHandler.
+func NewNotificationHandler(usecase usecase.NotificationUsecaseInterface) *NotificationHandler {
+	return &NotificationHandler{usecase: usecase}
+}
+
+// ListNotifications godoc
+// @Summary List my notifications
+// @Description Notifications of the logged in user, newest first
+// @Produce json
+// @Tags notification
+// @Param unread query bool false "Unread notifications only"
+// @Param before query int false "Continue after this notification id (next_before of the previous page)"
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Security bearerToken
+// @Success 200 {object} dto.NotificationListDTO
+// @Router /api/v1/notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	var filter dto.NotificationFilter
+	var err error
+	if v := c.Query("unread"); v != "" {
+		if filter.UnreadOnly, err = strconv.ParseBool(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid unread"})
+			return
+		}
+	}
+	if v := c.Query("before"); v != "" {
+		if filter.Before, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid before"})
+			return
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		if filter.Limit, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+	list, err := h.usecase.List(c.Request.Context(), c.GetInt("userId"), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// CountUnread godoc
+// @Summary Count my unread notifications
+// @Produce json
+// @Tags notification
+// @Security bearerToken
+// @Success 200 {object} map[string]int64
+// @Router /api/v1/notifications/unread-count [get]
+func (h *NotificationHandler) CountUnread(c *gin.Context) {
+	count, err := h.usecase.CountUnread(c.Request.Context(), c.GetInt("userId"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"unread": count})
+}
+
+// MarkRead godoc
+// @Summary Mark notifications as read
+// @Accept json
+// @Produce json
+// @Tags notification
+// @Param request body dto.NotificationMarkReadDTO true "Notification ids"
+// @Security bearerToken
+// @Success 200 {object} map[string]string
+// @Router /api/v1/notifications/read [post]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	var input dto.NotificationMarkReadDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.usecase.MarkRead(c.Request.Context(), c.GetInt("userId"), input.IDs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Notifications marked as read"})
+}
+
+// MarkAllRead godoc
+// @Summary Mark all my notifications as read
+// @Produce json
+// @Tags notification
+// @Security bearerToken
+// @Success 200 {object} map[string]string
+// @Router /api/v1/notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	if err := h.usecase.MarkAllRead(c.Request.Context(), c.GetInt("userId")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Notifications marked as read"})
+}
+
+// Stream godoc
+// @Summary Stream my notifications
+// @Description Server-Sent Events stream, a "notification" event is sent for every new notification and a "ping" event when idle. Browsers can pass the token as access_token
+// @Produce text/event-stream
+// @Tags notification
+// @Param access_token query string false "Token, for clients that cannot set the Authorization header"
+// @Security bearerToken
+// @Success 200 {object} dto.NotificationResponseDTO
+// @Router /api/v1/notifications/stream [get]
+func (h *NotificationHandler) Stream(c *gin.Context) {
+	userID := c.GetInt("userId")
+	unread, err := h.usecase.CountUnread(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	events, unsubscribe := h.usecase.Subscribe(userID)
+	defer unsubscribe()
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent("ready", gin.H{"unread": unread})
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case notification, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent("notification", notification)
+			return true
+		case <-keepAlive.C:
+			c.SSEvent("ping", gin.H{"time": time.Now().Unix()})
+			return true
+		}
+	})
+}
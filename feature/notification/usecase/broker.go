@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"sync"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/dto"
+)
+
+// subscriberBuffer is how many notifications a slow stream can lag behind before it misses some
+const subscriberBuffer = 16
+
+// Broker fans notifications out to the live streams of a user within this process.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[int]map[chan dto.NotificationResponseDTO]struct{}
+}
+
+// NewBroker creates a new instance of Broker.
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[int]map[chan dto.NotificationResponseDTO]struct{})}
+}
+
+// Subscribe opens a stream for the user, the returned func closes it.
+func (b *Broker) Subscribe(userID int) (<-chan dto.NotificationResponseDTO, func()) {
+	ch := make(chan dto.NotificationResponseDTO, subscriberBuffer)
+	b.mu.Lock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[chan dto.NotificationResponseDTO]struct{})
+	}
+	b.subscribers[userID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[userID], ch)
+			if len(b.subscribers[userID]) == 0 {
+				delete(b.subscribers, userID)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish never blocks, a stream whose buffer is full misses the notification
+// and picks it up from the list endpoint instead.
+func (b *Broker) Publish(userID int, notification dto.NotificationResponseDTO) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subscribers[userID] {
+		select {
+		case ch <- notification:
+		default:
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/event"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/storage"
+	userDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	volunteerDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+	// commentPreviewLength is how much of a comment is copied into its notification
+	commentPreviewLength = 200
+)
+
+type NotificationUsecaseInterface interface {
+	Notify(ctx context.Context, userIDs []int, notification domain.Notification) error
+	List(ctx context.Context, userID int, filter dto.NotificationFilter) (*dto.NotificationListDTO, error)
+	CountUnread(ctx context.Context, userID int) (int64, error)
+	MarkRead(ctx context.Context, userID int, ids []int) error
+	MarkAllRead(ctx context.Context, userID int) error
+	Subscribe(userID int) (<-chan dto.NotificationResponseDTO, func())
+}
+
+// NotificationUsecase stores notifications and pushes them to the live streams of their users.
+// It implements the notifier interfaces of the other features.
+type NotificationUsecase struct {
+	Repo   storage.NotificationRepositoryInterface
+	Broker *Broker
+}
+
+// NewNotificationUsecase creates a new instance of NotificationUsecase.
+func NewNotificationUsecase(repo storage.NotificationRepositoryInterface, broker *Broker) *NotificationUsecase {
+	return &NotificationUsecase{Repo: repo, Broker: broker}
+}
+
+// Notify sends a copy of notification to every user once.
+func (u *NotificationUsecase) Notify(ctx context.Context, userIDs []int, notification domain.Notification) error {
+	seen := make(map[int]bool, len(userIDs))
+	notifications := make([]domain.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID == 0 || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		n := notification
+		n.UserID = userID
+		notifications = append(notifications, n)
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+	if err := u.Repo.Create(ctx, notifications); err != nil {
+		return err
+	}
+	for i := range notifications {
+		u.Broker.Publish(notifications[i].UserID, notificationResponse(&notifications[i]))
+	}
+	return nil
+}
+
+func (u *NotificationUsecase) List(ctx context.Context, userID int, filter dto.NotificationFilter) (*dto.NotificationListDTO, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultNotificationLimit
+	}
+	if filter.Limit > maxNotificationLimit {
+		filter.Limit = maxNotificationLimit
+	}
+	notifications, err := u.Repo.List(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := u.Repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	list := &dto.NotificationListDTO{
+		Notifications: make([]dto.NotificationResponseDTO, 0, len(notifications)),
+		Unread:        unread,
+	}
+	for i := range notifications {
+		list.Notifications = append(list.Notifications, notificationResponse(&notifications[i]))
+	}
+	if len(notifications) == filter.Limit {
+		last := notifications[len(notifications)-1].ID
+		list.NextBefore = &last
+	}
+	return list, nil
+}
+
+func (u *NotificationUsecase) CountUnread(ctx context.Context, userID int) (int64, error) {
+	return u.Repo.CountUnread(ctx, userID)
+}
+
+func (u *NotificationUsecase) MarkRead(ctx context.Context, userID int, ids []int) error {
+	return u.Repo.MarkRead(ctx, userID, ids)
+}
+
+func (u *NotificationUsecase) MarkAllRead(ctx context.Context, userID int) error {
+	return u.Repo.MarkAllRead(ctx, userID)
+}
+
+func (u *NotificationUsecase) Subscribe(userID int) (<-chan dto.NotificationResponseDTO, func()) {
+	return u.Broker.Subscribe(userID)
+}
+
+// OnRequestApproved tells the requester their request was approved.
+func (u *NotificationUsecase) OnRequestApproved(e event.RequestApproved) {
+	u.requestDecided(context.Background(), e.RequestID, e.UserID, e.Type, "approved")
+}
+
+// OnRequestRejected tells the requester their request was rejected.
+func (u *NotificationUsecase) OnRequestRejected(e event.RequestRejected) {
+	u.requestDecided(context.Background(), e.RequestID, e.UserID, e.Type, "rejected")
+}
+
+func (u *NotificationUsecase) requestDecided(ctx context.Context, requestID int, userID int, requestType string, outcome string) {
+	u.notify(ctx, []int{userID}, domain.Notification{
+		Type:         domain.TypeRequestDecided,
+		Title:        fmt.Sprintf("Your %s request was %s", requestType, outcome),
+		ResourceType: domain.ResourceRequest,
+		ResourceID:   requestID,
+	})
+}
+
+// NotifyComment tells the other side of a request thread about a new comment.
+func (u *NotificationUsecase) NotifyComment(ctx context.Context, userIDs []int, comment *userDomain.RequestComment) {
+	body := comment.Body
+	if runes := []rune(body); len(runes) > commentPreviewLength {
+		body = string(runes[:commentPreviewLength]) + "…"
+	}
+	u.notify(ctx, userIDs, domain.Notification{
+		Type:         domain.TypeComment,
+		Title:        fmt.Sprintf("New comment on request #%d", comment.RequestID),
+		Body:         body,
+		ResourceType: domain.ResourceRequest,
+		ResourceID:   comment.RequestID,
+	})
+}
+
+// NotifyTransfer tells the parties of a department transfer about its progress.
+func (u *NotificationUsecase) NotifyTransfer(ctx context.Context, userIDs []int, transfer *volunteerDomain.DepartmentTransfer, message string) {
+	u.notify(ctx, userIDs, domain.Notification{
+		Type:         domain.TypeTransfer,
+		Title:        "Department transfer update",
+		Body:         message,
+		ResourceType: domain.ResourceTransfer,
+		ResourceID:   transfer.ID,
+	})
+}
+
+// NotifyShiftAssigned tells a volunteer they were scheduled on a shift.
+func (u *NotificationUsecase) NotifyShiftAssigned(ctx context.Context, userID int, shift *volunteerDomain.Shift) {
+	u.notify(ctx, []int{userID}, domain.Notification{
+		Type:         domain.TypeShiftAssigned,
+		Title:        fmt.Sprintf("You have been scheduled for %s", shift.Name),
+		Body:         fmt.Sprintf("%s - %s", shift.StartTime.Format("2006-01-02 15:04"), shift.EndTime.Format("15:04")),
+		ResourceType: domain.ResourceShift,
+		ResourceID:   shift.ID,
+	})
+}
+
+// NotifySLAReminder tells the reviewer holding a request that its deadline is close.
+func (u *NotificationUsecase) NotifySLAReminder(ctx context.Context, userIDs []int, request *userDomain.Request, dueAt time.Time) {
+	u.notify(ctx, userIDs, domain.Notification{
+		Type:         domain.TypeSLAReminder,
+		Title:        fmt.Sprintf("Request #%d is due soon", request.ID),
+		Body:         fmt.Sprintf("The %s request must be decided by %s", strings.TrimSpace(request.Type), dueAt.Format("2006-01-02 15:04")),
+		ResourceType: domain.ResourceRequest,
+		ResourceID:   request.ID,
+	})
+}
+
+// NotifySLABreached tells the department managers that a request is still pending past its deadline.
+func (u *NotificationUsecase) NotifySLABreached(ctx context.Context, userIDs []int, request *userDomain.Request, dueAt time.Time) {
+	u.notify(ctx, userIDs, domain.Notification{
+		Type:         domain.TypeSLABreached,
+		Title:        fmt.Sprintf("Request #%d breached its SLA", request.ID),
+		Body:         fmt.Sprintf("The %s request was due by %s and is still pending", strings.TrimSpace(request.Type), dueAt.Format("2006-01-02 15:04")),
+		ResourceType: domain.ResourceRequest,
+		ResourceID:   request.ID,
+	})
+}
+
+// notify is used by the notifier implementations, a failed notification must not fail the action behind it,
+// nor may the caller cancelling its context drop the notification
+func (u *NotificationUsecase) notify(ctx context.Context, userIDs []int, notification domain.Notification) {
+	if err := u.Notify(context.WithoutCancel(ctx), userIDs, notification); err != nil {
+		log.Printf("cannot send %s notification to users %v: %v", notification.Type, userIDs, err)
+	}
+}
+
+func notificationResponse(notification *domain.Notification) dto.NotificationResponseDTO {
+	return dto.NotificationResponseDTO{
+		ID:           notification.ID,
+		Type:         notification.Type,
+		Title:        notification.Title,
+		Body:         notification.Body,
+		ResourceType: notification.ResourceType,
+		ResourceID:   notification.ResourceID,
+		Read:         notification.ReadAt != nil,
+		ReadAt:       notification.ReadAt,
+		CreatedAt:    notification.CreatedAt,
+	}
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryNotificationRepository struct {
+	stored []domain.Notification
+}
+
+func (r *memoryNotificationRepository) Create(ctx context.Context, notifications []domain.Notification) error {
+	for i := range notifications {
+		notifications[i].ID = len(r.stored) + 1
+		r.stored = append(r.stored, notifications[i])
+	}
+	return nil
+}
+
+func (r *memoryNotificationRepository) List(ctx context.Context, userID int, filter dto.NotificationFilter) ([]domain.Notification, error) {
+	var notifications []domain.Notification
+	for i := len(r.stored) - 1; i >= 0 && len(notifications) < filter.Limit; i-- {
+		n := r.stored[i]
+		if n.UserID == userID && (filter.Before == 0 || n.ID < filter.Before) {
+			notifications = append(notifications, n)
+		}
+	}
+	return notifications, nil
+}
+
+func (r *memoryNotificationRepository) CountUnread(ctx context.Context, userID int) (int64, error) {
+	var count int64
+	for _, n := range r.stored {
+		if n.UserID == userID && n.ReadAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryNotificationRepository) MarkRead(ctx context.Context, userID int, ids []int) error {
+	return nil
+}
+
+func (r *memoryNotificationRepository) MarkAllRead(ctx context.Context, userID int) error { return nil }
+
+func TestNotifySendsOneCopyPerUser(t *testing.T) {
+	repo := &memoryNotificationRepository{}
+	u := NewNotificationUsecase(repo, NewBroker())
+	events, unsubscribe := u.Subscribe(7)
+	defer unsubscribe()
+
+	err := u.Notify(context.Background(), []int{7, 0, 8, 7}, domain.Notification{Type: domain.TypeComment, Title: "New comment"})
+
+	assert.NoError(t, err)
+	assert.Len(t, repo.stored, 2)
+	select {
+	case n := <-events:
+		assert.Equal(t, "New comment", n.Title)
+	default:
+		t.Fatal("subscriber did not receive the notification")
+	}
+	assert.Len(t, events, 0)
+}
+
+func TestListPagesWithNextBefore(t *testing.T) {
+	repo := &memoryNotificationRepository{}
+	u := NewNotificationUsecase(repo, NewBroker())
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, u.Notify(context.Background(), []int{1}, domain.Notification{Type: domain.TypeComment}))
+	}
+
+	page, err := u.List(context.Background(), 1, dto.NotificationFilter{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page.Notifications, 2)
+	assert.Equal(t, int64(3), page.Unread)
+	if assert.NotNil(t, page.NextBefore) {
+		assert.Equal(t, 2, *page.NextBefore)
+	}
+
+	page, err = u.List(context.Background(), 1, dto.NotificationFilter{Before: *page.NextBefore, Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page.Notifications, 1)
+	assert.Nil(t, page.NextBefore)
+}
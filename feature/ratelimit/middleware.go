@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader is the header API clients send their key in.
+const APIKeyHeader = "X-API-Key"
+
+// KeyFunc names the client a request is counted for.
+type KeyFunc func(c *gin.Context) string
+
+// ByIP counts requests per client ip.
+func ByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// ByUser counts requests per user id set by AuthMiddleware, so it must run after it,
+// and per client ip for anonymous requests.
+func ByUser(c *gin.Context) string {
+	if userID, ok := c.Get("userId"); ok {
+		return fmt.Sprintf("user:%v", userID)
+	}
+	return ByIP(c)
+}
+
+// ByAPIKey counts requests per key sent in header when it is one of keys, and falls back to
+// ByUser otherwise: an unknown key must not give its sender a fresh bucket. Only hashes of the
+// keys are kept, in memory and in the store.
+func ByAPIKey(header string, keys []string) KeyFunc {
+	known := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		known[hashKey(key)] = true
+	}
+	return func(c *gin.Context) string {
+		key := c.GetHeader(header)
+		if key == "" {
+			return ByUser(c)
+		}
+		hash := hashKey(key)
+		if !known[hash] {
+			return ByUser(c)
+		}
+		return "key:" + hash
+	}
+}
+
+// APIKeysFromEnv returns the API keys listed in API_KEYS, separated by commas.
+func APIKeysFromEnv() []string {
+	var keys []string
+	for _, key := range strings.Split(os.Getenv("API_KEYS"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:12])
+}
+
+// Limiter builds the rate limiting middlewares of the routes on one store.
+type Limiter struct {
+	store Store
+}
+
+// New creates a Limiter keeping its buckets in store.
+func New(store Store) *Limiter {
+	return &Limiter{store: store}
+}
+
+// Limit gives every client, as named by key, its own bucket for the routes it is used on.
+// Routes sharing a name share the buckets. The X-RateLimit-Limit and X-RateLimit-Remaining
+// headers are set on every response, refused requests get 429 and a Retry-After in seconds.
+// Requests go through when the store fails, an outage of Redis must not take the API down.
+func (l *Limiter) Limit(name string, limit Limit, key KeyFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, err := l.store.Take(c.Request.Context(), "ratelimit:"+name+":"+key(c), limit)
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "rate limit store failed", "limit", name, "error", err)
+			c.Next()
+			return
+		}
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests, retry later"})
+			return
+		}
+		c.Next()
+	}
+}
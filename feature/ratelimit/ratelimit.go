@@ -0,0 +1,81 @@
+// Package ratelimit throttles clients with token buckets.
+//
+// Every bucket holds up to Burst tokens and refills at Rate tokens per Per, a request takes one
+// token and is answered 429 with a Retry-After header when the bucket is empty. Buckets live in
+// memory, or in Redis when REDIS_URL is set so that every instance of the service shares them.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Limit is the size and refill speed of a bucket, Burst defaults to Rate.
+type Limit struct {
+	Rate  int
+	Per   time.Duration
+	Burst int
+}
+
+func (l Limit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Rate
+}
+
+// tokens per nanosecond
+func (l Limit) refill() float64 {
+	return float64(l.Rate) / float64(l.Per)
+}
+
+// Result is the state of a bucket after a request took its token, or failed to.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets, Take takes one token from the bucket of key.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// StoreFromEnv returns a RedisStore when REDIS_URL is set and a MemoryStore otherwise.
+func StoreFromEnv() (Store, error) {
+	url := os.Getenv("REDIS_URL")
+	if url == "" {
+		return NewMemoryStore(), nil
+	}
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return NewRedisStore(redis.NewClient(options)), nil
+}
+
+// take refills a bucket holding tokens for elapsed and takes one token from it.
+// It returns the tokens left in the bucket.
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, Result) {
+	burst := limit.burst()
+	tokens = math.Min(float64(burst), tokens+float64(elapsed)*limit.refill())
+	result := Result{Limit: burst}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration(math.Ceil((1 - tokens) / limit.refill()))
+	}
+	result.Remaining = int(tokens)
+	return tokens, result
+}
+
+// idleTTL is how long a bucket takes to fill up again, after that it can be forgotten
+func idleTTL(limit Limit) time.Duration {
+	return time.Duration(math.Ceil(float64(limit.burst()) / limit.refill()))
+}
@@ -0,0 +1,156 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var limit = Limit{Rate: 2, Per: time.Second, Burst: 3}
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newRedisStore(t *testing.T, clock *clock) (*RedisStore, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	store := NewRedisStore(client)
+	store.now = clock.Now
+	return store, server
+}
+
+func assertBucket(t *testing.T, store Store, clock *clock) {
+	ctx := context.Background()
+	for remaining := 2; remaining >= 0; remaining-- {
+		result, err := store.Take(ctx, "client", limit)
+		assert.NoError(t, err)
+		assert.Equal(t, Result{Allowed: true, Limit: 3, Remaining: remaining}, result)
+	}
+
+	result, err := store.Take(ctx, "client", limit)
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+	// another client has its own bucket
+	result, _ = store.Take(ctx, "other", limit)
+	assert.True(t, result.Allowed)
+
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	result, _ = store.Take(ctx, "client", limit)
+	assert.Equal(t, Result{Allowed: true, Limit: 3, Remaining: 0}, result)
+
+	// a bucket never holds more than its burst
+	clock.now = clock.now.Add(time.Hour)
+	result, _ = store.Take(ctx, "client", limit)
+	assert.Equal(t, Result{Allowed: true, Limit: 3, Remaining: 2}, result)
+}
+
+func TestMemoryStore(t *testing.T) {
+	clock := &clock{now: time.Now()}
+	store := NewMemoryStore()
+	store.now = clock.Now
+
+	assertBucket(t, store, clock)
+
+	clock.now = clock.now.Add(sweepInterval)
+	store.Take(context.Background(), "client", limit)
+	assert.Len(t, store.buckets, 1)
+}
+
+func TestRedisStore(t *testing.T) {
+	clock := &clock{now: time.Now()}
+	store, server := newRedisStore(t, clock)
+
+	assertBucket(t, store, clock)
+
+	assert.True(t, server.Exists("client"))
+	server.FastForward(2 * time.Second)
+	assert.False(t, server.Exists("client"))
+}
+
+func TestLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	clock := &clock{now: time.Now()}
+	store, _ := newRedisStore(t, clock)
+	limiter := New(store)
+	router := gin.New()
+	router.POST("/login", limiter.Limit("login", Limit{Rate: 1, Per: time.Minute}, ByAPIKey(APIKeyHeader, []string{"secret-key"})), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	send := func(apiKey string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/login", nil)
+		if apiKey != "" {
+			request.Header.Set(APIKeyHeader, apiKey)
+		}
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		return response
+	}
+
+	response := send("")
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Equal(t, "1", response.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", response.Header().Get("X-RateLimit-Remaining"))
+
+	response = send("")
+	assert.Equal(t, http.StatusTooManyRequests, response.Code)
+	assert.Equal(t, "60", response.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":"Too many requests, retry later"}`, response.Body.String())
+
+	// a client with an API key is counted apart from its ip
+	assert.Equal(t, http.StatusNoContent, send("secret-key").Code)
+	assert.Equal(t, http.StatusTooManyRequests, send("secret-key").Code)
+
+	// unknown keys are counted on the ip
+	assert.Equal(t, http.StatusTooManyRequests, send("made-up-key").Code)
+	assert.Equal(t, http.StatusTooManyRequests, send("another-made-up-key").Code)
+}
+
+func TestAPIKeysFromEnv(t *testing.T) {
+	t.Setenv("API_KEYS", " partner-a, ,partner-b")
+	assert.Equal(t, []string{"partner-a", "partner-b"}, APIKeysFromEnv())
+
+	t.Setenv("API_KEYS", "")
+	assert.Empty(t, APIKeysFromEnv())
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit) (Result, error) {
+	return Result{}, redis.ErrClosed
+}
+
+func TestLimit_StoreDown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", New(failingStore{}).Limit("api", limit, ByIP), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusNoContent, response.Code)
+}
+
+func TestByUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.RemoteAddr = "10.0.0.1:1234"
+
+	assert.Equal(t, "ip:10.0.0.1", ByUser(c))
+	c.Set("userId", 7)
+	assert.Equal(t, "user:7", ByUser(c))
+}
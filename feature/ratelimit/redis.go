@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript is take run atomically in Redis. The bucket is a hash of its tokens and the time
+// in milliseconds it was last updated, it expires once it would be full again.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+	tokens = burst
+	updated = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - updated) * rate)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], ttl)
+return {allowed, math.floor(tokens), wait}
+`)
+
+// RedisStore keeps the buckets in Redis, shared by every instance of the service.
+// The buckets are timed with the clock of the instance, keep the clocks in sync.
+type RedisStore struct {
+	client redis.Scripter
+	now    func() time.Time
+}
+
+// NewRedisStore creates a RedisStore on client.
+func NewRedisStore(client redis.Scripter) *RedisStore {
+	return &RedisStore{client: client, now: time.Now}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	ttl := idleTTL(limit).Milliseconds() + 1
+	values, err := takeScript.Run(ctx, s.client, []string{key},
+		limit.refill()*float64(time.Millisecond), limit.burst(), s.now().UnixMilli(), ttl).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit.burst(),
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
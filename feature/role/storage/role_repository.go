@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"context"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
 	"gorm.io/gorm"
 )
 
@@ -16,29 +18,29 @@ func NewRoleRepository(db *gorm.DB) *RoleRepository {
 }
 
 // Create inserts a new role record into the database.
-func (r *RoleRepository) Create(role *domain.Role) error {
-	return r.DB.Create(role).Error
+func (r *RoleRepository) Create(ctx context.Context, role *domain.Role) error {
+	return uow.Conn(ctx, r.DB).Create(role).Error
 }
 
-func (r *RoleRepository) GetAll() ([]domain.Role, error) {
+func (r *RoleRepository) GetAll(ctx context.Context) ([]domain.Role, error) {
 	var roles []domain.Role
-	err := r.DB.Find(&roles).Error
+	err := uow.Conn(ctx, r.DB).Find(&roles).Error
 	return roles, err
 }
 
 // GetByID retrieves a role record by its ID from the database.
-func (r *RoleRepository) GetByID(id uint) (*domain.Role, error) {
+func (r *RoleRepository) GetByID(ctx context.Context, id uint) (*domain.Role, error) {
 	var role domain.Role
-	err := r.DB.First(&role, id).Error
+	err := uow.Conn(ctx, r.DB).First(&role, id).Error
 	return &role, err
 }
 
 // Update updates a role record in the database.
-func (r *RoleRepository) Update(role *domain.Role) error {
-	return r.DB.Save(role).Error
+func (r *RoleRepository) Update(ctx context.Context, role *domain.Role) error {
+	return uow.Conn(ctx, r.DB).Save(role).Error
 }
 
 // Delete deletes a role record from the database.
-func (r *RoleRepository) Delete(id uint) error {
-	return r.DB.Delete(&domain.Role{}, id).Error
+func (r *RoleRepository) Delete(ctx context.Context, id uint) error {
+	return uow.Conn(ctx, r.DB).Delete(&domain.Role{}, id).Error
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/cache"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/usecase"
 	"github.com/gin-gonic/gin"
@@ -35,7 +36,7 @@ func (h *RoleHandler) CreateRole(c *gin.Context) {
 		return
 	}
 
-	role, err := h.usecase.CreateRole(input)
+	role, err := h.usecase.CreateRole(c.Request.Context(), input)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -50,16 +51,19 @@ func (h *RoleHandler) CreateRole(c *gin.Context) {
 // @Description Get all roles
 // @Produce json
 // @Tags role
+// @Param If-None-Match header string false "ETag of a previous response"
 // @Success 200 {array} domain.Role
+// @Header 200 {string} ETag "Hash of the body"
+// @Success 304 "Not modified since the ETag of If-None-Match"
 // @Router /api/v1/role/ [get]
 func (h *RoleHandler) GetAllRoles(c *gin.Context) {
-	roles, err := h.usecase.GetAllRoles()
+	roles, err := h.usecase.GetAllRoles(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, roles)
+	cache.JSON(c, http.StatusOK, roles)
 }
 
 // GetRoleByID handles the HTTP GET request to retrieve a role by its ID.
@@ -78,7 +82,7 @@ func (h *RoleHandler) GetRoleByID(c *gin.Context) {
 		return
 	}
 
-	role, err := h.usecase.GetRoleByID(uint(id))
+	role, err := h.usecase.GetRoleByID(c.Request.Context(), uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
 		return
@@ -110,7 +114,7 @@ func (h *RoleHandler) UpdateRole(c *gin.Context) {
 		return
 	}
 
-	role, err := h.usecase.UpdateRole(uint(id), input)
+	role, err := h.usecase.UpdateRole(c.Request.Context(), uint(id), input)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -135,7 +139,7 @@ func (h *RoleHandler) DeleteRole(c *gin.Context) {
 		return
 	}
 
-	err = h.usecase.DeleteRole(uint(id))
+	err = h.usecase.DeleteRole(c.Request.Context(), uint(id))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
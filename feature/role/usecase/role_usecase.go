@@ -1,53 +1,70 @@
 package usecase
 
 import (
+	"context"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/cache"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/storage"
 )
 
+// allRolesKey caches the role list, changing a role deletes it
+const allRolesKey = "roles:all"
+
 // RoleUsecase handles the business logic for roles.
 type RoleUsecase struct {
-	repo *storage.RoleRepository
+	repo  *storage.RoleRepository
+	cache cache.Cache
 }
 
-// NewRoleUsecase creates a new instance of RoleUsecase.
-func NewRoleUsecase(repo *storage.RoleRepository) *RoleUsecase {
-	return &RoleUsecase{repo: repo}
+// NewRoleUsecase creates a new instance of RoleUsecase, the role list is kept in cache.
+func NewRoleUsecase(repo *storage.RoleRepository, cache cache.Cache) *RoleUsecase {
+	return &RoleUsecase{repo: repo, cache: cache}
 }
 
 // CreateRole creates a new role using the provided DTO.
-func (u *RoleUsecase) CreateRole(input dto.RoleCreateDTO) (*domain.Role, error) {
+func (u *RoleUsecase) CreateRole(ctx context.Context, input dto.RoleCreateDTO) (*domain.Role, error) {
 	role := &domain.Role{
 		Name:   input.Name,
 		Status: input.Status,
 	}
-	err := u.repo.Create(role)
-	return role, err
+	if err := u.repo.Create(ctx, role); err != nil {
+		return role, err
+	}
+	cache.Invalidate(ctx, u.cache, allRolesKey)
+	return role, nil
 }
 
-func (u *RoleUsecase) GetAllRoles() ([]domain.Role, error) {
-	return u.repo.GetAll()
+// GetAllRoles returns every role, from the cache while it holds them.
+func (u *RoleUsecase) GetAllRoles(ctx context.Context) ([]domain.Role, error) {
+	return cache.Load(ctx, u.cache, allRolesKey, u.repo.GetAll)
 }
 
 // GetRoleByID retrieves a role by its ID.
-func (u *RoleUsecase) GetRoleByID(id uint) (*domain.Role, error) {
-	return u.repo.GetByID(id)
+func (u *RoleUsecase) GetRoleByID(ctx context.Context, id uint) (*domain.Role, error) {
+	return u.repo.GetByID(ctx, id)
 }
 
 // UpdateRole updates a role using the provided DTO.
-func (u *RoleUsecase) UpdateRole(id uint, input dto.RoleUpdateDTO) (*domain.Role, error) {
-	role, err := u.repo.GetByID(id)
+func (u *RoleUsecase) UpdateRole(ctx context.Context, id uint, input dto.RoleUpdateDTO) (*domain.Role, error) {
+	role, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	role.Name = input.Name
 	role.Status = input.Status
-	err = u.repo.Update(role)
-	return role, err
+	if err := u.repo.Update(ctx, role); err != nil {
+		return role, err
+	}
+	cache.Invalidate(ctx, u.cache, allRolesKey)
+	return role, nil
 }
 
 // DeleteRole deletes a role by its ID.
-func (u *RoleUsecase) DeleteRole(id uint) error {
-	return u.repo.Delete(id)
+func (u *RoleUsecase) DeleteRole(ctx context.Context, id uint) error {
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, u.cache, allRolesKey)
+	return nil
 }
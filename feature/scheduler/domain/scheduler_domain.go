@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// What started a run
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+// Run statuses
+const (
+	RunRunning   = "running"
+	RunSucceeded = "succeeded"
+	RunFailed    = "failed"
+)
+
+// ScheduledJob is the state of a job shared by every instance. The instance running the job
+// holds its row locked until LockedUntil, so a due run is only started once.
+type ScheduledJob struct {
+	Name        string    `gorm:"primaryKey"`
+	Schedule    string    `gorm:"not null"`
+	NextRunAt   time.Time `gorm:"not null"`
+	LockedBy    *string
+	LockedUntil *time.Time
+	LastRunAt   *time.Time
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// Locked reports whether an instance is running the job at now
+func (j *ScheduledJob) Locked(now time.Time) bool {
+	return j.LockedUntil != nil && j.LockedUntil.After(now)
+}
+
+// JobRun is one run of a job in the run history
+type JobRun struct {
+	ID          int    `gorm:"primaryKey"`
+	Job         string `gorm:"not null"`
+	Trigger     string `gorm:"not null"`
+	TriggeredBy *int
+	Instance    string `gorm:"not null"`
+	Status      string `gorm:"not null"`
+	Error       *string
+	StartedAt   time.Time `gorm:"not null"`
+	FinishedAt  *time.Time
+}
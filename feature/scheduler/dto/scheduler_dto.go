@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// JobResponseDTO is a registered job with its shared state and its last run
+type JobResponseDTO struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Schedule    string             `json:"schedule"`
+	Timeout     string             `json:"timeout"`
+	NextRunAt   *time.Time         `json:"next_run_at"`
+	LastRunAt   *time.Time         `json:"last_run_at"`
+	Running     bool               `json:"running"`
+	LockedBy    *string            `json:"locked_by"`
+	LockedUntil *time.Time         `json:"locked_until"`
+	LastRun     *JobRunResponseDTO `json:"last_run"`
+}
+
+// JobRunResponseDTO is one run of a job
+type JobRunResponseDTO struct {
+	ID          int        `json:"id"`
+	Job         string     `json:"job"`
+	Trigger     string     `json:"trigger"`
+	TriggeredBy *int       `json:"triggered_by"`
+	Instance    string     `json:"instance"`
+	Status      string     `json:"status"`
+	Error       *string    `json:"error"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/scheduler/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SchedulerRepositoryInterface interface {
+	SyncJob(ctx context.Context, name string, schedule string, nextRunAt time.Time) error
+	ListJobs(ctx context.Context) ([]domain.ScheduledJob, error)
+	ClaimDue(ctx context.Context, name string, instance string, now time.Time, lease time.Duration, nextRunAt time.Time) (bool, error)
+	Claim(ctx context.Context, name string, instance string, now time.Time, lease time.Duration) (bool, error)
+	Release(ctx context.Context, name string, instance string, finishedAt time.Time) error
+	CreateRun(ctx context.Context, run *domain.JobRun) error
+	FinishRun(ctx context.Context, run *domain.JobRun) error
+	ListRuns(ctx context.Context, job string, limit int) ([]domain.JobRun, error)
+	LastRuns(ctx context.Context) ([]domain.JobRun, error)
+	PurgeRuns(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SchedulerRepository stores the shared state of the scheduled jobs and their run history.
+type SchedulerRepository struct {
+	DB *gorm.DB
+}
+
+// NewSchedulerRepository creates a new instance of SchedulerRepository.
+func NewSchedulerRepository(db *gorm.DB) *SchedulerRepository {
+	return &SchedulerRepository{DB: db}
+}
+
+// SyncJob creates the row of a job. When the schedule changed the next run is moved to
+// nextRunAt, otherwise the one already planned is kept.
+func (r *SchedulerRepository) SyncJob(ctx context.Context, name string, schedule string, nextRunAt time.Time) error {
+	job := domain.ScheduledJob{Name: name, Schedule: schedule, NextRunAt: nextRunAt}
+	// MySQL assigns from left to right, next_run_at must be compared with the old schedule
+	return uow.Conn(ctx, r.DB).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "next_run_at"}, Value: gorm.Expr("IF(schedule = ?, next_run_at, ?)", schedule, nextRunAt)},
+			{Column: clause.Column{Name: "schedule"}, Value: schedule},
+		},
+	}).Create(&job).Error
+}
+
+func (r *SchedulerRepository) ListJobs(ctx context.Context) ([]domain.ScheduledJob, error) {
+	var jobs []domain.ScheduledJob
+	err := uow.Conn(ctx, r.DB).Order("name").Find(&jobs).Error
+	return jobs, err
+}
+
+// ClaimDue locks the job for instance when its next run is due and nobody holds it, and plans
+// the run after. The single UPDATE makes it safe between instances: only one of them changes the row.
+func (r *SchedulerRepository) ClaimDue(ctx context.Context, name string, instance string, now time.Time, lease time.Duration, nextRunAt time.Time) (bool, error) {
+	result := uow.Conn(ctx, r.DB).Model(&domain.ScheduledJob{}).
+		Where("name = ? AND next_run_at <= ? AND (locked_until IS NULL OR locked_until < ?)", name, now, now).
+		Updates(map[string]any{"locked_by": instance, "locked_until": now.Add(lease), "next_run_at": nextRunAt})
+	return result.RowsAffected == 1, result.Error
+}
+
+// Claim locks the job for instance when nobody holds it, for a run out of schedule.
+func (r *SchedulerRepository) Claim(ctx context.Context, name string, instance string, now time.Time, lease time.Duration) (bool, error) {
+	result := uow.Conn(ctx, r.DB).Model(&domain.ScheduledJob{}).
+		Where("name = ? AND (locked_until IS NULL OR locked_until < ?)", name, now).
+		Updates(map[string]any{"locked_by": instance, "locked_until": now.Add(lease)})
+	return result.RowsAffected == 1, result.Error
+}
+
+// Release unlocks the job if instance still holds it.
+func (r *SchedulerRepository) Release(ctx context.Context, name string, instance string, finishedAt time.Time) error {
+	return uow.Conn(ctx, r.DB).Model(&domain.ScheduledJob{}).
+		Where("name = ? AND locked_by = ?", name, instance).
+		Updates(map[string]any{"locked_by": nil, "locked_until": nil, "last_run_at": finishedAt}).Error
+}
+
+func (r *SchedulerRepository) CreateRun(ctx context.Context, run *domain.JobRun) error {
+	return uow.Conn(ctx, r.DB).Create(run).Error
+}
+
+func (r *SchedulerRepository) FinishRun(ctx context.Context, run *domain.JobRun) error {
+	return uow.Conn(ctx, r.DB).Model(run).Select("status", "error", "finished_at").Updates(run).Error
+}
+
+// ListRuns returns the last runs of job, newest first.
+func (r *SchedulerRepository) ListRuns(ctx context.Context, job string, limit int) ([]domain.JobRun, error) {
+	var runs []domain.JobRun
+	err := uow.Conn(ctx, r.DB).Where("job = ?", job).Order("id DESC").Limit(limit).Find(&runs).Error
+	return runs, err
+}
+
+// LastRuns returns the last run of every job.
+func (r *SchedulerRepository) LastRuns(ctx context.Context) ([]domain.JobRun, error) {
+	var runs []domain.JobRun
+	db := uow.Conn(ctx, r.DB)
+	err := db.Where("id IN (?)", db.Model(&domain.JobRun{}).Select("MAX(id)").Group("job")).Find(&runs).Error
+	return runs, err
+}
+
+// PurgeRuns deletes the finished runs started before before.
+func (r *SchedulerRepository) PurgeRuns(ctx context.Context, before time.Time) (int64, error) {
+	result := uow.Conn(ctx, r.DB).Where("started_at < ? AND status <> ?", before, domain.RunRunning).Delete(&domain.JobRun{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to setup mock db: %v", err)
+	}
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return gormDB, mock
+}
+
+func TestClaimDue(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewSchedulerRepository(db)
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `scheduled_jobs` SET `locked_by`=\\?,`locked_until`=\\?,`next_run_at`=\\?,`updated_at`=\\? WHERE name = \\? AND next_run_at <= \\? AND \\(locked_until IS NULL OR locked_until < \\?\\)").
+		WithArgs("host-1", now.Add(time.Minute), now.Add(time.Hour), sqlmock.AnyArg(), "purge", now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `scheduled_jobs`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	claimed, err := repo.ClaimDue(context.Background(), "purge", "host-1", now, time.Minute, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+
+	// another instance finds the row already claimed
+	claimed, err = repo.ClaimDue(context.Background(), "purge", "host-2", now, time.Minute, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncJob(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewSchedulerRepository(db)
+	next := time.Date(2024, 6, 2, 3, 15, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `scheduled_jobs` .* ON DUPLICATE KEY UPDATE `next_run_at`=IF\\(schedule = \\?, next_run_at, \\?\\),`schedule`=\\?").
+		WithArgs("purge", "15 3 * * *", next, nil, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), "15 3 * * *", next, "15 3 * * *").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.SyncJob(context.Background(), "purge", "15 3 * * *", next))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
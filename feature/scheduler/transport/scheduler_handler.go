@@ -0,0 +1,113 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/scheduler/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// SchedulerHandler handles the HTTP requests for the scheduled jobs, admins only.
+type SchedulerHandler struct {
+	usecase usecase.SchedulerUsecaseInterface
+}
+
+// NewSchedulerHandler creates a new instance of SchedulerHandler.
+func NewSchedulerHandler(usecase usecase.SchedulerUsecaseInterface) *SchedulerHandler {
+	return &SchedulerHandler{usecase: usecase}
+}
+
+// ListJobs godoc
+// @Summary List scheduled jobs
+// @Description Registered jobs with their schedule, next run, whether an instance is running them and their last run
+// @Produce json
+// @Tags scheduler
+// @Security bearerToken
+// @Success 200 {array} dto.JobResponseDTO
+// @Router /api/v1/admin/jobs [get]
+func (h *SchedulerHandler) ListJobs(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	jobs, err := h.usecase.ListJobs(c.Request.Context())
+	if err != nil {
+		c.JSON(schedulerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, jobs)
+}
+
+// ListJobRuns godoc
+// @Summary List the runs of a job
+// @Description Run history of a job, newest first
+// @Produce json
+// @Tags scheduler
+// @Param name path string true "Job name"
+// @Param limit query int false "Page size, 20 by default, at most 100"
+// @Security bearerToken
+// @Success 200 {array} dto.JobRunResponseDTO
+// @Router /api/v1/admin/jobs/{name}/runs [get]
+func (h *SchedulerHandler) ListJobRuns(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+	runs, err := h.usecase.ListRuns(c.Request.Context(), c.Param("name"), limit)
+	if err != nil {
+		c.JSON(schedulerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, runs)
+}
+
+// TriggerJob godoc
+// @Summary Run a job now
+// @Description Starts a run of the job out of its schedule, the run goes on in the background. 409 while an instance is running the job
+// @Produce json
+// @Tags scheduler
+// @Param name path string true "Job name"
+// @Security bearerToken
+// @Success 202 {object} dto.JobRunResponseDTO
+// @Router /api/v1/admin/jobs/{name}/run [post]
+func (h *SchedulerHandler) TriggerJob(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	run, err := h.usecase.Trigger(c.Request.Context(), c.Param("name"), c.GetInt("userId"))
+	if err != nil {
+		c.JSON(schedulerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, run)
+}
+
+func checkAdminRole(c *gin.Context) error {
+	roleId, exists := c.Get("roleId")
+	if !exists || roleId.(int) != 1 {
+		return errors.New("forbidden: only admins can perform this action")
+	}
+	return nil
+}
+
+func schedulerErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrUnknownJob):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrJobRunning):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,337 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/scheduler/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/scheduler/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/scheduler/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/tracing"
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	// tickInterval is how often every instance looks for due jobs
+	tickInterval = 15 * time.Second
+	// DefaultJobTimeout bounds a run, and the lock it holds, when the job sets no Timeout
+	DefaultJobTimeout = 10 * time.Minute
+	// RunRetention is how long the run history is kept by the job-runs-purge job
+	RunRetention    = 30 * 24 * time.Hour
+	defaultRunLimit = 20
+	maxRunLimit     = 100
+	runErrorLength  = 1000
+)
+
+var (
+	ErrUnknownJob = errors.New("job not found")
+	ErrJobRunning = errors.New("job is already running")
+)
+
+// Job is a recurring task. Schedule is a cron expression (minute hour day-of-month month
+// day-of-week, in the local time of the process) or a descriptor such as @hourly or @every 6h.
+// The context of Run is done after Timeout.
+type Job struct {
+	Name        string
+	Description string
+	Schedule    string
+	Timeout     time.Duration
+	Run         func(ctx context.Context) error
+}
+
+type SchedulerUsecaseInterface interface {
+	ListJobs(ctx context.Context) ([]dto.JobResponseDTO, error)
+	ListRuns(ctx context.Context, name string, limit int) ([]dto.JobRunResponseDTO, error)
+	Trigger(ctx context.Context, name string, userID int) (*dto.JobRunResponseDTO, error)
+}
+
+type registeredJob struct {
+	Job
+	schedule cron.Schedule
+}
+
+// SchedulerUsecase runs the registered jobs on their schedule. Every instance of the service
+// runs it, the lock on the row of a job in scheduled_jobs lets only one of them start each run.
+type SchedulerUsecase struct {
+	Repo     storage.SchedulerRepositoryInterface
+	instance string
+	jobs     []registeredJob
+	synced   bool
+	now      func() time.Time
+	running  sync.WaitGroup
+}
+
+// NewSchedulerUsecase creates a new instance of SchedulerUsecase with no job.
+func NewSchedulerUsecase(repo storage.SchedulerRepositoryInterface) *SchedulerUsecase {
+	return &SchedulerUsecase{Repo: repo, instance: instanceName(), now: time.Now}
+}
+
+// instanceName tells the instances apart in the locks and the run history
+func instanceName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// Register adds a job, before Run is started.
+func (u *SchedulerUsecase) Register(job Job) error {
+	schedule, err := cron.ParseStandard(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: invalid schedule %q: %w", job.Name, job.Schedule, err)
+	}
+	for _, registered := range u.jobs {
+		if registered.Name == job.Name {
+			return fmt.Errorf("job %s is registered twice", job.Name)
+		}
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = DefaultJobTimeout
+	}
+	u.jobs = append(u.jobs, registeredJob{Job: job, schedule: schedule})
+	return nil
+}
+
+// Run starts the due jobs until ctx is done, then waits for the runs it started.
+func (u *SchedulerUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+	for {
+		u.RunDue(ctx)
+		select {
+		case <-ctx.Done():
+			u.running.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue starts the jobs whose next run is due and that no instance is running.
+func (u *SchedulerUsecase) RunDue(ctx context.Context) {
+	now := u.now()
+	if !u.synced {
+		u.synced = u.sync(ctx, now)
+	}
+	for _, job := range u.jobs {
+		claimed, err := u.Repo.ClaimDue(ctx, job.Name, u.instance, now, job.Timeout, job.schedule.Next(now))
+		if err != nil {
+			slog.ErrorContext(ctx, "cannot claim job", "job", job.Name, "error", err)
+			continue
+		}
+		if claimed {
+			if _, err := u.start(ctx, job, domain.TriggerSchedule, nil); err != nil {
+				slog.ErrorContext(ctx, "cannot start job", "job", job.Name, "error", err)
+			}
+		}
+	}
+}
+
+// sync records the registered jobs, it is retried on the next tick when it fails
+func (u *SchedulerUsecase) sync(ctx context.Context, now time.Time) bool {
+	for _, job := range u.jobs {
+		if err := u.Repo.SyncJob(ctx, job.Name, job.Schedule, job.schedule.Next(now)); err != nil {
+			slog.ErrorContext(ctx, "cannot record job", "job", job.Name, "error", err)
+			return false
+		}
+	}
+	return true
+}
+
+// start records the run of a claimed job and runs it in the background
+func (u *SchedulerUsecase) start(ctx context.Context, job registeredJob, trigger string, userID *int) (*dto.JobRunResponseDTO, error) {
+	run := &domain.JobRun{
+		Job:         job.Name,
+		Trigger:     trigger,
+		TriggeredBy: userID,
+		Instance:    u.instance,
+		Status:      domain.RunRunning,
+		StartedAt:   u.now(),
+	}
+	if err := u.Repo.CreateRun(ctx, run); err != nil {
+		if releaseErr := u.Repo.Release(ctx, job.Name, u.instance, u.now()); releaseErr != nil {
+			slog.ErrorContext(ctx, "cannot release job", "job", job.Name, "error", releaseErr)
+		}
+		return nil, err
+	}
+	started := toRunDTO(run)
+	u.running.Add(1)
+	go func() {
+		defer u.running.Done()
+		u.execute(ctx, job, run)
+	}()
+	return started, nil
+}
+
+// execute runs the job, records the outcome and releases the lock
+func (u *SchedulerUsecase) execute(ctx context.Context, job registeredJob, run *domain.JobRun) {
+	runCtx, cancel := context.WithTimeout(ctx, job.Timeout)
+	defer cancel()
+	runCtx, span := tracing.Tracer("scheduler").Start(runCtx, "job "+job.Name)
+	defer span.End()
+
+	err := safeRun(runCtx, job.Run)
+	finished := u.now()
+	run.FinishedAt = &finished
+	run.Status = domain.RunSucceeded
+	if err != nil {
+		message := err.Error()
+		if len(message) > runErrorLength {
+			message = message[:runErrorLength]
+		}
+		run.Status = domain.RunFailed
+		run.Error = &message
+		span.SetStatus(codes.Error, message)
+		slog.ErrorContext(runCtx, "job failed", "job", job.Name, "run", run.ID, "error", err)
+	}
+	// the outcome is recorded even when the run was cancelled by the shutdown
+	storeCtx := context.WithoutCancel(ctx)
+	if err := u.Repo.FinishRun(storeCtx, run); err != nil {
+		slog.ErrorContext(storeCtx, "cannot record job run", "job", job.Name, "run", run.ID, "error", err)
+	}
+	if err := u.Repo.Release(storeCtx, job.Name, u.instance, finished); err != nil {
+		slog.ErrorContext(storeCtx, "cannot release job", "job", job.Name, "error", err)
+	}
+}
+
+// safeRun turns a panic of the job into a failed run
+func safeRun(ctx context.Context, run func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return run(ctx)
+}
+
+func (u *SchedulerUsecase) find(name string) (registeredJob, bool) {
+	for _, job := range u.jobs {
+		if job.Name == name {
+			return job, true
+		}
+	}
+	return registeredJob{}, false
+}
+
+// ListJobs returns the registered jobs in registration order.
+func (u *SchedulerUsecase) ListJobs(ctx context.Context) ([]dto.JobResponseDTO, error) {
+	rows, err := u.Repo.ListJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]domain.ScheduledJob, len(rows))
+	for _, row := range rows {
+		states[row.Name] = row
+	}
+	runs, err := u.Repo.LastRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastRuns := make(map[string]domain.JobRun, len(runs))
+	for _, run := range runs {
+		lastRuns[run.Job] = run
+	}
+
+	now := u.now()
+	jobs := make([]dto.JobResponseDTO, 0, len(u.jobs))
+	for _, job := range u.jobs {
+		item := dto.JobResponseDTO{
+			Name:        job.Name,
+			Description: job.Description,
+			Schedule:    job.Schedule,
+			Timeout:     job.Timeout.String(),
+		}
+		if state, ok := states[job.Name]; ok {
+			nextRunAt := state.NextRunAt
+			item.NextRunAt = &nextRunAt
+			item.LastRunAt = state.LastRunAt
+			item.Running = state.Locked(now)
+			if item.Running {
+				item.LockedBy = state.LockedBy
+				item.LockedUntil = state.LockedUntil
+			}
+		}
+		if run, ok := lastRuns[job.Name]; ok {
+			item.LastRun = toRunDTO(&run)
+		}
+		jobs = append(jobs, item)
+	}
+	return jobs, nil
+}
+
+// ListRuns returns the last runs of a job, newest first.
+func (u *SchedulerUsecase) ListRuns(ctx context.Context, name string, limit int) ([]dto.JobRunResponseDTO, error) {
+	if _, ok := u.find(name); !ok {
+		return nil, ErrUnknownJob
+	}
+	if limit <= 0 {
+		limit = defaultRunLimit
+	}
+	if limit > maxRunLimit {
+		limit = maxRunLimit
+	}
+	runs, err := u.Repo.ListRuns(ctx, name, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dto.JobRunResponseDTO, 0, len(runs))
+	for i := range runs {
+		result = append(result, *toRunDTO(&runs[i]))
+	}
+	return result, nil
+}
+
+// Trigger starts a run of the job now, out of its schedule. The next scheduled run is kept.
+func (u *SchedulerUsecase) Trigger(ctx context.Context, name string, userID int) (*dto.JobRunResponseDTO, error) {
+	job, ok := u.find(name)
+	if !ok {
+		return nil, ErrUnknownJob
+	}
+	now := u.now()
+	// the server may not run the scheduler, the row of the job must exist to be claimed
+	if err := u.Repo.SyncJob(ctx, job.Name, job.Schedule, job.schedule.Next(now)); err != nil {
+		return nil, err
+	}
+	claimed, err := u.Repo.Claim(ctx, name, u.instance, now, job.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrJobRunning
+	}
+	// the run outlives the request that triggered it
+	return u.start(context.WithoutCancel(ctx), job, domain.TriggerManual, &userID)
+}
+
+// PurgeRuns deletes the run history older than RunRetention, it is the job-runs-purge job.
+func (u *SchedulerUsecase) PurgeRuns(ctx context.Context) error {
+	purged, err := u.Repo.PurgeRuns(ctx, u.now().Add(-RunRetention))
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		slog.InfoContext(ctx, "purged job runs", "count", purged)
+	}
+	return nil
+}
+
+func toRunDTO(run *domain.JobRun) *dto.JobRunResponseDTO {
+	return &dto.JobRunResponseDTO{
+		ID:          run.ID,
+		Job:         run.Job,
+		Trigger:     run.Trigger,
+		TriggeredBy: run.TriggeredBy,
+		Instance:    run.Instance,
+		Status:      run.Status,
+		Error:       run.Error,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+	}
+}
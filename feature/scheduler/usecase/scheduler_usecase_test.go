@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/scheduler/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// memorySchedulerRepository claims like the SQL of SchedulerRepository
+type memorySchedulerRepository struct {
+	mu   sync.Mutex
+	jobs map[string]*domain.ScheduledJob
+	runs []domain.JobRun
+}
+
+func newMemorySchedulerRepository() *memorySchedulerRepository {
+	return &memorySchedulerRepository{jobs: map[string]*domain.ScheduledJob{}}
+}
+
+func (r *memorySchedulerRepository) SyncJob(ctx context.Context, name string, schedule string, nextRunAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if job, ok := r.jobs[name]; ok {
+		if job.Schedule != schedule {
+			job.Schedule, job.NextRunAt = schedule, nextRunAt
+		}
+		return nil
+	}
+	r.jobs[name] = &domain.ScheduledJob{Name: name, Schedule: schedule, NextRunAt: nextRunAt}
+	return nil
+}
+
+func (r *memorySchedulerRepository) ListJobs(ctx context.Context) ([]domain.ScheduledJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var jobs []domain.ScheduledJob
+	for _, job := range r.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs, nil
+}
+
+func (r *memorySchedulerRepository) ClaimDue(ctx context.Context, name string, instance string, now time.Time, lease time.Duration, nextRunAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[name]
+	if !ok || job.NextRunAt.After(now) || job.Locked(now) {
+		return false, nil
+	}
+	lockedUntil := now.Add(lease)
+	job.LockedBy, job.LockedUntil, job.NextRunAt = &instance, &lockedUntil, nextRunAt
+	return true, nil
+}
+
+func (r *memorySchedulerRepository) Claim(ctx context.Context, name string, instance string, now time.Time, lease time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[name]
+	if !ok || job.Locked(now) {
+		return false, nil
+	}
+	lockedUntil := now.Add(lease)
+	job.LockedBy, job.LockedUntil = &instance, &lockedUntil
+	return true, nil
+}
+
+func (r *memorySchedulerRepository) Release(ctx context.Context, name string, instance string, finishedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if job, ok := r.jobs[name]; ok && job.LockedBy != nil && *job.LockedBy == instance {
+		job.LockedBy, job.LockedUntil, job.LastRunAt = nil, nil, &finishedAt
+	}
+	return nil
+}
+
+func (r *memorySchedulerRepository) CreateRun(ctx context.Context, run *domain.JobRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run.ID = len(r.runs) + 1
+	r.runs = append(r.runs, *run)
+	return nil
+}
+
+func (r *memorySchedulerRepository) FinishRun(ctx context.Context, run *domain.JobRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs[run.ID-1] = *run
+	return nil
+}
+
+func (r *memorySchedulerRepository) ListRuns(ctx context.Context, job string, limit int) ([]domain.JobRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var runs []domain.JobRun
+	for i := len(r.runs) - 1; i >= 0 && len(runs) < limit; i-- {
+		if r.runs[i].Job == job {
+			runs = append(runs, r.runs[i])
+		}
+	}
+	return runs, nil
+}
+
+func (r *memorySchedulerRepository) LastRuns(ctx context.Context) ([]domain.JobRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	last := map[string]domain.JobRun{}
+	for _, run := range r.runs {
+		last[run.Job] = run
+	}
+	var runs []domain.JobRun
+	for _, run := range last {
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+func (r *memorySchedulerRepository) PurgeRuns(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func newScheduler(t *testing.T, repo *memorySchedulerRepository, now time.Time, jobs ...Job) *SchedulerUsecase {
+	scheduler := NewSchedulerUsecase(repo)
+	scheduler.now = func() time.Time { return now }
+	for _, job := range jobs {
+		if err := scheduler.Register(job); err != nil {
+			t.Fatalf("failed to register %s: %v", job.Name, err)
+		}
+	}
+	return scheduler
+}
+
+func TestRegister(t *testing.T) {
+	scheduler := NewSchedulerUsecase(newMemorySchedulerRepository())
+	noop := func(context.Context) error { return nil }
+
+	assert.NoError(t, scheduler.Register(Job{Name: "purge", Schedule: "@every 1h", Run: noop}))
+	assert.EqualError(t, scheduler.Register(Job{Name: "purge", Schedule: "@daily", Run: noop}), "job purge is registered twice")
+	assert.ErrorContains(t, scheduler.Register(Job{Name: "broken", Schedule: "every day", Run: noop}), `job broken: invalid schedule "every day"`)
+	assert.Equal(t, DefaultJobTimeout, scheduler.jobs[0].Timeout)
+}
+
+func TestRunDue(t *testing.T) {
+	repo := newMemorySchedulerRepository()
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	runs := make(chan string, 4)
+	jobs := []Job{
+		{Name: "purge", Schedule: "0 * * * *", Run: func(context.Context) error {
+			runs <- "purge"
+			return nil
+		}},
+		{Name: "broken", Schedule: "0 * * * *", Run: func(context.Context) error { return errors.New("table is missing") }},
+	}
+	// the jobs were recorded an hour ago, their run of 10:00 is due
+	repo.SyncJob(context.Background(), "purge", "0 * * * *", now)
+	repo.SyncJob(context.Background(), "broken", "0 * * * *", now)
+	first := newScheduler(t, repo, now, jobs...)
+	second := newScheduler(t, repo, now, jobs...)
+
+	first.RunDue(context.Background())
+	second.RunDue(context.Background())
+	first.running.Wait()
+	second.running.Wait()
+
+	// only one instance ran each job
+	assert.Len(t, runs, 1)
+	assert.Len(t, repo.runs, 2)
+	for _, run := range repo.runs {
+		assert.Equal(t, first.instance, run.Instance)
+		assert.Equal(t, domain.TriggerSchedule, run.Trigger)
+		assert.NotNil(t, run.FinishedAt)
+	}
+	assert.Equal(t, domain.RunSucceeded, repo.runs[0].Status)
+	assert.Equal(t, domain.RunFailed, repo.runs[1].Status)
+	assert.Equal(t, "table is missing", *repo.runs[1].Error)
+	assert.Equal(t, now.Add(time.Hour), repo.jobs["purge"].NextRunAt)
+	assert.Nil(t, repo.jobs["purge"].LockedBy)
+
+	// nothing is due before 11:00
+	first.RunDue(context.Background())
+	first.running.Wait()
+	assert.Len(t, repo.runs, 2)
+}
+
+func TestTrigger(t *testing.T) {
+	repo := newMemorySchedulerRepository()
+	now := time.Date(2024, 6, 1, 10, 30, 0, 0, time.UTC)
+	release := make(chan struct{})
+	scheduler := newScheduler(t, repo, now, Job{Name: "purge", Schedule: "@daily", Run: func(context.Context) error {
+		<-release
+		return nil
+	}})
+
+	run, err := scheduler.Trigger(context.Background(), "purge", 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TriggerManual, run.Trigger)
+	assert.Equal(t, 4, *run.TriggeredBy)
+	assert.Equal(t, domain.RunRunning, run.Status)
+
+	_, err = scheduler.Trigger(context.Background(), "purge", 4)
+	assert.ErrorIs(t, err, ErrJobRunning)
+	_, err = scheduler.Trigger(context.Background(), "unknown", 4)
+	assert.ErrorIs(t, err, ErrUnknownJob)
+
+	jobs, err := scheduler.ListJobs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.True(t, jobs[0].Running)
+	assert.Equal(t, "10m0s", jobs[0].Timeout)
+
+	close(release)
+	scheduler.running.Wait()
+	runs, err := scheduler.ListRuns(context.Background(), "purge", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RunSucceeded, runs[0].Status)
+	// a manual run keeps the next scheduled one
+	assert.Equal(t, time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), repo.jobs["purge"].NextRunAt)
+}
@@ -0,0 +1,146 @@
+// Package security holds the browser facing protections of the HTTP server: CORS, the security
+// headers, the proxies trusted for the client ip and whether the Swagger UI is served.
+//
+// Everything is read from the environment:
+//
+//	CORS_ALLOW_ORIGINS      comma separated origins, * (the default) allows any origin
+//	CORS_ALLOW_METHODS      comma separated methods, GET, POST, PUT, PATCH, DELETE, HEAD and OPTIONS by default
+//	CORS_ALLOW_HEADERS      comma separated request headers allowed on top of the default ones
+//	CORS_ALLOW_CREDENTIALS  true lets browsers send cookies and auth, it needs explicit origins
+//	CORS_MAX_AGE            how long browsers cache a preflight, 12h by default
+//	HSTS_MAX_AGE            Strict-Transport-Security max-age, 8760h by default, 0 turns it off
+//	TRUSTED_PROXIES         comma separated ips or CIDRs whose X-Forwarded-For is believed, none by default
+//	TRUSTED_PLATFORM        cloudflare, google or the header carrying the client ip set by the platform
+//	DOCS_ENABLED            false stops serving the Swagger UI on /docs
+package security
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	defaultMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead, http.MethodOptions}
+	defaultHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-Request-ID", "X-API-Key", "traceparent", "If-None-Match"}
+	// the response headers scripts of another origin may read
+	exposedHeaders = []string{"Content-Disposition", "X-Request-ID", "X-Trace-ID", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "ETag"}
+)
+
+// Config is the security configuration of the server.
+type Config struct {
+	CORS            cors.Config
+	HSTSMaxAge      time.Duration
+	TrustedProxies  []string
+	TrustedPlatform string
+	DocsEnabled     bool
+}
+
+// ConfigFromEnv reads the Config from the environment variables listed in the package doc.
+func ConfigFromEnv() (Config, error) {
+	config := Config{
+		CORS: cors.Config{
+			AllowMethods:  defaultMethods,
+			AllowHeaders:  defaultHeaders,
+			ExposeHeaders: exposedHeaders,
+			MaxAge:        12 * time.Hour,
+		},
+		HSTSMaxAge:     365 * 24 * time.Hour,
+		TrustedProxies: list(os.Getenv("TRUSTED_PROXIES")),
+		DocsEnabled:    true,
+	}
+
+	origins := list(os.Getenv("CORS_ALLOW_ORIGINS"))
+	if len(origins) == 0 || (len(origins) == 1 && origins[0] == "*") {
+		config.CORS.AllowAllOrigins = true
+	} else {
+		config.CORS.AllowOrigins = origins
+	}
+	if methods := list(os.Getenv("CORS_ALLOW_METHODS")); len(methods) > 0 {
+		config.CORS.AllowMethods = methods
+	}
+	config.CORS.AllowHeaders = append(config.CORS.AllowHeaders, list(os.Getenv("CORS_ALLOW_HEADERS"))...)
+
+	var err error
+	if config.CORS.AllowCredentials, err = boolEnv("CORS_ALLOW_CREDENTIALS", false); err != nil {
+		return Config{}, err
+	}
+	if config.CORS.AllowCredentials && config.CORS.AllowAllOrigins {
+		return Config{}, errors.New("CORS_ALLOW_CREDENTIALS needs the origins listed in CORS_ALLOW_ORIGINS")
+	}
+	if config.CORS.MaxAge, err = durationEnv("CORS_MAX_AGE", config.CORS.MaxAge); err != nil {
+		return Config{}, err
+	}
+	if config.HSTSMaxAge, err = durationEnv("HSTS_MAX_AGE", config.HSTSMaxAge); err != nil {
+		return Config{}, err
+	}
+	if config.DocsEnabled, err = boolEnv("DOCS_ENABLED", true); err != nil {
+		return Config{}, err
+	}
+
+	switch platform := os.Getenv("TRUSTED_PLATFORM"); strings.ToLower(platform) {
+	case "":
+	case "cloudflare":
+		config.TrustedPlatform = gin.PlatformCloudflare
+	case "google":
+		config.TrustedPlatform = gin.PlatformGoogleAppEngine
+	default:
+		config.TrustedPlatform = platform
+	}
+	return config, nil
+}
+
+// Apply sets the trusted proxies of router and adds the CORS and security header middlewares.
+func (c Config) Apply(router *gin.Engine) error {
+	// with no proxy trusted gin takes the ip of the connection, X-Forwarded-For is ignored
+	if err := router.SetTrustedProxies(c.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+	router.TrustedPlatform = c.TrustedPlatform
+	if err := c.CORS.Validate(); err != nil {
+		return fmt.Errorf("invalid CORS configuration: %w", err)
+	}
+	router.Use(cors.New(c.CORS), Headers(c.HSTSMaxAge))
+	return nil
+}
+
+func list(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func boolEnv(name string, fallback bool) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	return b, nil
+}
+
+func durationEnv(name string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q, use a duration such as 12h", name, value)
+	}
+	return d, nil
+}
@@ -0,0 +1,118 @@
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRouter(t *testing.T, config Config) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if err := config.Apply(router); err != nil {
+		t.Fatalf("failed to apply config: %v", err)
+	}
+	router.GET("/api/v1/ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+	router.GET(DocsPath+"*any", func(c *gin.Context) { c.String(http.StatusOK, "swagger") })
+	return router
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("CORS_ALLOW_ORIGINS", "https://app.example.org, https://admin.example.org")
+	t.Setenv("CORS_ALLOW_HEADERS", "X-Client-Version")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	t.Setenv("HSTS_MAX_AGE", "0")
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8")
+	t.Setenv("TRUSTED_PLATFORM", "cloudflare")
+	t.Setenv("DOCS_ENABLED", "false")
+
+	config, err := ConfigFromEnv()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://app.example.org", "https://admin.example.org"}, config.CORS.AllowOrigins)
+	assert.False(t, config.CORS.AllowAllOrigins)
+	assert.True(t, config.CORS.AllowCredentials)
+	assert.Contains(t, config.CORS.AllowHeaders, "X-Client-Version")
+	assert.Contains(t, config.CORS.AllowHeaders, "Authorization")
+	assert.Equal(t, 12*time.Hour, config.CORS.MaxAge)
+	assert.Equal(t, time.Duration(0), config.HSTSMaxAge)
+	assert.Equal(t, []string{"10.0.0.0/8"}, config.TrustedProxies)
+	assert.Equal(t, gin.PlatformCloudflare, config.TrustedPlatform)
+	assert.False(t, config.DocsEnabled)
+}
+
+func TestConfigFromEnv_Invalid(t *testing.T) {
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	_, err := ConfigFromEnv()
+	assert.EqualError(t, err, "CORS_ALLOW_CREDENTIALS needs the origins listed in CORS_ALLOW_ORIGINS")
+
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "")
+	t.Setenv("CORS_MAX_AGE", "soon")
+	_, err = ConfigFromEnv()
+	assert.EqualError(t, err, `invalid CORS_MAX_AGE "soon", use a duration such as 12h`)
+}
+
+func TestApply_CORS(t *testing.T) {
+	t.Setenv("CORS_ALLOW_ORIGINS", "https://app.example.org")
+	config, err := ConfigFromEnv()
+	assert.NoError(t, err)
+	router := setupRouter(t, config)
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodOptions, "/api/v1/ip", nil)
+		request.Header.Set("Origin", origin)
+		request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		return response
+	}
+
+	response := preflight("https://app.example.org")
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Equal(t, "https://app.example.org", response.Header().Get("Access-Control-Allow-Origin"))
+
+	response = preflight("https://evil.example.com")
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	assert.Empty(t, response.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestApply_Headers(t *testing.T) {
+	config, err := ConfigFromEnv()
+	assert.NoError(t, err)
+	router := setupRouter(t, config)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/api/v1/ip", nil))
+
+	assert.Equal(t, apiPolicy, response.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "nosniff", response.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", response.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "max-age=31536000; includeSubDomains", response.Header().Get("Strict-Transport-Security"))
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, DocsPath+"index.html", nil))
+
+	assert.Equal(t, docsPolicy, response.Header().Get("Content-Security-Policy"))
+}
+
+func TestApply_TrustedProxies(t *testing.T) {
+	send := func(router *gin.Engine) string {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/ip", nil)
+		request.RemoteAddr = "10.1.2.3:4567"
+		request.Header.Set("X-Forwarded-For", "203.0.113.9")
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		return response.Body.String()
+	}
+
+	config, err := ConfigFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, "10.1.2.3", send(setupRouter(t, config)))
+
+	config.TrustedProxies = []string{"10.0.0.0/8"}
+	assert.Equal(t, "203.0.113.9", send(setupRouter(t, config)))
+}
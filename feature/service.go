@@ -0,0 +1,31 @@
+package feature
+
+import (
+	"log/slog"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/logging"
+	"github.com/cesc1802/share-module/system"
+)
+
+// Service is the system.Service the features are registered on, with the structured logger.
+type Service interface {
+	system.Service
+	Slog() *slog.Logger
+}
+
+type service struct {
+	system.Service
+	logger *slog.Logger
+}
+
+// WithLogger attaches logger to sys. It also becomes the slog default, which the standard log
+// package writes through, and the logger of the GORM connection.
+func WithLogger(sys system.Service, logger *slog.Logger) Service {
+	slog.SetDefault(logger)
+	sys.DB().Logger = logging.NewGormLogger(logger)
+	return &service{Service: sys, logger: logger}
+}
+
+func (s *service) Slog() *slog.Logger {
+	return s.logger
+}
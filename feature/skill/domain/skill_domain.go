@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+)
+
+// Skill categories of the taxonomy
+const (
+	CategorySkill         = "skill"
+	CategoryLanguage      = "language"
+	CategoryCertification = "certification"
+)
+
+// Skill struct is an entry of the skills/languages/certifications taxonomy (GORM)
+type Skill struct {
+	Id        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:100;not null" json:"name"`
+	Category  string    `gorm:"size:20;not null" json:"category"`
+	Status    uint      `gorm:"not null" json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// VolunteerSkill is a skill declared by a volunteer, optionally verified by an admin.
+// ExpiresAt is only meaningful for certifications.
+type VolunteerSkill struct {
+	ID          int  `gorm:"primaryKey"`
+	VolunteerID int  `gorm:"index;not null"`
+	SkillID     uint `gorm:"index;not null"`
+	Level       int  `gorm:"not null"`
+	VerifiedBy  *int `gorm:"index"`
+	VerifiedAt  *time.Time
+	ExpiresAt   *time.Time
+	Skill       Skill     `gorm:"foreignKey:SkillID"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// Verified reports whether an admin has confirmed the skill
+func (s *VolunteerSkill) Verified() bool {
+	return s.VerifiedAt != nil
+}
+
+// Expired reports whether the certificate is past its expiry date at the given time
+func (s *VolunteerSkill) Expired(at time.Time) bool {
+	return s.ExpiresAt != nil && s.ExpiresAt.Before(at)
+}
@@ -0,0 +1,61 @@
+package dto
+
+// SkillCreateDTO represents the data transfer object for creating a skill.
+type SkillCreateDTO struct {
+	Name     string `json:"name" binding:"required"`
+	Category string `json:"category" binding:"required,oneof=skill language certification"`
+	Status   uint   `json:"status" binding:"required"`
+}
+
+// SkillUpdateDTO represents the data transfer object for updating a skill.
+type SkillUpdateDTO struct {
+	Name     string `json:"name" binding:"required"`
+	Category string `json:"category" binding:"required,oneof=skill language certification"`
+	Status   uint   `json:"status"`
+}
+
+// VolunteerSkillDTO is one self-declared skill, level goes from 1 (basic) to 5 (expert)
+type VolunteerSkillDTO struct {
+	SkillID   uint   `json:"skill_id" binding:"required"`
+	Level     int    `json:"level" binding:"required,min=1,max=5"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// VolunteerSkillsUpdateDTO replaces the declared skills of a volunteer.
+type VolunteerSkillsUpdateDTO struct {
+	Skills []VolunteerSkillDTO `json:"skills" binding:"dive"`
+}
+
+// VolunteerSkillResponseDTO represents a declared skill in responses.
+type VolunteerSkillResponseDTO struct {
+	SkillID   uint   `json:"skill_id"`
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	Level     int    `json:"level"`
+	Verified  bool   `json:"verified"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	Expired   bool   `json:"expired"`
+}
+
+// SkillRequirementDTO is one requirement of an activity.
+type SkillRequirementDTO struct {
+	SkillID      uint `json:"skill_id" binding:"required"`
+	MinLevel     int  `json:"min_level"`
+	Required     bool `json:"required"`
+	VerifiedOnly bool `json:"verified_only"`
+}
+
+// SkillMatchRequestDTO describes the activity the volunteers are matched against.
+type SkillMatchRequestDTO struct {
+	DepartmentID int                   `json:"department_id"`
+	Requirements []SkillRequirementDTO `json:"requirements" binding:"required,min=1,dive"`
+	Limit        int                   `json:"limit"`
+}
+
+// SkillMatchResponseDTO is a ranked volunteer.
+type SkillMatchResponseDTO struct {
+	VolunteerID     int     `json:"volunteer_id"`
+	Score           float64 `json:"score"`
+	MatchedSkillIDs []uint  `json:"matched_skill_ids"`
+	MissingSkillIDs []uint  `json:"missing_skill_ids"`
+}
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/skill/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"gorm.io/gorm"
+)
+
+// SkillRepositoryInterface defines the methods that any repository implementation must provide.
+type SkillRepositoryInterface interface {
+	Create(ctx context.Context, skill *domain.Skill) error
+	GetAll(ctx context.Context, category string) ([]domain.Skill, error)
+	GetByID(ctx context.Context, id uint) (*domain.Skill, error)
+	Update(ctx context.Context, skill *domain.Skill) error
+	Delete(ctx context.Context, id uint) error
+	ListVolunteerSkills(ctx context.Context, volunteerID int) ([]*domain.VolunteerSkill, error)
+	ReplaceVolunteerSkills(ctx context.Context, volunteerID int, skills []*domain.VolunteerSkill) error
+	VerifyVolunteerSkill(ctx context.Context, volunteerID int, skillID uint, verifierID int) error
+	FindVolunteerSkillsForMatch(ctx context.Context, skillIDs []uint, departmentID int) ([]*domain.VolunteerSkill, error)
+}
+
+// SkillRepository handles the CRUD operations with the database.
+type SkillRepository struct {
+	DB *gorm.DB
+}
+
+// NewSkillRepository creates a new instance of SkillRepository.
+func NewSkillRepository(db *gorm.DB) *SkillRepository {
+	return &SkillRepository{DB: db}
+}
+
+// Create inserts a new skill record into the database.
+func (r *SkillRepository) Create(ctx context.Context, skill *domain.Skill) error {
+	return uow.Conn(ctx, r.DB).Create(skill).Error
+}
+
+// GetAll retrieves the taxonomy, optionally restricted to one category.
+func (r *SkillRepository) GetAll(ctx context.Context, category string) ([]domain.Skill, error) {
+	var skills []domain.Skill
+	query := uow.Conn(ctx, r.DB).Order("category, name")
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	err := query.Find(&skills).Error
+	return skills, err
+}
+
+// GetByID retrieves a skill record by its ID from the database.
+func (r *SkillRepository) GetByID(ctx context.Context, id uint) (*domain.Skill, error) {
+	var skill domain.Skill
+	err := uow.Conn(ctx, r.DB).First(&skill, id).Error
+	return &skill, err
+}
+
+// Update updates a skill record in the database.
+func (r *SkillRepository) Update(ctx context.Context, skill *domain.Skill) error {
+	return uow.Conn(ctx, r.DB).Save(skill).Error
+}
+
+// Delete deletes a skill record together with the declarations referencing it.
+func (r *SkillRepository) Delete(ctx context.Context, id uint) error {
+	return uow.Conn(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("skill_id = ?", id).Delete(&domain.VolunteerSkill{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Skill{}, id).Error
+	})
+}
+
+// ListVolunteerSkills retrieves the declared skills of a volunteer with their taxonomy entry.
+func (r *SkillRepository) ListVolunteerSkills(ctx context.Context, volunteerID int) ([]*domain.VolunteerSkill, error) {
+	var skills []*domain.VolunteerSkill
+	err := uow.Conn(ctx, r.DB).Preload("Skill").Where("volunteer_id = ?", volunteerID).Find(&skills).Error
+	return skills, err
+}
+
+// ReplaceVolunteerSkills swaps the declared skills of a volunteer. A verification is kept
+// only when the skill is re-declared with the same level and expiry date.
+func (r *SkillRepository) ReplaceVolunteerSkills(ctx context.Context, volunteerID int, skills []*domain.VolunteerSkill) error {
+	return uow.Conn(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		var existing []*domain.VolunteerSkill
+		if err := tx.Where("volunteer_id = ?", volunteerID).Find(&existing).Error; err != nil {
+			return err
+		}
+		previous := make(map[uint]*domain.VolunteerSkill, len(existing))
+		for _, skill := range existing {
+			previous[skill.SkillID] = skill
+		}
+		for _, skill := range skills {
+			old, ok := previous[skill.SkillID]
+			if ok && old.Level == skill.Level && sameDate(old.ExpiresAt, skill.ExpiresAt) {
+				skill.VerifiedBy = old.VerifiedBy
+				skill.VerifiedAt = old.VerifiedAt
+			}
+		}
+		if err := tx.Where("volunteer_id = ?", volunteerID).Delete(&domain.VolunteerSkill{}).Error; err != nil {
+			return err
+		}
+		if len(skills) == 0 {
+			return nil
+		}
+		return tx.Omit("Skill").Create(&skills).Error
+	})
+}
+
+// VerifyVolunteerSkill marks a declared skill as verified by an admin.
+func (r *SkillRepository) VerifyVolunteerSkill(ctx context.Context, volunteerID int, skillID uint, verifierID int) error {
+	result := uow.Conn(ctx, r.DB).Model(&domain.VolunteerSkill{}).
+		Where("volunteer_id = ? AND skill_id = ?", volunteerID, skillID).
+		Updates(map[string]interface{}{
+			"verified_by": verifierID,
+			"verified_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("volunteer has not declared this skill")
+	}
+	return nil
+}
+
+// FindVolunteerSkillsForMatch retrieves declarations of active volunteers for the given skills,
+// restricted to one department when departmentID is set.
+func (r *SkillRepository) FindVolunteerSkillsForMatch(ctx context.Context, skillIDs []uint, departmentID int) ([]*domain.VolunteerSkill, error) {
+	var skills []*domain.VolunteerSkill
+	query := uow.Conn(ctx, r.DB).Model(&domain.VolunteerSkill{}).
+		Joins("JOIN volunteer_details ON volunteer_details.id = volunteer_skills.volunteer_id").
+		Where("volunteer_skills.skill_id IN ? AND volunteer_details.status = ?", skillIDs, 1)
+	if departmentID != 0 {
+		query = query.Where("volunteer_details.department_id = ?", departmentID)
+	}
+	err := query.Find(&skills).Error
+	return skills, err
+}
+
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Format("2006-01-02") == b.Format("2006-01-02")
+}
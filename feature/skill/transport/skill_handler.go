@@ -0,0 +1,270 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/skill/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/skill/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// SkillHandler handles the HTTP requests for skills.
+type SkillHandler struct {
+	usecase usecase.SkillUsecaseInterface
+}
+
+// NewSkillHandler creates a new instance of SkillHandler.
+func NewSkillHandler(usecase usecase.SkillUsecaseInterface) *SkillHandler {
+	return &SkillHandler{usecase: usecase}
+}
+
+// CreateSkill handles the HTTP POST request to create a new skill.
+// CreateSkill godoc
+// @Summary Create a new skill
+// @Description Create a skill, language or certification in the taxonomy
+// @Accept json
+// @Produce json
+// @Tags skill
+// @Param skill body dto.SkillCreateDTO true "Skill data"
+// @Success 201 {object} domain.Skill
+// @Router /api/v1/skills [post]
+func (h *SkillHandler) CreateSkill(c *gin.Context) {
+	var input dto.SkillCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill, err := h.usecase.CreateSkill(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, skill)
+}
+
+// GetAllSkills handles the HTTP GET request to retrieve the taxonomy.
+// GetAllSkills godoc
+// @Summary Get all skills
+// @Description Get all skills, optionally filtered by category
+// @Produce json
+// @Tags skill
+// @Param category query string false "skill, language or certification"
+// @Success 200 {array} domain.Skill
+// @Router /api/v1/skills [get]
+func (h *SkillHandler) GetAllSkills(c *gin.Context) {
+	skills, err := h.usecase.GetAllSkills(c.Request.Context(), c.Query("category"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, skills)
+}
+
+// GetSkillByID handles the HTTP GET request to retrieve a skill by its ID.
+// GetSkillByID godoc
+// @Summary Get skill by ID
+// @Description Get skill by ID
+// @Produce json
+// @Tags skill
+// @Param id path int true "Skill ID"
+// @Success 200 {object} domain.Skill
+// @Router /api/v1/skills/{id} [get]
+func (h *SkillHandler) GetSkillByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	skill, err := h.usecase.GetSkillByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Skill not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, skill)
+}
+
+// UpdateSkill handles the HTTP PUT request to update a skill.
+// UpdateSkill godoc
+// @Summary Update skill
+// @Description Update skill
+// @Accept json
+// @Produce json
+// @Tags skill
+// @Param id path int true "Skill ID"
+// @Param skill body dto.SkillUpdateDTO true "Skill data"
+// @Success 200 {object} domain.Skill
+// @Router /api/v1/skills/{id} [put]
+func (h *SkillHandler) UpdateSkill(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	var input dto.SkillUpdateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill, err := h.usecase.UpdateSkill(c.Request.Context(), uint(id), input)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, skill)
+}
+
+// DeleteSkill handles the HTTP DELETE request to delete a skill.
+// DeleteSkill godoc
+// @Summary Delete skill
+// @Description Delete skill and the volunteer declarations referencing it
+// @Tags skill
+// @Param id path int true "Skill ID"
+// @Success 204
+// @Router /api/v1/skills/{id} [delete]
+func (h *SkillHandler) DeleteSkill(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	if err := h.usecase.DeleteSkill(c.Request.Context(), uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetVolunteerSkills handles the HTTP GET request to list the skills of a volunteer.
+// GetVolunteerSkills godoc
+// @Summary Get volunteer skills
+// @Description Get the skills, languages and certifications declared by a volunteer
+// @Produce json
+// @Tags skill
+// @Param id path int true "Volunteer ID"
+// @Success 200 {array} dto.VolunteerSkillResponseDTO
+// @Router /api/v1/volunteer/{id}/skills [get]
+func (h *SkillHandler) GetVolunteerSkills(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	skills, err := h.usecase.GetVolunteerSkills(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, skills)
+}
+
+// UpdateVolunteerSkills handles the HTTP PUT request to declare the skills of a volunteer.
+// UpdateVolunteerSkills godoc
+// @Summary Declare volunteer skills
+// @Description Replace the skills declared by a volunteer, unchanged verified skills stay verified
+// @Accept json
+// @Produce json
+// @Tags skill
+// @Param id path int true "Volunteer ID"
+// @Param request body dto.VolunteerSkillsUpdateDTO true "Declared skills"
+// @Success 200 {string} message "Skills updated successfully"
+// @Router /api/v1/volunteer/{id}/skills [put]
+func (h *SkillHandler) UpdateVolunteerSkills(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	var input dto.VolunteerSkillsUpdateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.usecase.UpdateVolunteerSkills(c.Request.Context(), id, input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Skills updated successfully"})
+}
+
+// VerifyVolunteerSkill handles the HTTP POST request to verify a declared skill.
+// VerifyVolunteerSkill godoc
+// @Summary Verify volunteer skill
+// @Description Mark a skill declared by a volunteer as verified
+// @Produce json
+// @Tags skill
+// @Param id path int true "Volunteer ID"
+// @Param skillId path int true "Skill ID"
+// @Success 200 {string} message "Skill verified successfully"
+// @Security bearerToken
+// @Router /api/v1/volunteer/{id}/skills/{skillId}/verify [post]
+func (h *SkillHandler) VerifyVolunteerSkill(c *gin.Context) {
+	roleId, exists := c.Get("roleId")
+	if !exists || roleId.(int) != 1 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden: only admins can perform this action"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+	skillID, err := strconv.Atoi(c.Param("skillId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	if err := h.usecase.VerifyVolunteerSkill(c.Request.Context(), id, uint(skillID), userId.(int)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Skill verified successfully"})
+}
+
+// MatchVolunteers handles the HTTP POST request to rank volunteers for an activity.
+// MatchVolunteers godoc
+// @Summary Match volunteers by skills
+// @Description Rank active volunteers by how well their skills meet the activity requirements
+// @Accept json
+// @Produce json
+// @Tags skill
+// @Param request body dto.SkillMatchRequestDTO true "Activity requirements"
+// @Success 200 {array} dto.SkillMatchResponseDTO
+// @Router /api/v1/skills/match [post]
+func (h *SkillHandler) MatchVolunteers(c *gin.Context) {
+	var input dto.SkillMatchRequestDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	matches, err := h.usecase.MatchVolunteers(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, matches)
+}
@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/skill/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/skill/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/skill/storage"
+)
+
+// SkillUsecaseInterface defines the methods that any use case implementation must provide.
+type SkillUsecaseInterface interface {
+	CreateSkill(ctx context.Context, input dto.SkillCreateDTO) (*domain.Skill, error)
+	GetAllSkills(ctx context.Context, category string) ([]domain.Skill, error)
+	GetSkillByID(ctx context.Context, id uint) (*domain.Skill, error)
+	UpdateSkill(ctx context.Context, id uint, input dto.SkillUpdateDTO) (*domain.Skill, error)
+	DeleteSkill(ctx context.Context, id uint) error
+	GetVolunteerSkills(ctx context.Context, volunteerID int) ([]dto.VolunteerSkillResponseDTO, error)
+	UpdateVolunteerSkills(ctx context.Context, volunteerID int, input dto.VolunteerSkillsUpdateDTO) error
+	VerifyVolunteerSkill(ctx context.Context, volunteerID int, skillID uint, verifierID int) error
+	MatchVolunteers(ctx context.Context, input dto.SkillMatchRequestDTO) ([]dto.SkillMatchResponseDTO, error)
+}
+
+// SkillUsecase handles the business logic for skills.
+type SkillUsecase struct {
+	SkillRepo storage.SkillRepositoryInterface
+}
+
+// NewSkillUsecase creates a new instance of SkillUsecase.
+func NewSkillUsecase(skillRepo storage.SkillRepositoryInterface) *SkillUsecase {
+	return &SkillUsecase{SkillRepo: skillRepo}
+}
+
+// CreateSkill creates a new taxonomy entry using the provided DTO.
+func (u *SkillUsecase) CreateSkill(ctx context.Context, input dto.SkillCreateDTO) (*domain.Skill, error) {
+	skill := &domain.Skill{
+		Name:     input.Name,
+		Category: input.Category,
+		Status:   input.Status,
+	}
+	err := u.SkillRepo.Create(ctx, skill)
+	return skill, err
+}
+
+func (u *SkillUsecase) GetAllSkills(ctx context.Context, category string) ([]domain.Skill, error) {
+	return u.SkillRepo.GetAll(ctx, category)
+}
+
+// GetSkillByID retrieves a skill by its ID.
+func (u *SkillUsecase) GetSkillByID(ctx context.Context, id uint) (*domain.Skill, error) {
+	return u.SkillRepo.GetByID(ctx, id)
+}
+
+// UpdateSkill updates a skill using the provided DTO.
+func (u *SkillUsecase) UpdateSkill(ctx context.Context, id uint, input dto.SkillUpdateDTO) (*domain.Skill, error) {
+	skill, err := u.SkillRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	skill.Name = input.Name
+	skill.Category = input.Category
+	skill.Status = input.Status
+	err = u.SkillRepo.Update(ctx, skill)
+	return skill, err
+}
+
+// DeleteSkill deletes a skill by its ID.
+func (u *SkillUsecase) DeleteSkill(ctx context.Context, id uint) error {
+	return u.SkillRepo.Delete(ctx, id)
+}
+
+// GetVolunteerSkills lists the declared skills of a volunteer.
+func (u *SkillUsecase) GetVolunteerSkills(ctx context.Context, volunteerID int) ([]dto.VolunteerSkillResponseDTO, error) {
+	skills, err := u.SkillRepo.ListVolunteerSkills(ctx, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	response := make([]dto.VolunteerSkillResponseDTO, 0, len(skills))
+	for _, skill := range skills {
+		item := dto.VolunteerSkillResponseDTO{
+			SkillID:  skill.SkillID,
+			Name:     skill.Skill.Name,
+			Category: skill.Skill.Category,
+			Level:    skill.Level,
+			Verified: skill.Verified(),
+			Expired:  skill.Expired(now),
+		}
+		if skill.ExpiresAt != nil {
+			item.ExpiresAt = skill.ExpiresAt.Format("2006-01-02")
+		}
+		response = append(response, item)
+	}
+	return response, nil
+}
+
+// UpdateVolunteerSkills replaces the self-declared skills of a volunteer.
+// Certifications must come with an expiry date.
+func (u *SkillUsecase) UpdateVolunteerSkills(ctx context.Context, volunteerID int, input dto.VolunteerSkillsUpdateDTO) error {
+	seen := make(map[uint]bool, len(input.Skills))
+	skills := make([]*domain.VolunteerSkill, 0, len(input.Skills))
+	for _, item := range input.Skills {
+		if seen[item.SkillID] {
+			return errors.New("a skill can only be declared once")
+		}
+		seen[item.SkillID] = true
+		skill, err := u.SkillRepo.GetByID(ctx, item.SkillID)
+		if err != nil {
+			return errors.New("skill not found")
+		}
+		var expiresAt *time.Time
+		if item.ExpiresAt != "" {
+			parsed, err := time.Parse("2006-01-02", item.ExpiresAt)
+			if err != nil {
+				return errors.New("invalid expiry date")
+			}
+			expiresAt = &parsed
+		}
+		if skill.Category == domain.CategoryCertification && expiresAt == nil {
+			return errors.New("certification " + skill.Name + " requires an expiry date")
+		}
+		skills = append(skills, &domain.VolunteerSkill{
+			VolunteerID: volunteerID,
+			SkillID:     item.SkillID,
+			Level:       item.Level,
+			ExpiresAt:   expiresAt,
+		})
+	}
+	return u.SkillRepo.ReplaceVolunteerSkills(ctx, volunteerID, skills)
+}
+
+func (u *SkillUsecase) VerifyVolunteerSkill(ctx context.Context, volunteerID int, skillID uint, verifierID int) error {
+	return u.SkillRepo.VerifyVolunteerSkill(ctx, volunteerID, skillID, verifierID)
+}
+
+// MatchVolunteers ranks volunteers against the requirements of an activity.
+func (u *SkillUsecase) MatchVolunteers(ctx context.Context, input dto.SkillMatchRequestDTO) ([]dto.SkillMatchResponseDTO, error) {
+	skillIDs := make([]uint, 0, len(input.Requirements))
+	for _, requirement := range input.Requirements {
+		skillIDs = append(skillIDs, requirement.SkillID)
+	}
+	declared, err := u.SkillRepo.FindVolunteerSkillsForMatch(ctx, skillIDs, input.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	ranked := rankVolunteers(input.Requirements, declared, time.Now())
+	if input.Limit > 0 && len(ranked) > input.Limit {
+		ranked = ranked[:input.Limit]
+	}
+	return ranked, nil
+}
+
+type volunteerMatch struct {
+	dto.SkillMatchResponseDTO
+	verified int
+	surplus  int
+}
+
+// rankVolunteers scores each volunteer by the weighted share of requirements met, a required
+// skill weighs twice an optional one and volunteers missing a required skill are dropped.
+// Ties go to more verified skills, then to levels further above the minimum.
+func rankVolunteers(requirements []dto.SkillRequirementDTO, declared []*domain.VolunteerSkill, now time.Time) []dto.SkillMatchResponseDTO {
+	byVolunteer := make(map[int]map[uint]*domain.VolunteerSkill)
+	for _, skill := range declared {
+		if byVolunteer[skill.VolunteerID] == nil {
+			byVolunteer[skill.VolunteerID] = make(map[uint]*domain.VolunteerSkill)
+		}
+		byVolunteer[skill.VolunteerID][skill.SkillID] = skill
+	}
+
+	totalWeight := 0
+	for _, requirement := range requirements {
+		totalWeight += requirementWeight(requirement)
+	}
+
+	matches := make([]volunteerMatch, 0, len(byVolunteer))
+	for volunteerID, skills := range byVolunteer {
+		match := volunteerMatch{SkillMatchResponseDTO: dto.SkillMatchResponseDTO{
+			VolunteerID:     volunteerID,
+			MatchedSkillIDs: []uint{},
+			MissingSkillIDs: []uint{},
+		}}
+		weight := 0
+		eligible := true
+		for _, requirement := range requirements {
+			skill, ok := skills[requirement.SkillID]
+			minLevel := requirement.MinLevel
+			if minLevel < 1 {
+				minLevel = 1
+			}
+			if !ok || skill.Level < minLevel || skill.Expired(now) || (requirement.VerifiedOnly && !skill.Verified()) {
+				match.MissingSkillIDs = append(match.MissingSkillIDs, requirement.SkillID)
+				if requirement.Required {
+					eligible = false
+				}
+				continue
+			}
+			match.MatchedSkillIDs = append(match.MatchedSkillIDs, requirement.SkillID)
+			weight += requirementWeight(requirement)
+			match.surplus += skill.Level - minLevel
+			if skill.Verified() {
+				match.verified++
+			}
+		}
+		if !eligible || weight == 0 {
+			continue
+		}
+		match.Score = float64(weight) / float64(totalWeight)
+		matches = append(matches, match)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.verified != b.verified {
+			return a.verified > b.verified
+		}
+		if a.surplus != b.surplus {
+			return a.surplus > b.surplus
+		}
+		return a.VolunteerID < b.VolunteerID
+	})
+
+	response := make([]dto.SkillMatchResponseDTO, 0, len(matches))
+	for _, match := range matches {
+		response = append(response, match.SkillMatchResponseDTO)
+	}
+	return response
+}
+
+func requirementWeight(requirement dto.SkillRequirementDTO) int {
+	if requirement.Required {
+		return 2
+	}
+	return 1
+}
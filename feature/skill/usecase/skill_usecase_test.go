@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/skill/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/skill/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankVolunteers(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	verifiedAt := now.AddDate(0, -1, 0)
+	expired := now.AddDate(0, 0, -1)
+	valid := now.AddDate(1, 0, 0)
+
+	requirements := []dto.SkillRequirementDTO{
+		{SkillID: 1, MinLevel: 3, Required: true},
+		{SkillID: 2},
+		{SkillID: 3, VerifiedOnly: true},
+	}
+	declared := []*domain.VolunteerSkill{
+		// volunteer 1 meets everything
+		{VolunteerID: 1, SkillID: 1, Level: 3},
+		{VolunteerID: 1, SkillID: 2, Level: 1},
+		{VolunteerID: 1, SkillID: 3, Level: 2, VerifiedAt: &verifiedAt, ExpiresAt: &valid},
+		// volunteer 2 has the required skill at a higher level but an unverified certificate
+		{VolunteerID: 2, SkillID: 1, Level: 5},
+		{VolunteerID: 2, SkillID: 3, Level: 2},
+		// volunteer 3 is below the required level
+		{VolunteerID: 3, SkillID: 1, Level: 2},
+		{VolunteerID: 3, SkillID: 2, Level: 4},
+		// volunteer 4 ties with volunteer 2 on score but has an expired certificate and a lower level
+		{VolunteerID: 4, SkillID: 1, Level: 3},
+		{VolunteerID: 4, SkillID: 3, Level: 2, VerifiedAt: &verifiedAt, ExpiresAt: &expired},
+	}
+
+	ranked := rankVolunteers(requirements, declared, now)
+
+	assert.Len(t, ranked, 3)
+	assert.Equal(t, 1, ranked[0].VolunteerID)
+	assert.Equal(t, 1.0, ranked[0].Score)
+	assert.Equal(t, 2, ranked[1].VolunteerID)
+	assert.Equal(t, 0.5, ranked[1].Score)
+	assert.Equal(t, []uint{2, 3}, ranked[1].MissingSkillIDs)
+	assert.Equal(t, 4, ranked[2].VolunteerID)
+}
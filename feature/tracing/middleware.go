@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+const TraceIDHeader = "X-Trace-ID"
+
+// probes are scraped every few seconds, tracing them would bury the real traffic
+var untracedPaths = map[string]bool{"/livez": true, "/readyz": true, "/metrics": true, "/health": true}
+
+// Middleware starts a server span named after the route for every request, continuing the
+// trace of the caller when it sends a traceparent header.
+func Middleware() gin.HandlerFunc {
+	return otelgin.Middleware(serviceName(), otelgin.WithFilter(func(r *http.Request) bool {
+		return !untracedPaths[r.URL.Path]
+	}))
+}
+
+// ResponseTraceID returns the trace id in the X-Trace-ID header and adds it as trace_id to JSON
+// error bodies, so a client reporting a failure can hand over the trace. It must run after Middleware.
+func ResponseTraceID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		traceID := TraceID(c.Request.Context())
+		if traceID == "" {
+			c.Next()
+			return
+		}
+		c.Header(TraceIDHeader, traceID)
+		writer := &errorBodyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+		if writer.body != nil {
+			writer.ResponseWriter.Write(withTraceID(writer.body.Bytes(), traceID))
+		}
+	}
+}
+
+// errorBodyWriter holds back JSON bodies written with an error status
+type errorBodyWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *errorBodyWriter) Write(data []byte) (int, error) {
+	if w.Status() < http.StatusBadRequest || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		return w.ResponseWriter.Write(data)
+	}
+	if w.body == nil {
+		w.body = &bytes.Buffer{}
+	}
+	return w.body.Write(data)
+}
+
+func (w *errorBodyWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// withTraceID adds trace_id to a JSON object, anything else is returned as is
+func withTraceID(body []byte, traceID string) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return body
+	}
+	if _, ok := fields["trace_id"]; ok {
+		return body
+	}
+	fields["trace_id"], _ = json.Marshal(traceID)
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupRouter(t *testing.T) (*gin.Engine, *tracetest.SpanRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	router := gin.New()
+	router.Use(Middleware(), ResponseTraceID())
+	router.GET("/users/:id", func(c *gin.Context) {
+		if c.Param("id") == "0" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+	router.GET("/livez", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	return router, recorder
+}
+
+func TestResponseTraceID(t *testing.T) {
+	router, spans := setupRouter(t)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/users/0", nil))
+
+	assert.Len(t, spans.Ended(), 1)
+	traceID := spans.Ended()[0].SpanContext().TraceID().String()
+	assert.Equal(t, "/users/:id", spans.Ended()[0].Name())
+	assert.Equal(t, traceID, response.Header().Get(TraceIDHeader))
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.JSONEq(t, `{"error":"user not found","trace_id":"`+traceID+`"}`, response.Body.String())
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/users/7", nil))
+
+	assert.NotEmpty(t, response.Header().Get(TraceIDHeader))
+	assert.JSONEq(t, `{"id":"7"}`, response.Body.String())
+}
+
+func TestResponseTraceID_ContinuesCallerTrace(t *testing.T) {
+	router, spans := setupRouter(t)
+
+	request := httptest.NewRequest(http.MethodGet, "/users/7", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", response.Header().Get(TraceIDHeader))
+	assert.Len(t, spans.Ended(), 1)
+}
+
+func TestMiddleware_SkipsProbes(t *testing.T) {
+	router, spans := setupRouter(t)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Empty(t, response.Header().Get(TraceIDHeader))
+	assert.Empty(t, spans.Ended())
+}
@@ -0,0 +1,78 @@
+// Package uow lets a usecase run several repositories in one transaction.
+//
+// Repositories take their connection from Conn, which returns the transaction carried by ctx
+// when the call happens inside Do and the plain connection bound to ctx otherwise, so the
+// same repository method works in and out of a unit of work.
+package uow
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// txState is the transaction carried by a context and what to run once it committed
+type txState struct {
+	tx          *gorm.DB
+	afterCommit *[]func()
+}
+
+// Runner runs fn in one transaction, the repositories called with the ctx given to fn join it.
+type Runner interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// UnitOfWork is the Runner of a database.
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+// New creates a UnitOfWork on db.
+func New(db *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return Do(ctx, u.db, fn)
+}
+
+// Conn returns the transaction carried by ctx, or db bound to ctx.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx
+	}
+	return db.WithContext(ctx)
+}
+
+// Do runs fn in a transaction on db. Called inside another Do it runs in a savepoint of the
+// outer transaction and the AfterCommit callbacks wait for the outer commit.
+func Do(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	outer, nested := ctx.Value(txKey{}).(*txState)
+	var afterCommit []func()
+	err := Conn(ctx, db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, &txState{tx: tx, afterCommit: &afterCommit}))
+	})
+	if err != nil {
+		return err
+	}
+	if nested {
+		*outer.afterCommit = append(*outer.afterCommit, afterCommit...)
+		return nil
+	}
+	for _, callback := range afterCommit {
+		callback()
+	}
+	return nil
+}
+
+// AfterCommit runs callback once the transaction carried by ctx committed, right away when there is none.
+// Nothing runs when the transaction rolls back.
+func AfterCommit(ctx context.Context, callback func()) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		*state.afterCommit = append(*state.afterCommit, callback)
+		return
+	}
+	callback()
+}
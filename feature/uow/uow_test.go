@@ -0,0 +1,98 @@
+package uow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to setup mock db: %v", err)
+	}
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return gormDB, mock
+}
+
+func TestDoRunsRepositoriesInOneTransaction(t *testing.T) {
+	db, mock := setupMockDB(t)
+	var ran []string
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE shifts").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO shift_assignments").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	err := New(db).Do(context.Background(), func(ctx context.Context) error {
+		AfterCommit(ctx, func() { ran = append(ran, "after commit") })
+		if err := Conn(ctx, db).Exec("UPDATE shifts SET capacity = 2").Error; err != nil {
+			return err
+		}
+		ran = append(ran, "work")
+		return Conn(ctx, db).Exec("INSERT INTO shift_assignments (shift_id) VALUES (1)").Error
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"work", "after commit"}, ran)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDoRollsBackAndDropsCallbacks(t *testing.T) {
+	db, mock := setupMockDB(t)
+	failure := errors.New("shift is already at capacity")
+	called := false
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	err := Do(context.Background(), db, func(ctx context.Context) error {
+		AfterCommit(ctx, func() { called = true })
+		return failure
+	})
+
+	assert.ErrorIs(t, err, failure)
+	assert.False(t, called)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNestedDoUsesSavepoint(t *testing.T) {
+	db, mock := setupMockDB(t)
+	var ran []string
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	err := Do(context.Background(), db, func(ctx context.Context) error {
+		failed := Do(ctx, db, func(ctx context.Context) error {
+			AfterCommit(ctx, func() { ran = append(ran, "rolled back") })
+			return errors.New("nested failure")
+		})
+		assert.Error(t, failed)
+		return Do(ctx, db, func(ctx context.Context) error {
+			AfterCommit(ctx, func() { ran = append(ran, "nested") })
+			// the outer transaction has not committed yet
+			assert.Empty(t, ran)
+			return nil
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"nested"}, ran)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAfterCommitWithoutTransactionRunsRightAway(t *testing.T) {
+	called := false
+	AfterCommit(context.Background(), func() { called = true })
+	assert.True(t, called)
+}
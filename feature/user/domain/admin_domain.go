@@ -28,9 +28,26 @@ type Request struct {
 	Type        string `gorm:"not null"`
 	Status      int    `gorm:"not null"`
 	RejectNotes string
-	VerifierID  *int      `gorm:"index"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	VerifierID  *int `gorm:"index"`
+	// AssigneeID holds the request until ClaimExpiresAt, see ClaimedBy
+	AssigneeID     *int `gorm:"index"`
+	ClaimExpiresAt *time.Time
+	ViewedAt       *time.Time
+	ViewedBy       *int
+	DecidedAt      *time.Time
+	// SLARemindedAt and SLAEscalatedAt keep the SLA job from notifying twice, see RequestSLA
+	SLARemindedAt  *time.Time
+	SLAEscalatedAt *time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+// ClaimedBy returns the reviewer holding a live claim on the request at now, nil when it is free
+func (r *Request) ClaimedBy(now time.Time) *int {
+	if r.AssigneeID == nil || r.ClaimExpiresAt == nil || !r.ClaimExpiresAt.After(now) {
+		return nil
+	}
+	return r.AssigneeID
 }
 
 type VolunteerDetail struct {
@@ -41,3 +58,14 @@ type VolunteerDetail struct {
 	CreatedAt    time.Time `gorm:"autoCreateTime"`
 	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
 }
+
+// UserInvite lets an imported user choose their password with a single-use token,
+// only the hash of the token is stored
+type UserInvite struct {
+	ID        int    `gorm:"primaryKey"`
+	UserID    int    `gorm:"index"`
+	TokenHash string `gorm:"unique;not null"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Comment visibilities
+const (
+	CommentInternal = 0
+	CommentExternal = 1
+)
+
+// RequestComment is a message on the thread of a request, ParentID makes it a reply.
+// Internal comments are only shown to reviewers, external ones to the requester as well
+type RequestComment struct {
+	ID         int  `gorm:"primaryKey"`
+	RequestID  int  `gorm:"index"`
+	ParentID   *int `gorm:"index"`
+	AuthorID   int  `gorm:"index"`
+	Visibility int  `gorm:"not null"`
+	Body       string
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+type CommentAttachment struct {
+	ID          int `gorm:"primaryKey"`
+	CommentID   int `gorm:"index"`
+	FileName    string
+	ContentType string
+	Size        int64
+	StoragePath string
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+// CommentRead is a read receipt, one per comment and reader
+type CommentRead struct {
+	ID        int `gorm:"primaryKey"`
+	CommentID int `gorm:"index"`
+	UserID    int `gorm:"index"`
+	ReadAt    time.Time
+}
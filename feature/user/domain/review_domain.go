@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// RejectionReason is a catalog entry reviewers pick from when rejecting a request
+type RejectionReason struct {
+	ID               int    `gorm:"primaryKey"`
+	Code             string `gorm:"unique;not null"`
+	Label            string `gorm:"not null"`
+	ApplicantMessage string `gorm:"not null"`
+	// RequestType limits the reason to one request type, nil means every type
+	RequestType *string
+	Status      int       `gorm:"default:1"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// AppliesTo reports whether the reason can be used for a request of requestType
+func (r *RejectionReason) AppliesTo(requestType string) bool {
+	return r.RequestType == nil || *r.RequestType == requestType
+}
+
+// RequestRejectionReason is one reason given for a rejected request,
+// Field points at the profile field or document the reason is about
+type RequestRejectionReason struct {
+	ID                int `gorm:"primaryKey"`
+	RequestID         int `gorm:"index"`
+	RejectionReasonID int `gorm:"index"`
+	Field             *string
+	Comment           *string
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+}
+
+// ChecklistItem is a step reviewers tick off before deciding a request of RequestType
+type ChecklistItem struct {
+	ID          int    `gorm:"primaryKey"`
+	RequestType string `gorm:"not null"`
+	Label       string `gorm:"not null"`
+	Position    int
+	Status      int       `gorm:"default:1"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+type RequestChecklistItem struct {
+	ID              int `gorm:"primaryKey"`
+	RequestID       int `gorm:"index"`
+	ChecklistItemID int `gorm:"index"`
+	Checked         bool
+	CheckedBy       *int
+	CheckedAt       *time.Time
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+}
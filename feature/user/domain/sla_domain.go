@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// SLA states of a pending request
+const (
+	SLAOnTrack  = "on_track"
+	SLADueSoon  = "due_soon"
+	SLABreached = "breached"
+)
+
+// RequestSLA is how long a pending request of RequestType may wait for a decision. The reviewer
+// holding the request is reminded ReminderHours before the deadline, the managers of the
+// requester's department are told once it is breached.
+type RequestSLA struct {
+	RequestType   string `gorm:"primaryKey"`
+	TargetHours   int    `gorm:"not null"`
+	ReminderHours int
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+// DueAt returns the deadline of a request submitted at submittedAt
+func (s *RequestSLA) DueAt(submittedAt time.Time) time.Time {
+	return submittedAt.Add(time.Duration(s.TargetHours) * time.Hour)
+}
+
+// RemindAt returns when the reviewer of a request submitted at submittedAt is reminded,
+// it is the deadline itself when the SLA has no reminder
+func (s *RequestSLA) RemindAt(submittedAt time.Time) time.Time {
+	return s.DueAt(submittedAt).Add(-time.Duration(s.ReminderHours) * time.Hour)
+}
+
+// State tells where a request submitted at submittedAt stands against the SLA at now
+func (s *RequestSLA) State(submittedAt time.Time, now time.Time) string {
+	switch {
+	case !now.Before(s.DueAt(submittedAt)):
+		return SLABreached
+	case s.ReminderHours > 0 && !now.Before(s.RemindAt(submittedAt)):
+		return SLADueSoon
+	default:
+		return SLAOnTrack
+	}
+}
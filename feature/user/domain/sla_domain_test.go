@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestSLA_State(t *testing.T) {
+	submitted := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	sla := RequestSLA{RequestType: "registration", TargetHours: 48, ReminderHours: 8}
+
+	assert.Equal(t, submitted.Add(48*time.Hour), sla.DueAt(submitted))
+	assert.Equal(t, submitted.Add(40*time.Hour), sla.RemindAt(submitted))
+	assert.Equal(t, SLAOnTrack, sla.State(submitted, submitted.Add(39*time.Hour)))
+	assert.Equal(t, SLADueSoon, sla.State(submitted, submitted.Add(40*time.Hour)))
+	assert.Equal(t, SLABreached, sla.State(submitted, submitted.Add(48*time.Hour)))
+
+	// without reminder a request is on track until it breaches
+	sla.ReminderHours = 0
+	assert.Equal(t, SLAOnTrack, sla.State(submitted, submitted.Add(47*time.Hour)))
+	assert.Equal(t, SLABreached, sla.State(submitted, submitted.Add(49*time.Hour)))
+}
@@ -15,14 +15,19 @@ type PendingRequest struct {
 }
 
 type RequestResponse struct {
-	ID          int       `json:"id"`
-	UserID      int       `json:"user_id"`
-	Type        string    `json:"type"`
-	Status      int       `json:"status"`
-	RejectNotes string    `json:"reject_notes"`
-	VerifierID  *int      `json:"verifier_id"`
-	CreateAt    time.Time `json:"create_at"`
-	UpdateAt    time.Time `json:"update_at"`
+	ID          int    `json:"id"`
+	UserID      int    `json:"user_id"`
+	Type        string `json:"type"`
+	Status      int    `json:"status"`
+	RejectNotes string `json:"reject_notes"`
+	VerifierID  *int   `json:"verifier_id"`
+	// ClaimedBy is the reviewer currently holding the request, nil when nobody does
+	ClaimedBy      *int       `json:"claimed_by"`
+	ClaimExpiresAt *time.Time `json:"claim_expires_at"`
+	ViewedAt       *time.Time `json:"viewed_at"`
+	ViewedBy       *int       `json:"viewed_by"`
+	CreateAt       time.Time  `json:"create_at"`
+	UpdateAt       time.Time  `json:"update_at"`
 }
 
 type ListRequest struct {
@@ -30,5 +35,205 @@ type ListRequest struct {
 }
 
 type AddRejectNoteRequest struct {
-	Notes string `json:"notes"`
+	Notes string `json:"notes" binding:"required,max=255"`
+}
+
+// BulkDecisionRequest applies one action to many requests, reasons and notes are shared by every rejected request
+type BulkDecisionRequest struct {
+	IDs     []int                  `json:"ids" binding:"required,min=1,max=200,dive,gt=0"`
+	Action  string                 `json:"action" binding:"required,oneof=approve reject"`
+	Reasons []RejectionReasonInput `json:"reasons" binding:"omitempty,dive"`
+	Notes   string                 `json:"notes" binding:"max=255"`
+}
+
+type BulkDecisionResult struct {
+	ID      int    `json:"id"`
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type BulkDecisionResponse struct {
+	Action    string               `json:"action"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Results   []BulkDecisionResult `json:"results"`
+}
+
+type ClaimResponse struct {
+	RequestID int       `json:"request_id"`
+	ClaimedBy int       `json:"claimed_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AutoAssignRequest distributes unclaimed pending requests round-robin,
+// reviewers default to every active admin
+type AutoAssignRequest struct {
+	ReviewerIDs []int `json:"reviewer_ids" binding:"omitempty,dive,gt=0"`
+	Limit       int   `json:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+type Assignment struct {
+	RequestID  int `json:"request_id"`
+	ReviewerID int `json:"reviewer_id"`
+}
+
+type AutoAssignResponse struct {
+	ExpiresAt   time.Time    `json:"expires_at"`
+	Assignments []Assignment `json:"assignments"`
+}
+
+type ListVolunteer struct {
+	Volunteers []*domain.VolunteerDetail `json:"volunteers"`
+}
+
+// AdminScope limits what an admin can see and decide,
+// global admins see everything, department managers only their department subtree
+type AdminScope struct {
+	All           bool
+	DepartmentIDs []int
+}
+
+// Allows reports whether a user in departmentID falls inside the scope
+func (s AdminScope) Allows(departmentID *int) bool {
+	if s.All {
+		return true
+	}
+	if departmentID == nil {
+		return false
+	}
+	for _, id := range s.DepartmentIDs {
+		if id == *departmentID {
+			return true
+		}
+	}
+	return false
+}
+
+// StatsFilter is the [From, To) window for time based statistics
+type StatsFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+type RequestTypeCount struct {
+	Type     string `json:"type"`
+	Pending  int    `json:"pending"`
+	Approved int    `json:"approved"`
+	Rejected int    `json:"rejected"`
+}
+
+type PeriodCount struct {
+	Period string `json:"period"`
+	Count  int    `json:"count"`
+}
+
+type GroupCount struct {
+	ID    *int   `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type ReviewerThroughput struct {
+	VerifierID int    `json:"verifier_id"`
+	Name       string `json:"name"`
+	Approved   int    `json:"approved"`
+	Rejected   int    `json:"rejected"`
+	Total      int    `json:"total"`
+}
+
+type AdminStats struct {
+	From                   string               `json:"from"`
+	To                     string               `json:"to"`
+	Requests               []RequestTypeCount   `json:"requests"`
+	MedianDecisionSeconds  *float64             `json:"median_decision_seconds"`
+	RegistrationsPerDay    []PeriodCount        `json:"registrations_per_day"`
+	RegistrationsPerWeek   []PeriodCount        `json:"registrations_per_week"`
+	VolunteersByDepartment []GroupCount         `json:"volunteers_by_department"`
+	VolunteersByCountry    []GroupCount         `json:"volunteers_by_country"`
+	ReviewerThroughput     []ReviewerThroughput `json:"reviewer_throughput"`
+}
+
+// ExportFilter narrows the export endpoints, zero values mean no filter
+type ExportFilter struct {
+	Status             *int
+	Type               string
+	DepartmentID       int
+	VerificationStatus *int
+}
+
+type RequestExportRow struct {
+	ID          int
+	UserID      int
+	Email       string
+	Name        string
+	Surname     string
+	Department  *string
+	Type        string
+	Status      int
+	VerifierID  *int
+	RejectNotes *string
+	CreatedAt   time.Time
+	DecidedAt   *time.Time
+}
+
+type VolunteerExportRow struct {
+	ID         int
+	UserID     int
+	Email      string
+	Name       string
+	Surname    string
+	Mobile     *string
+	Department *string
+	Country    *string
+	Status     int
+	CreatedAt  time.Time
+}
+
+type ApplicantExportRow struct {
+	ID                 int
+	Email              string
+	Name               string
+	Surname            string
+	Gender             *string
+	Dob                *time.Time
+	Mobile             *string
+	Department         *string
+	Country            *string
+	VerificationStatus int
+	CreatedAt          time.Time
+}
+
+// NamedID is a reference data row resolved by name during imports
+type NamedID struct {
+	ID   int
+	Name string
+}
+
+// VolunteerImport is one validated import row ready to be created. Rows without password get an
+// Invite, InviteToken is the token handed out in the report whose hash the invite stores.
+type VolunteerImport struct {
+	User         domain.User
+	DepartmentID int
+	Invite       *domain.UserInvite
+	InviteToken  string
+}
+
+// ImportRowResult is the outcome of one row, the invite of a created volunteer without password
+// is redeemed on POST /api/v1/auth/accept-invite
+type ImportRowResult struct {
+	Row             int        `json:"row"`
+	Email           string     `json:"email"`
+	Status          string     `json:"status"`
+	Errors          []string   `json:"errors,omitempty"`
+	InviteToken     string     `json:"invite_token,omitempty"`
+	InviteExpiresAt *time.Time `json:"invite_expires_at,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Valid   int               `json:"valid"`
+	Invalid int               `json:"invalid"`
+	Created int               `json:"created"`
+	Rows    []ImportRowResult `json:"rows"`
 }
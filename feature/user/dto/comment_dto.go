@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"io"
+	"time"
+)
+
+// CommentCreateDTO is sent as JSON or as a multipart form when files are attached,
+// visibility is ignored for requesters whose comments are always external
+type CommentCreateDTO struct {
+	Body       string `json:"body" form:"body" binding:"required,max=5000"`
+	ParentID   *int   `json:"parent_id" form:"parent_id" binding:"omitempty,gt=0"`
+	Visibility string `json:"visibility" form:"visibility" binding:"omitempty,oneof=internal external"`
+}
+
+// AttachmentUpload is a file attached to a new comment
+type AttachmentUpload struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Content     io.Reader
+}
+
+type AttachmentResponse struct {
+	ID          int    `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+type CommentReadResponse struct {
+	UserID int       `json:"user_id"`
+	ReadAt time.Time `json:"read_at"`
+}
+
+type CommentResponse struct {
+	ID            int                   `json:"id"`
+	ParentID      *int                  `json:"parent_id"`
+	AuthorID      int                   `json:"author_id"`
+	FromRequester bool                  `json:"from_requester"`
+	Visibility    string                `json:"visibility"`
+	Body          string                `json:"body"`
+	Attachments   []AttachmentResponse  `json:"attachments"`
+	ReadBy        []CommentReadResponse `json:"read_by"`
+	Read          bool                  `json:"read"`
+	CreatedAt     time.Time             `json:"created_at"`
+	Replies       []*CommentResponse    `json:"replies"`
+}
+
+// CommentThread lists the top level comments of a request, oldest first, with their replies nested
+type CommentThread struct {
+	RequestID int                `json:"request_id"`
+	Unread    int                `json:"unread"`
+	Comments  []*CommentResponse `json:"comments"`
+}
+
+// AttachmentDownload is an attachment ready to be streamed, the caller closes Content
+type AttachmentDownload struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Content     io.ReadCloser
+}
@@ -0,0 +1,109 @@
+package dto
+
+import "time"
+
+type RejectionReasonCreateDTO struct {
+	Code             string  `json:"code" binding:"required,max=50"`
+	Label            string  `json:"label" binding:"required,max=255"`
+	ApplicantMessage string  `json:"applicant_message" binding:"required,max=500"`
+	RequestType      *string `json:"request_type" binding:"omitempty,oneof=registration verification"`
+}
+
+type RejectionReasonUpdateDTO struct {
+	Label            string  `json:"label" binding:"required,max=255"`
+	ApplicantMessage string  `json:"applicant_message" binding:"required,max=500"`
+	RequestType      *string `json:"request_type" binding:"omitempty,oneof=registration verification"`
+	Status           int     `json:"status" binding:"oneof=0 1"`
+}
+
+type RejectionReasonResponseDTO struct {
+	ID               int     `json:"id"`
+	Code             string  `json:"code"`
+	Label            string  `json:"label"`
+	ApplicantMessage string  `json:"applicant_message"`
+	RequestType      *string `json:"request_type"`
+	Status           int     `json:"status"`
+}
+
+type ChecklistItemCreateDTO struct {
+	RequestType string `json:"request_type" binding:"required,oneof=registration verification"`
+	Label       string `json:"label" binding:"required,max=255"`
+	Position    int    `json:"position"`
+}
+
+type ChecklistItemUpdateDTO struct {
+	Label    string `json:"label" binding:"required,max=255"`
+	Position int    `json:"position"`
+	Status   int    `json:"status" binding:"oneof=0 1"`
+}
+
+type ChecklistItemResponseDTO struct {
+	ID          int    `json:"id"`
+	RequestType string `json:"request_type"`
+	Label       string `json:"label"`
+	Position    int    `json:"position"`
+	Status      int    `json:"status"`
+}
+
+// RejectionReasonInput picks a catalog reason, field points at what is wrong (e.g. "dob", "identity document")
+type RejectionReasonInput struct {
+	ReasonID int    `json:"reason_id" binding:"required,gt=0"`
+	Field    string `json:"field" binding:"max=100"`
+	Comment  string `json:"comment" binding:"max=500"`
+}
+
+type RejectRequestDTO struct {
+	Reasons []RejectionReasonInput `json:"reasons" binding:"required,min=1,dive"`
+	Notes   string                 `json:"notes" binding:"max=255"`
+}
+
+type ChecklistCheckDTO struct {
+	Checked *bool `json:"checked" binding:"required"`
+}
+
+type ChecklistItemState struct {
+	ItemID    int        `json:"item_id"`
+	Label     string     `json:"label"`
+	Position  int        `json:"position"`
+	Checked   bool       `json:"checked"`
+	CheckedBy *int       `json:"checked_by"`
+	CheckedAt *time.Time `json:"checked_at"`
+}
+
+// RejectionReasonDetail is a reason given for a rejected request, as stored with its catalog entry
+type RejectionReasonDetail struct {
+	RequestID        int     `json:"-"`
+	ReasonID         int     `json:"reason_id"`
+	Code             string  `json:"code"`
+	Label            string  `json:"label"`
+	ApplicantMessage string  `json:"applicant_message"`
+	Field            *string `json:"field"`
+	Comment          *string `json:"comment"`
+}
+
+// RequestReview is what a reviewer needs to decide a request: the checklist and, once rejected, the reasons
+type RequestReview struct {
+	RequestID         int                     `json:"request_id"`
+	Type              string                  `json:"type"`
+	Status            int                     `json:"status"`
+	ChecklistComplete bool                    `json:"checklist_complete"`
+	Checklist         []ChecklistItemState    `json:"checklist"`
+	Reasons           []RejectionReasonDetail `json:"reasons"`
+}
+
+type ApplicantRejectionReason struct {
+	Message string  `json:"message"`
+	Field   *string `json:"field"`
+	Comment *string `json:"comment"`
+}
+
+// ApplicantRequestStatus is a request as its owner sees it, without reviewer-only details
+type ApplicantRequestStatus struct {
+	ID          int                        `json:"id"`
+	Type        string                     `json:"type"`
+	Status      string                     `json:"status"`
+	SubmittedAt time.Time                  `json:"submitted_at"`
+	DecidedAt   *time.Time                 `json:"decided_at"`
+	Reasons     []ApplicantRejectionReason `json:"reasons"`
+	Notes       string                     `json:"notes,omitempty"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+)
+
+type RequestSLAUpdateDTO struct {
+	TargetHours   int `json:"target_hours" binding:"required,min=1,max=2160"`
+	ReminderHours int `json:"reminder_hours" binding:"min=0,ltfield=TargetHours"`
+}
+
+type RequestSLAResponseDTO struct {
+	RequestType   string `json:"request_type"`
+	TargetHours   int    `json:"target_hours"`
+	ReminderHours int    `json:"reminder_hours"`
+}
+
+// PendingRequestSLA is a pending request of the admin listing with where it stands against the SLA
+// of its type, the SLA fields are empty when its type has no SLA
+type PendingRequestSLA struct {
+	*domain.Request
+	SLADueAt  *time.Time `json:"sla_due_at"`
+	SLAStatus string     `json:"sla_status,omitempty"`
+	// SLABreachedFor is how long ago the deadline passed, in seconds
+	SLABreachedFor int64 `json:"sla_breached_for,omitempty"`
+}
+
+type ListPendingRequest struct {
+	Requests []PendingRequestSLA `json:"requests"`
+}
@@ -1,32 +1,50 @@
 package storage
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/event"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"gorm.io/gorm"
 	"strings"
+	"time"
 )
 
 type AdminRepositoryInterface interface {
-	GetListPendingRequest() ([]*domain.Request, string)
-	GetPendingRequestByID(id int) (*domain.Request, string)
-	GetListAllRequest() ([]*domain.Request, string)
-	GetRequestByID(id int) (*domain.Request, string)
-	ApproveRequest(id int, verifier_id int) string
-	RejectRequest(id int, verifier_id int) string
-	AddRejectNotes(id int, notes string) string
-	DeleteRequest(id int) string
+	GetListPendingRequest(ctx context.Context, scope dto.AdminScope) ([]*domain.Request, string)
+	ListRequestSLAs(ctx context.Context) ([]domain.RequestSLA, error)
+	GetPendingRequestByID(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, string)
+	GetListAllRequest(ctx context.Context, scope dto.AdminScope) ([]*domain.Request, string)
+	GetRequestByID(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, string)
+	GetListVolunteer(ctx context.Context, scope dto.AdminScope) ([]*domain.VolunteerDetail, string)
+	ApproveRequest(ctx context.Context, id int, verifier_id int) string
+	RejectRequest(ctx context.Context, id int, verifier_id int) string
+	AddRejectNotes(ctx context.Context, id int, notes string) string
+	RejectRequestWithReasons(ctx context.Context, id int, verifier_id int, reasons []domain.RequestRejectionReason, notes string) string
+	DeleteRequest(ctx context.Context, id int) ([]domain.CommentAttachment, string)
 }
 
+// Messages returned by ApproveRequest and RejectRequest when the decision is stored
+const (
+	ApproveRequestSuccess = "Approve request success"
+	RejectRequestSuccess  = "Reject request success"
+)
+
 type AdminRepository struct {
-	db *gorm.DB
+	db  *gorm.DB
+	bus *event.Bus
 }
 
-func NewAdminRepository(db *gorm.DB) *AdminRepository {
-	return &AdminRepository{db: db}
+func NewAdminRepository(db *gorm.DB, bus *event.Bus) *AdminRepository {
+	return &AdminRepository{db: db, bus: bus}
 }
-func (r *AdminRepository) GetListPendingRequest() ([]*domain.Request, string) {
+func (r *AdminRepository) GetListPendingRequest(ctx context.Context, scope dto.AdminScope) ([]*domain.Request, string) {
 	var listRequest []*domain.Request
-	result := r.db.Where("status = ?", 0).Find(&listRequest)
+	result := scopeRequests(uow.Conn(ctx, r.db), scope).Where("status = ?", 0).Find(&listRequest)
 	if result.Error != nil {
 		return nil, result.Error.Error()
 	}
@@ -36,18 +54,22 @@ func (r *AdminRepository) GetListPendingRequest() ([]*domain.Request, string) {
 	return listRequest, ""
 }
 
-func (r *AdminRepository) GetPendingRequestByID(id int) (*domain.Request, string) {
+func (r *AdminRepository) ListRequestSLAs(ctx context.Context) ([]domain.RequestSLA, error) {
+	return listRequestSLAs(uow.Conn(ctx, r.db))
+}
+
+func (r *AdminRepository) GetPendingRequestByID(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, string) {
 	var request domain.Request
-	result := r.db.Where("id = ? and status = 0", id).First(&request)
+	result := scopeRequests(uow.Conn(ctx, r.db), scope).Where("id = ? and status = 0", id).First(&request)
 	if result.Error != nil {
 		return nil, result.Error.Error()
 	}
 	return &request, ""
 }
 
-func (r *AdminRepository) GetListAllRequest() ([]*domain.Request, string) {
+func (r *AdminRepository) GetListAllRequest(ctx context.Context, scope dto.AdminScope) ([]*domain.Request, string) {
 	var listRequest []*domain.Request
-	result := r.db.Find(&listRequest)
+	result := scopeRequests(uow.Conn(ctx, r.db), scope).Find(&listRequest)
 	if result.Error != nil {
 		return nil, result.Error.Error()
 	}
@@ -57,111 +79,253 @@ func (r *AdminRepository) GetListAllRequest() ([]*domain.Request, string) {
 	return listRequest, ""
 }
 
-func (r *AdminRepository) GetRequestByID(id int) (*domain.Request, string) {
+func (r *AdminRepository) GetRequestByID(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, string) {
 	var request domain.Request
-	result := r.db.Where("id = ?", id).First(&request)
+	result := scopeRequests(uow.Conn(ctx, r.db), scope).Where("id = ?", id).First(&request)
 	if result.Error != nil {
 		return nil, result.Error.Error()
 	}
 	return &request, ""
 }
 
+func (r *AdminRepository) GetListVolunteer(ctx context.Context, scope dto.AdminScope) ([]*domain.VolunteerDetail, string) {
+	var listVolunteer []*domain.VolunteerDetail
+	db := uow.Conn(ctx, r.db)
+	if !scope.All {
+		db = db.Where("department_id IN ?", scope.DepartmentIDs)
+	}
+	result := db.Find(&listVolunteer)
+	if result.Error != nil {
+		return nil, result.Error.Error()
+	}
+	if len(listVolunteer) == 0 {
+		return nil, "No volunteer found"
+	}
+	return listVolunteer, ""
+}
+
 // ApproveRequest change status of request to 1 (approved)
 // change verifier_id to admin id
 // if requestType is registration, change user role to 1 (applicant)
-// else if requestType is verification, change user role to 2 (volunteer) and change verification status to 1 (active)
-// and insert this user to volunteer_details table
-func (r *AdminRepository) ApproveRequest(id int, verifier_id int) string {
-	// get request type
-	request := r.getRequestByRequestID(id)
-	if request == nil {
-		return "Request not found"
-	}
-	if request.Status != 0 {
-		return "Request already processed"
-	}
-	userID := request.UserID
-	if strings.TrimSpace(request.Type) == "registration" {
-		result := r.db.Model(&domain.Request{}).Where("id = ?", id).Update("status", 1).Update("verifier_id", verifier_id)
-		if result.Error != nil {
-			return result.Error.Error()
-		}
-		// change user role to 1 (applicant)
-		s, done := updateRoleId(result, r, userID, 1)
-		if done {
-			return s
-		}
-		return "Approve request success"
-	} else if strings.TrimSpace(request.Type) == "verification" {
-		result := r.db.Model(&domain.Request{}).Where("id = ?", id).Update("status", 1).Update("verifier_id", verifier_id)
-		if result.Error != nil {
-			return result.Error.Error()
-		}
-		// change user role to 2 (volunteer)
-		s, done := updateRoleId(result, r, userID, 2)
-		if done {
-			return s
-		}
-		// insert to volunteer_details
-		departmentID := r.getDeptIdFromUser(userID)
-		volunteerDetail := domain.VolunteerDetail{
-			UserID:       userID,
-			DepartmentID: *departmentID,
-			Status:       1,
+// else if requestType is verification, change user role to 2 (volunteer)
+// then publishes RequestApproved, the volunteer feature creates the volunteer of a verification request
+// all changes, subscribers included, are applied in one transaction
+func (r *AdminRepository) ApproveRequest(ctx context.Context, id int, verifier_id int) string {
+	err := r.bus.InTx(ctx, func(tx *event.Tx) error {
+		request, err := decideRequest(tx.DB(), id, 1, verifier_id)
+		if err != nil {
+			return err
 		}
-		result = r.db.Create(&volunteerDetail)
-		if result.Error != nil {
-			return result.Error.Error()
+		userID := request.UserID
+		switch strings.TrimSpace(request.Type) {
+		case "registration":
+			// change user role to 1 (applicant)
+			err = updateRoleId(tx.DB(), userID, 1)
+		case "verification":
+			// change user role to 2 (volunteer)
+			err = updateRoleId(tx.DB(), userID, 2)
+		default:
+			return errors.New("Invalid request type")
 		}
-		return "Approve request success"
+		if err != nil {
+			return err
+		}
+		return tx.Publish(event.RequestApproved{
+			RequestID:    request.ID,
+			UserID:       userID,
+			Type:         strings.TrimSpace(request.Type),
+			VerifierID:   verifier_id,
+			DepartmentID: getDeptIdFromUser(tx.DB(), userID),
+			SubmittedAt:  request.CreatedAt,
+			DecidedAt:    *request.DecidedAt,
+		})
+	})
+	if err != nil {
+		return err.Error()
 	}
-	return "Invalid request type"
+	return ApproveRequestSuccess
 }
-func (r *AdminRepository) RejectRequest(id int, verifier_id int) string {
-	request := r.getRequestByRequestID(id)
-	if request == nil {
-		return "Request not found"
-	}
-	if request.Status != 0 {
-		return "Request already processed"
-	}
-	result := r.db.Model(&request).Where("id = ?", id).Update("status", 2).Update("verifier_id", verifier_id)
-	if result.Error != nil {
-		return result.Error.Error()
+func (r *AdminRepository) RejectRequest(ctx context.Context, id int, verifier_id int) string {
+	return r.RejectRequestWithReasons(ctx, id, verifier_id, nil, "")
+}
+
+// RejectRequestWithReasons rejects the request and stores the reasons and notes in the same transaction,
+// reasons must be active catalog entries for the request type, empty notes leave reject_notes untouched
+func (r *AdminRepository) RejectRequestWithReasons(ctx context.Context, id int, verifier_id int, reasons []domain.RequestRejectionReason, notes string) string {
+	err := r.bus.InTx(ctx, func(etx *event.Tx) error {
+		tx := etx.DB()
+		request, err := decideRequest(tx, id, 2, verifier_id)
+		if err != nil {
+			return err
+		}
+		if err := checkRejectionReasons(tx, strings.TrimSpace(request.Type), reasons); err != nil {
+			return err
+		}
+		for i := range reasons {
+			reasons[i].RequestID = id
+		}
+		if len(reasons) > 0 {
+			if err := tx.Create(&reasons).Error; err != nil {
+				return err
+			}
+		}
+		if notes != "" {
+			if err := tx.Model(&domain.Request{}).Where("id = ?", id).Update("reject_notes", notes).Error; err != nil {
+				return err
+			}
+		}
+		return etx.Publish(event.RequestRejected{
+			RequestID:   request.ID,
+			UserID:      request.UserID,
+			Type:        strings.TrimSpace(request.Type),
+			VerifierID:  verifier_id,
+			SubmittedAt: request.CreatedAt,
+			DecidedAt:   *request.DecidedAt,
+		})
+	})
+	if err != nil {
+		return err.Error()
 	}
-	return "Reject request success"
+	return RejectRequestSuccess
 }
-func (r *AdminRepository) AddRejectNotes(id int, notes string) string {
-	result := r.db.Model(&domain.Request{}).Where("id = ?", id).Update("reject_notes", notes)
+
+// AddRejectNotes only applies to rejected requests, the notes are shown to the applicant
+func (r *AdminRepository) AddRejectNotes(ctx context.Context, id int, notes string) string {
+	result := uow.Conn(ctx, r.db).Model(&domain.Request{}).Where("id = ? AND status = 2", id).Update("reject_notes", notes)
 	if result.Error != nil {
 		return result.Error.Error()
 	}
+	if result.RowsAffected == 0 {
+		return "Request is not rejected"
+	}
 	return "Add reject notes success"
 }
-func (r *AdminRepository) DeleteRequest(id int) string {
-	result := r.db.Where("id = ?", id).Delete(&domain.Request{})
+
+// DeleteRequest removes the request with its rejection reasons, checklist ticks and comment threads
+// in one transaction. It returns the deleted attachments whose files the caller removes.
+func (r *AdminRepository) DeleteRequest(ctx context.Context, id int) ([]domain.CommentAttachment, string) {
+	var attachments []domain.CommentAttachment
+	err := uow.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("request_id = ?", id).Delete(&domain.RequestRejectionReason{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("request_id = ?", id).Delete(&domain.RequestChecklistItem{}).Error; err != nil {
+			return err
+		}
+		comments := tx.Session(&gorm.Session{NewDB: true}).Model(&domain.RequestComment{}).Select("id").Where("request_id = ?", id)
+		if err := tx.Where("comment_id IN (?)", comments).Find(&attachments).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id IN (?)", comments).Delete(&domain.CommentAttachment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id IN (?)", comments).Delete(&domain.CommentRead{}).Error; err != nil {
+			return err
+		}
+		// replies reference their parent, detach them so the thread deletes in any order
+		if err := tx.Model(&domain.RequestComment{}).Where("request_id = ? AND parent_id IS NOT NULL", id).Update("parent_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("request_id = ?", id).Delete(&domain.RequestComment{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.Request{}).Error
+	})
+	if err != nil {
+		return nil, err.Error()
+	}
+	return attachments, "Delete request success"
+}
+
+// scopeRequests keeps only requests of users belonging to the scope departments
+func scopeRequests(db *gorm.DB, scope dto.AdminScope) *gorm.DB {
+	if scope.All {
+		return db
+	}
+	return db.Where("user_id IN (?)", db.Session(&gorm.Session{NewDB: true}).
+		Model(&domain.User{}).Select("id").Where("department_id IN ?", scope.DepartmentIDs))
+}
+
+// decideRequest moves a pending request to status once its checklist is complete,
+// the status and claim guards keep two reviewers from deciding the same request concurrently
+func decideRequest(tx *gorm.DB, id int, status int, verifierID int) (*domain.Request, error) {
+	var request domain.Request
+	if err := tx.First(&request, id).Error; err != nil {
+		return nil, errors.New("Request not found")
+	}
+	if request.Status != 0 {
+		return nil, errors.New("Request already processed")
+	}
+	now := time.Now()
+	if holder := request.ClaimedBy(now); holder != nil && *holder != verifierID {
+		return nil, errors.New("Request claimed by another reviewer")
+	}
+	var unchecked int64
+	err := tx.Model(&domain.ChecklistItem{}).
+		Where("request_type = ? AND status = 1", strings.TrimSpace(request.Type)).
+		Where("id NOT IN (?)", tx.Model(&domain.RequestChecklistItem{}).Select("checklist_item_id").Where("request_id = ? AND checked = ?", id, true)).
+		Count(&unchecked).Error
+	if err != nil {
+		return nil, err
+	}
+	if unchecked > 0 {
+		return nil, errors.New("Checklist not completed")
+	}
+	result := tx.Model(&domain.Request{}).Where("id = ? AND status = 0", id).
+		Where("assignee_id IS NULL OR assignee_id = ? OR claim_expires_at IS NULL OR claim_expires_at <= ?", verifierID, now).
+		Updates(map[string]interface{}{"status": status, "verifier_id": verifierID, "decided_at": now})
 	if result.Error != nil {
-		return result.Error.Error()
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("Request already processed")
+	}
+	request.Status = status
+	request.VerifierID = &verifierID
+	request.DecidedAt = &now
+	return &request, nil
+}
+
+// checkRejectionReasons makes sure every reason is an active catalog entry usable for requestType
+func checkRejectionReasons(tx *gorm.DB, requestType string, reasons []domain.RequestRejectionReason) error {
+	if len(reasons) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(reasons))
+	for _, reason := range reasons {
+		ids = append(ids, reason.RejectionReasonID)
 	}
-	return "Delete request success"
+	var catalog []domain.RejectionReason
+	if err := tx.Where("id IN ? AND status = 1", ids).Find(&catalog).Error; err != nil {
+		return err
+	}
+	usable := make(map[int]bool, len(catalog))
+	for i := range catalog {
+		usable[catalog[i].ID] = catalog[i].AppliesTo(requestType)
+	}
+	for _, id := range ids {
+		if !usable[id] {
+			return fmt.Errorf("Invalid rejection reason %d", id)
+		}
+	}
+	return nil
 }
 
-func (r *AdminRepository) getRequestByRequestID(requestID int) *domain.Request {
-	var request *domain.Request
-	r.db.First(&request, requestID)
-	return request
+// findRequest loads a request visible in scope
+func findRequest(db *gorm.DB, id int, scope dto.AdminScope) (*domain.Request, error) {
+	var request domain.Request
+	if err := scopeRequests(db, scope).Where("id = ?", id).First(&request).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
 }
 
-func (r *AdminRepository) getDeptIdFromUser(id int) *int {
+func getDeptIdFromUser(tx *gorm.DB, id int) *int {
 	var user domain.User
-	r.db.First(&user, id)
+	tx.First(&user, id)
 	return user.DepartmentID
 }
 
-func updateRoleId(result *gorm.DB, r *AdminRepository, userID int, roleId int) (string, bool) {
-	result = r.db.Model(&domain.User{}).Where("id = ?", userID).Update("role_id", roleId)
-	if result.Error != nil {
-		return result.Error.Error(), true
-	}
-	return "", false
+func updateRoleId(tx *gorm.DB, userID int, roleId int) error {
+	return tx.Model(&domain.User{}).Where("id = ?", userID).Update("role_id", roleId).Error
 }
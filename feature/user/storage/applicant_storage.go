@@ -1,16 +1,18 @@
 package storage
 
 import (
+	"context"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 
 	"gorm.io/gorm"
 )
 
 type ApplicantRepositoryInterface interface {
-	CreateApplicant(user *domain.User) error
-	UpdateApplicant(user *domain.User) error
-	DeleteApplicant(id int) error
-	FindApplicantByID(id int) (*domain.User, error)
+	CreateApplicant(ctx context.Context, user *domain.User) error
+	UpdateApplicant(ctx context.Context, user *domain.User) error
+	DeleteApplicant(ctx context.Context, id int) error
+	FindApplicantByID(ctx context.Context, id int) (*domain.User, error)
 }
 
 type ApplicantRepository struct {
@@ -21,21 +23,21 @@ func NewApplicantRepository(db *gorm.DB) *ApplicantRepository {
 	return &ApplicantRepository{DB: db}
 }
 
-func (r *ApplicantRepository) CreateApplicant(user *domain.User) error {
-	return r.DB.Create(user).Error
+func (r *ApplicantRepository) CreateApplicant(ctx context.Context, user *domain.User) error {
+	return uow.Conn(ctx, r.DB).Create(user).Error
 }
 
-func (r *ApplicantRepository) UpdateApplicant(user *domain.User) error {
-	return r.DB.Save(user).Error
+func (r *ApplicantRepository) UpdateApplicant(ctx context.Context, user *domain.User) error {
+	return uow.Conn(ctx, r.DB).Save(user).Error
 }
 
-func (r *ApplicantRepository) DeleteApplicant(id int) error {
-	return r.DB.Delete(&domain.User{}, id).Error
+func (r *ApplicantRepository) DeleteApplicant(ctx context.Context, id int) error {
+	return uow.Conn(ctx, r.DB).Delete(&domain.User{}, id).Error
 }
 
-func (r *ApplicantRepository) FindApplicantByID(id int) (*domain.User, error) {
+func (r *ApplicantRepository) FindApplicantByID(ctx context.Context, id int) (*domain.User, error) {
 	var user domain.User
-	if err := r.DB.First(&user, id).Error; err != nil {
+	if err := uow.Conn(ctx, r.DB).First(&user, id).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
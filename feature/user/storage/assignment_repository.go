@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRequestNotPending = errors.New("request is not pending")
+	ErrRequestClaimed    = errors.New("request is claimed by another reviewer")
+)
+
+type AssignmentRepositoryInterface interface {
+	FindRequest(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, error)
+	ClaimRequest(ctx context.Context, id int, reviewerID int, expiresAt time.Time) error
+	ReleaseRequest(ctx context.Context, id int, reviewerID int, force bool) error
+	MarkViewed(ctx context.Context, id int, reviewerID int) error
+	ListQueue(ctx context.Context, reviewerID int, scope dto.AdminScope) ([]*domain.Request, error)
+	ListUnclaimed(ctx context.Context, scope dto.AdminScope, limit int) ([]*domain.Request, error)
+	ListActiveAdminIDs(ctx context.Context) ([]int, error)
+	CountActiveClaims(ctx context.Context, reviewerIDs []int) (map[int]int, error)
+}
+
+// AssignmentRepository keeps track of which reviewer holds which pending request
+type AssignmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAssignmentRepository(db *gorm.DB) *AssignmentRepository {
+	return &AssignmentRepository{db: db}
+}
+
+func (r *AssignmentRepository) FindRequest(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, error) {
+	return findRequest(uow.Conn(ctx, r.db), id, scope)
+}
+
+// ClaimRequest gives reviewerID the request until expiresAt, a reviewer can renew its own claim
+// and take over an expired one
+func (r *AssignmentRepository) ClaimRequest(ctx context.Context, id int, reviewerID int, expiresAt time.Time) error {
+	result := uow.Conn(ctx, r.db).Model(&domain.Request{}).
+		Where("id = ? AND status = 0", id).
+		Where("assignee_id IS NULL OR assignee_id = ? OR claim_expires_at IS NULL OR claim_expires_at <= ?", reviewerID, time.Now()).
+		Updates(map[string]interface{}{"assignee_id": reviewerID, "claim_expires_at": expiresAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return r.lockedReason(ctx, id)
+	}
+	return nil
+}
+
+// ReleaseRequest drops the claim of reviewerID, force drops anyone's claim
+func (r *AssignmentRepository) ReleaseRequest(ctx context.Context, id int, reviewerID int, force bool) error {
+	db := uow.Conn(ctx, r.db).Model(&domain.Request{}).Where("id = ? AND status = 0", id)
+	if !force {
+		db = db.Where("assignee_id IS NULL OR assignee_id = ? OR claim_expires_at IS NULL OR claim_expires_at <= ?", reviewerID, time.Now())
+	}
+	result := db.Updates(map[string]interface{}{"assignee_id": nil, "claim_expires_at": nil})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return r.lockedReason(ctx, id)
+	}
+	return nil
+}
+
+// MarkViewed records the first reviewer who opened the request, later calls keep it
+func (r *AssignmentRepository) MarkViewed(ctx context.Context, id int, reviewerID int) error {
+	return uow.Conn(ctx, r.db).Model(&domain.Request{}).
+		Where("id = ? AND viewed_at IS NULL", id).
+		Updates(map[string]interface{}{"viewed_at": time.Now(), "viewed_by": reviewerID}).Error
+}
+
+// ListQueue returns the pending requests reviewerID currently holds, oldest first
+func (r *AssignmentRepository) ListQueue(ctx context.Context, reviewerID int, scope dto.AdminScope) ([]*domain.Request, error) {
+	var requests []*domain.Request
+	err := scopeRequests(uow.Conn(ctx, r.db), scope).
+		Where("status = 0 AND assignee_id = ? AND claim_expires_at > ?", reviewerID, time.Now()).
+		Order("created_at").Order("id").
+		Find(&requests).Error
+	return requests, err
+}
+
+// ListUnclaimed returns pending requests nobody holds, oldest first
+func (r *AssignmentRepository) ListUnclaimed(ctx context.Context, scope dto.AdminScope, limit int) ([]*domain.Request, error) {
+	var requests []*domain.Request
+	err := scopeRequests(uow.Conn(ctx, r.db), scope).
+		Where("status = 0").
+		Where("assignee_id IS NULL OR claim_expires_at IS NULL OR claim_expires_at <= ?", time.Now()).
+		Order("created_at").Order("id").
+		Limit(limit).
+		Find(&requests).Error
+	return requests, err
+}
+
+func (r *AssignmentRepository) ListActiveAdminIDs(ctx context.Context) ([]int, error) {
+	var ids []int
+	err := uow.Conn(ctx, r.db).Model(&domain.User{}).Where("role_id = ? AND status = ?", 1, 1).Order("id").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// CountActiveClaims returns how many pending requests each reviewer holds right now
+func (r *AssignmentRepository) CountActiveClaims(ctx context.Context, reviewerIDs []int) (map[int]int, error) {
+	var rows []struct {
+		AssigneeID int
+		Count      int
+	}
+	err := uow.Conn(ctx, r.db).Model(&domain.Request{}).
+		Select("assignee_id, COUNT(*) AS count").
+		Where("status = 0 AND assignee_id IN ? AND claim_expires_at > ?", reviewerIDs, time.Now()).
+		Group("assignee_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		counts[row.AssigneeID] = row.Count
+	}
+	return counts, nil
+}
+
+// lockedReason explains why a claim update matched no row
+func (r *AssignmentRepository) lockedReason(ctx context.Context, id int) error {
+	var request domain.Request
+	if err := uow.Conn(ctx, r.db).First(&request, id).Error; err != nil {
+		return err
+	}
+	if request.Status != 0 {
+		return ErrRequestNotPending
+	}
+	return ErrRequestClaimed
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AttachmentStore keeps the content of comment attachments, paths are relative to the store
+type AttachmentStore interface {
+	Save(fileName string, content io.Reader) (string, error)
+	Open(path string) (io.ReadCloser, error)
+	Remove(path string) error
+}
+
+// GetAttachmentDir is where LocalAttachmentStore writes files, ATTACHMENT_DIR or ./attachments
+func GetAttachmentDir() string {
+	if dir := os.Getenv("ATTACHMENT_DIR"); dir != "" {
+		return dir
+	}
+	return "attachments"
+}
+
+// LocalAttachmentStore writes attachments to a directory under random names
+type LocalAttachmentStore struct {
+	dir string
+}
+
+func NewLocalAttachmentStore(dir string) *LocalAttachmentStore {
+	return &LocalAttachmentStore{dir: dir}
+}
+
+func (s *LocalAttachmentStore) Save(fileName string, content io.Reader) (string, error) {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return "", err
+	}
+	name := make([]byte, 16)
+	if _, err := rand.Read(name); err != nil {
+		return "", err
+	}
+	path := hex.EncodeToString(name) + strings.ToLower(filepath.Ext(fileName))
+	file, err := os.OpenFile(filepath.Join(s.dir, path), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		os.Remove(filepath.Join(s.dir, path))
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(filepath.Join(s.dir, path))
+		return "", err
+	}
+	return path, nil
+}
+
+func (s *LocalAttachmentStore) Open(path string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.dir, filepath.Base(path)))
+}
+
+func (s *LocalAttachmentStore) Remove(path string) error {
+	return os.Remove(filepath.Join(s.dir, filepath.Base(path)))
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CommentRepositoryInterface interface {
+	FindRequest(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, error)
+	FindUserRequest(ctx context.Context, id int, userID int) (*domain.Request, error)
+	FindComment(ctx context.Context, requestID int, id int) (*domain.RequestComment, error)
+	CreateComment(ctx context.Context, comment *domain.RequestComment, attachments []domain.CommentAttachment) error
+	ListComments(ctx context.Context, requestID int, externalOnly bool) ([]domain.RequestComment, error)
+	ListAttachments(ctx context.Context, commentIDs []int) ([]domain.CommentAttachment, error)
+	ListReads(ctx context.Context, commentIDs []int) ([]domain.CommentRead, error)
+	MarkRead(ctx context.Context, commentIDs []int, userID int) error
+	FindAttachment(ctx context.Context, requestID int, id int) (*domain.CommentAttachment, *domain.RequestComment, error)
+	ListReviewerIDs(ctx context.Context, requestID int) ([]int, error)
+}
+
+// CommentRepository stores the comment threads of requests
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+func (r *CommentRepository) FindRequest(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, error) {
+	return findRequest(uow.Conn(ctx, r.db), id, scope)
+}
+
+// FindUserRequest loads a request owned by userID
+func (r *CommentRepository) FindUserRequest(ctx context.Context, id int, userID int) (*domain.Request, error) {
+	var request domain.Request
+	if err := uow.Conn(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).First(&request).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *CommentRepository) FindComment(ctx context.Context, requestID int, id int) (*domain.RequestComment, error) {
+	var comment domain.RequestComment
+	if err := uow.Conn(ctx, r.db).Where("id = ? AND request_id = ?", id, requestID).First(&comment).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// CreateComment stores the comment and its attachments in one transaction
+func (r *CommentRepository) CreateComment(ctx context.Context, comment *domain.RequestComment, attachments []domain.CommentAttachment) error {
+	return uow.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+		if len(attachments) == 0 {
+			return nil
+		}
+		for i := range attachments {
+			attachments[i].CommentID = comment.ID
+		}
+		return tx.Create(&attachments).Error
+	})
+}
+
+func (r *CommentRepository) ListComments(ctx context.Context, requestID int, externalOnly bool) ([]domain.RequestComment, error) {
+	var comments []domain.RequestComment
+	db := uow.Conn(ctx, r.db).Where("request_id = ?", requestID)
+	if externalOnly {
+		db = db.Where("visibility = ?", domain.CommentExternal)
+	}
+	err := db.Order("created_at").Order("id").Find(&comments).Error
+	return comments, err
+}
+
+func (r *CommentRepository) ListAttachments(ctx context.Context, commentIDs []int) ([]domain.CommentAttachment, error) {
+	var attachments []domain.CommentAttachment
+	err := uow.Conn(ctx, r.db).Where("comment_id IN ?", commentIDs).Order("id").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *CommentRepository) ListReads(ctx context.Context, commentIDs []int) ([]domain.CommentRead, error) {
+	var reads []domain.CommentRead
+	err := uow.Conn(ctx, r.db).Where("comment_id IN ?", commentIDs).Order("read_at").Find(&reads).Error
+	return reads, err
+}
+
+// MarkRead records read receipts, comments already read keep their first read time
+func (r *CommentRepository) MarkRead(ctx context.Context, commentIDs []int, userID int) error {
+	now := time.Now()
+	reads := make([]domain.CommentRead, 0, len(commentIDs))
+	for _, id := range commentIDs {
+		reads = append(reads, domain.CommentRead{CommentID: id, UserID: userID, ReadAt: now})
+	}
+	return uow.Conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&reads).Error
+}
+
+// FindAttachment loads an attachment of the request together with its comment
+func (r *CommentRepository) FindAttachment(ctx context.Context, requestID int, id int) (*domain.CommentAttachment, *domain.RequestComment, error) {
+	var attachment domain.CommentAttachment
+	if err := uow.Conn(ctx, r.db).First(&attachment, id).Error; err != nil {
+		return nil, nil, err
+	}
+	comment, err := r.FindComment(ctx, requestID, attachment.CommentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &attachment, comment, nil
+}
+
+// ListReviewerIDs returns who handles the request on the reviewer side: the claim holder,
+// the verifier and every reviewer who commented, the requester excluded
+func (r *CommentRepository) ListReviewerIDs(ctx context.Context, requestID int) ([]int, error) {
+	var request domain.Request
+	if err := uow.Conn(ctx, r.db).First(&request, requestID).Error; err != nil {
+		return nil, err
+	}
+	var ids []int
+	err := uow.Conn(ctx, r.db).Model(&domain.RequestComment{}).
+		Where("request_id = ? AND author_id <> ?", requestID, request.UserID).
+		Distinct().Pluck("author_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	if holder := request.ClaimedBy(time.Now()); holder != nil {
+		ids = append(ids, *holder)
+	}
+	if request.VerifierID != nil {
+		ids = append(ids, *request.VerifierID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"gorm.io/gorm"
+)
+
+// ExportRepositoryInterface streams rows one at a time to fn so exports never hold the whole result in memory
+type ExportRepositoryInterface interface {
+	StreamRequests(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, fn func(row *dto.RequestExportRow) error) error
+	StreamVolunteers(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, fn func(row *dto.VolunteerExportRow) error) error
+	StreamApplicants(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, fn func(row *dto.ApplicantExportRow) error) error
+}
+
+type ExportRepository struct {
+	db *gorm.DB
+}
+
+func NewExportRepository(db *gorm.DB) *ExportRepository {
+	return &ExportRepository{db: db}
+}
+
+func (r *ExportRepository) StreamRequests(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, fn func(row *dto.RequestExportRow) error) error {
+	query := scopeRequests(uow.Conn(ctx, r.db), scope).Model(&domain.Request{}).
+		Select("requests.id, requests.user_id, users.email, users.name, users.surname, departments.name AS department, " +
+			"TRIM(requests.type) AS type, requests.status, requests.verifier_id, requests.reject_notes, requests.created_at, requests.decided_at").
+		Joins("JOIN users ON users.id = requests.user_id").
+		Joins("LEFT JOIN departments ON departments.id = users.department_id")
+	if filter.Status != nil {
+		query = query.Where("requests.status = ?", *filter.Status)
+	}
+	if filter.Type != "" {
+		query = query.Where("TRIM(requests.type) = ?", filter.Type)
+	}
+	if filter.DepartmentID != 0 {
+		query = query.Where("users.department_id = ?", filter.DepartmentID)
+	}
+	return streamRows(uow.Conn(ctx, r.db), query.Order("requests.id"), fn)
+}
+
+func (r *ExportRepository) StreamVolunteers(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, fn func(row *dto.VolunteerExportRow) error) error {
+	query := uow.Conn(ctx, r.db).Model(&domain.VolunteerDetail{}).
+		Select("volunteer_details.id, volunteer_details.user_id, users.email, users.name, users.surname, users.mobile, " +
+			"departments.name AS department, countries.name AS country, volunteer_details.status, volunteer_details.created_at").
+		Joins("JOIN users ON users.id = volunteer_details.user_id").
+		Joins("LEFT JOIN departments ON departments.id = volunteer_details.department_id").
+		Joins("LEFT JOIN countries ON countries.id = users.country_id")
+	if !scope.All {
+		query = query.Where("volunteer_details.department_id IN ?", scope.DepartmentIDs)
+	}
+	if filter.Status != nil {
+		query = query.Where("volunteer_details.status = ?", *filter.Status)
+	}
+	if filter.DepartmentID != 0 {
+		query = query.Where("volunteer_details.department_id = ?", filter.DepartmentID)
+	}
+	return streamRows(uow.Conn(ctx, r.db), query.Order("volunteer_details.id"), fn)
+}
+
+// StreamApplicants exports users that have not become volunteers yet
+func (r *ExportRepository) StreamApplicants(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, fn func(row *dto.ApplicantExportRow) error) error {
+	query := uow.Conn(ctx, r.db).Model(&domain.User{}).
+		Select("users.id, users.email, users.name, users.surname, users.gender, users.dob, users.mobile, " +
+			"departments.name AS department, countries.name AS country, users.verification_status, users.created_at").
+		Joins("LEFT JOIN departments ON departments.id = users.department_id").
+		Joins("LEFT JOIN countries ON countries.id = users.country_id").
+		Where("NOT EXISTS (SELECT 1 FROM volunteer_details WHERE volunteer_details.user_id = users.id)")
+	if !scope.All {
+		query = query.Where("users.department_id IN ?", scope.DepartmentIDs)
+	}
+	if filter.VerificationStatus != nil {
+		query = query.Where("users.verification_status = ?", *filter.VerificationStatus)
+	}
+	if filter.DepartmentID != 0 {
+		query = query.Where("users.department_id = ?", filter.DepartmentID)
+	}
+	return streamRows(uow.Conn(ctx, r.db), query.Order("users.id"), fn)
+}
+
+// streamRows iterates the query cursor and hands each scanned row to fn
+func streamRows[T any](db *gorm.DB, query *gorm.DB, fn func(row *T) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row T
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/event"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"gorm.io/gorm"
+)
+
+type ImportRepositoryInterface interface {
+	ListDepartments(ctx context.Context) ([]dto.NamedID, error)
+	ListCountries(ctx context.Context) ([]dto.NamedID, error)
+	FindExistingEmails(ctx context.Context, emails []string) ([]string, error)
+	CreateVolunteers(ctx context.Context, volunteers []*dto.VolunteerImport) error
+}
+
+type ImportRepository struct {
+	db  *gorm.DB
+	bus *event.Bus
+}
+
+func NewImportRepository(db *gorm.DB, bus *event.Bus) *ImportRepository {
+	return &ImportRepository{db: db, bus: bus}
+}
+
+func (r *ImportRepository) ListDepartments(ctx context.Context) ([]dto.NamedID, error) {
+	var departments []dto.NamedID
+	err := uow.Conn(ctx, r.db).Table("departments").Select("id, name").Scan(&departments).Error
+	return departments, err
+}
+
+func (r *ImportRepository) ListCountries(ctx context.Context) ([]dto.NamedID, error) {
+	var countries []dto.NamedID
+	err := uow.Conn(ctx, r.db).Table("countries").Select("id, name").Scan(&countries).Error
+	return countries, err
+}
+
+func (r *ImportRepository) FindExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	var existing []string
+	if len(emails) == 0 {
+		return existing, nil
+	}
+	err := uow.Conn(ctx, r.db).Model(&domain.User{}).Where("email IN ?", emails).Pluck("email", &existing).Error
+	return existing, err
+}
+
+// CreateVolunteers creates the users with their invite, their volunteer details and first department membership in
+// one transaction and publishes VolunteerCreated for each of them
+func (r *ImportRepository) CreateVolunteers(ctx context.Context, volunteers []*dto.VolunteerImport) error {
+	return r.bus.InTx(ctx, func(etx *event.Tx) error {
+		tx := etx.DB()
+		for _, volunteer := range volunteers {
+			if err := tx.Create(&volunteer.User).Error; err != nil {
+				return err
+			}
+			if volunteer.Invite != nil {
+				volunteer.Invite.UserID = volunteer.User.ID
+				if err := tx.Create(volunteer.Invite).Error; err != nil {
+					return err
+				}
+			}
+			detail := domain.VolunteerDetail{
+				UserID:       volunteer.User.ID,
+				DepartmentID: volunteer.DepartmentID,
+				Status:       1,
+			}
+			if err := tx.Create(&detail).Error; err != nil {
+				return err
+			}
+			err := tx.Table("department_memberships").Create(map[string]interface{}{
+				"volunteer_id":  detail.ID,
+				"department_id": detail.DepartmentID,
+				"start_date":    time.Now(),
+			}).Error
+			if err != nil {
+				return err
+			}
+			err = etx.Publish(event.VolunteerCreated{
+				VolunteerID:  detail.ID,
+				UserID:       detail.UserID,
+				DepartmentID: detail.DepartmentID,
+				Status:       detail.Status,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepositoryInterface interface {
+	ListReasons(ctx context.Context, activeOnly bool) ([]domain.RejectionReason, error)
+	FindReason(ctx context.Context, id int) (*domain.RejectionReason, error)
+	CreateReason(ctx context.Context, reason *domain.RejectionReason) error
+	UpdateReason(ctx context.Context, reason *domain.RejectionReason) error
+	ListChecklistItems(ctx context.Context, requestType string, activeOnly bool) ([]domain.ChecklistItem, error)
+	FindChecklistItem(ctx context.Context, id int) (*domain.ChecklistItem, error)
+	CreateChecklistItem(ctx context.Context, item *domain.ChecklistItem) error
+	UpdateChecklistItem(ctx context.Context, item *domain.ChecklistItem) error
+	FindRequest(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, error)
+	ListRequestChecklist(ctx context.Context, requestID int) ([]domain.RequestChecklistItem, error)
+	SetChecklistItem(ctx context.Context, requestID int, itemID int, checked bool, reviewerID int) error
+	ListRequestReasons(ctx context.Context, requestIDs []int) ([]dto.RejectionReasonDetail, error)
+	ListUserRequests(ctx context.Context, userID int) ([]*domain.Request, error)
+}
+
+// ReviewRepository stores the rejection reason catalog, the reviewer checklists and their use on requests
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+func (r *ReviewRepository) ListReasons(ctx context.Context, activeOnly bool) ([]domain.RejectionReason, error) {
+	var reasons []domain.RejectionReason
+	db := uow.Conn(ctx, r.db)
+	if activeOnly {
+		db = db.Where("status = ?", 1)
+	}
+	err := db.Order("id").Find(&reasons).Error
+	return reasons, err
+}
+
+func (r *ReviewRepository) FindReason(ctx context.Context, id int) (*domain.RejectionReason, error) {
+	var reason domain.RejectionReason
+	if err := uow.Conn(ctx, r.db).First(&reason, id).Error; err != nil {
+		return nil, err
+	}
+	return &reason, nil
+}
+
+func (r *ReviewRepository) CreateReason(ctx context.Context, reason *domain.RejectionReason) error {
+	return uow.Conn(ctx, r.db).Create(reason).Error
+}
+
+func (r *ReviewRepository) UpdateReason(ctx context.Context, reason *domain.RejectionReason) error {
+	return uow.Conn(ctx, r.db).Save(reason).Error
+}
+
+// ListChecklistItems returns the checklist of requestType in display order, every type when requestType is empty
+func (r *ReviewRepository) ListChecklistItems(ctx context.Context, requestType string, activeOnly bool) ([]domain.ChecklistItem, error) {
+	var items []domain.ChecklistItem
+	db := uow.Conn(ctx, r.db)
+	if requestType != "" {
+		db = db.Where("request_type = ?", requestType)
+	}
+	if activeOnly {
+		db = db.Where("status = ?", 1)
+	}
+	err := db.Order("request_type").Order("position").Order("id").Find(&items).Error
+	return items, err
+}
+
+func (r *ReviewRepository) FindChecklistItem(ctx context.Context, id int) (*domain.ChecklistItem, error) {
+	var item domain.ChecklistItem
+	if err := uow.Conn(ctx, r.db).First(&item, id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *ReviewRepository) CreateChecklistItem(ctx context.Context, item *domain.ChecklistItem) error {
+	return uow.Conn(ctx, r.db).Create(item).Error
+}
+
+func (r *ReviewRepository) UpdateChecklistItem(ctx context.Context, item *domain.ChecklistItem) error {
+	return uow.Conn(ctx, r.db).Save(item).Error
+}
+
+func (r *ReviewRepository) FindRequest(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, error) {
+	return findRequest(uow.Conn(ctx, r.db), id, scope)
+}
+
+func (r *ReviewRepository) ListRequestChecklist(ctx context.Context, requestID int) ([]domain.RequestChecklistItem, error) {
+	var items []domain.RequestChecklistItem
+	err := uow.Conn(ctx, r.db).Where("request_id = ?", requestID).Find(&items).Error
+	return items, err
+}
+
+// SetChecklistItem ticks or unticks one checklist item of a request
+func (r *ReviewRepository) SetChecklistItem(ctx context.Context, requestID int, itemID int, checked bool, reviewerID int) error {
+	now := time.Now()
+	item := domain.RequestChecklistItem{
+		RequestID:       requestID,
+		ChecklistItemID: itemID,
+		Checked:         checked,
+		CheckedBy:       &reviewerID,
+		CheckedAt:       &now,
+	}
+	return uow.Conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "request_id"}, {Name: "checklist_item_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"checked", "checked_by", "checked_at", "updated_at"}),
+	}).Create(&item).Error
+}
+
+func (r *ReviewRepository) ListRequestReasons(ctx context.Context, requestIDs []int) ([]dto.RejectionReasonDetail, error) {
+	var reasons []dto.RejectionReasonDetail
+	err := uow.Conn(ctx, r.db).Table("request_rejection_reasons rr").
+		Select("rr.request_id, rr.rejection_reason_id AS reason_id, r.code, r.label, r.applicant_message, rr.field, rr.comment").
+		Joins("JOIN rejection_reasons r ON r.id = rr.rejection_reason_id").
+		Where("rr.request_id IN ?", requestIDs).
+		Order("rr.request_id").Order("rr.id").
+		Scan(&reasons).Error
+	return reasons, err
+}
+
+func (r *ReviewRepository) ListUserRequests(ctx context.Context, userID int) ([]*domain.Request, error) {
+	var requests []*domain.Request
+	err := uow.Conn(ctx, r.db).Where("user_id = ?", userID).Order("created_at DESC").Order("id DESC").Find(&requests).Error
+	return requests, err
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SLARepositoryInterface interface {
+	ListSLAs(ctx context.Context) ([]domain.RequestSLA, error)
+	SaveSLA(ctx context.Context, sla *domain.RequestSLA) error
+	DeleteSLA(ctx context.Context, requestType string) error
+	ListReminderDue(ctx context.Context, sla domain.RequestSLA, now time.Time) ([]*domain.Request, error)
+	ListBreached(ctx context.Context, sla domain.RequestSLA, now time.Time) ([]*domain.Request, error)
+	MarkReminded(ctx context.Context, id int, at time.Time) (bool, error)
+	MarkEscalated(ctx context.Context, id int, at time.Time) (bool, error)
+	EscalationRecipients(ctx context.Context, userID int) ([]int, error)
+}
+
+// SLARepository stores the SLA of each request type and finds the pending requests that
+// approach or passed their deadline
+type SLARepository struct {
+	db *gorm.DB
+}
+
+func NewSLARepository(db *gorm.DB) *SLARepository {
+	return &SLARepository{db: db}
+}
+
+func (r *SLARepository) ListSLAs(ctx context.Context) ([]domain.RequestSLA, error) {
+	return listRequestSLAs(uow.Conn(ctx, r.db))
+}
+
+// SaveSLA creates the SLA of its request type or replaces it
+func (r *SLARepository) SaveSLA(ctx context.Context, sla *domain.RequestSLA) error {
+	return uow.Conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "request_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"target_hours", "reminder_hours", "updated_at"}),
+	}).Create(sla).Error
+}
+
+func (r *SLARepository) DeleteSLA(ctx context.Context, requestType string) error {
+	result := uow.Conn(ctx, r.db).Where("request_type = ?", requestType).Delete(&domain.RequestSLA{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListReminderDue returns the pending requests of the SLA type held by a reviewer, whose reminder
+// time passed but not their deadline and whose reviewer was not reminded yet
+func (r *SLARepository) ListReminderDue(ctx context.Context, sla domain.RequestSLA, now time.Time) ([]*domain.Request, error) {
+	target := time.Duration(sla.TargetHours) * time.Hour
+	reminder := time.Duration(sla.ReminderHours) * time.Hour
+	var requests []*domain.Request
+	err := uow.Conn(ctx, r.db).
+		Where("status = 0 AND type = ? AND sla_reminded_at IS NULL", sla.RequestType).
+		Where("created_at <= ? AND created_at > ?", now.Add(reminder-target), now.Add(-target)).
+		Where("assignee_id IS NOT NULL AND claim_expires_at > ?", now).
+		Order("created_at").Order("id").
+		Find(&requests).Error
+	return requests, err
+}
+
+// ListBreached returns the pending requests of the SLA type past their deadline that were not escalated yet
+func (r *SLARepository) ListBreached(ctx context.Context, sla domain.RequestSLA, now time.Time) ([]*domain.Request, error) {
+	var requests []*domain.Request
+	err := uow.Conn(ctx, r.db).
+		Where("status = 0 AND type = ? AND sla_escalated_at IS NULL", sla.RequestType).
+		Where("created_at <= ?", now.Add(-time.Duration(sla.TargetHours)*time.Hour)).
+		Order("created_at").Order("id").
+		Find(&requests).Error
+	return requests, err
+}
+
+// MarkReminded records the reminder of a pending request, false when it was already sent or the request decided
+func (r *SLARepository) MarkReminded(ctx context.Context, id int, at time.Time) (bool, error) {
+	result := uow.Conn(ctx, r.db).Model(&domain.Request{}).
+		Where("id = ? AND status = 0 AND sla_reminded_at IS NULL", id).
+		Update("sla_reminded_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
+// MarkEscalated records the escalation of a pending request, false when it was already sent or the request decided
+func (r *SLARepository) MarkEscalated(ctx context.Context, id int, at time.Time) (bool, error) {
+	result := uow.Conn(ctx, r.db).Model(&domain.Request{}).
+		Where("id = ? AND status = 0 AND sla_escalated_at IS NULL", id).
+		Update("sla_escalated_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
+// EscalationRecipients returns the managers of the department of userID and of its parent
+// departments, a manager's scope covers the sub-departments. Users without department or whose
+// departments have no manager are escalated to the active admins.
+func (r *SLARepository) EscalationRecipients(ctx context.Context, userID int) ([]int, error) {
+	db := uow.Conn(ctx, r.db)
+	var user domain.User
+	if err := db.Select("id", "department_id").First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	var managers []int
+	if user.DepartmentID != nil {
+		var departments []struct {
+			ID       int
+			ParentID *int
+		}
+		if err := db.Table("departments").Select("id", "parent_id").Scan(&departments).Error; err != nil {
+			return nil, err
+		}
+		parents := make(map[int]*int, len(departments))
+		for _, department := range departments {
+			parents[department.ID] = department.ParentID
+		}
+		var lineage []int
+		seen := make(map[int]bool)
+		for id := user.DepartmentID; id != nil && !seen[*id]; id = parents[*id] {
+			seen[*id] = true
+			lineage = append(lineage, *id)
+		}
+		err := db.Table("department_managers").Distinct("user_id").Where("department_id IN ?", lineage).Order("user_id").Pluck("user_id", &managers).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(managers) > 0 {
+		return managers, nil
+	}
+	var admins []int
+	err := db.Model(&domain.User{}).Where("role_id = ? AND status = ?", 1, 1).Order("id").Pluck("id", &admins).Error
+	return admins, err
+}
+
+func listRequestSLAs(db *gorm.DB) ([]domain.RequestSLA, error) {
+	var slas []domain.RequestSLA
+	err := db.Order("request_type").Find(&slas).Error
+	return slas, err
+}
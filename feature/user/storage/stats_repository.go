@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"gorm.io/gorm"
+)
+
+type StatsRepositoryInterface interface {
+	CountRequestsByType(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter) ([]dto.RequestTypeCount, error)
+	MedianDecisionSeconds(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter) (*float64, error)
+	CountRegistrations(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter, weekly bool) ([]dto.PeriodCount, error)
+	CountActiveVolunteersByDepartment(ctx context.Context, scope dto.AdminScope) ([]dto.GroupCount, error)
+	CountActiveVolunteersByCountry(ctx context.Context, scope dto.AdminScope) ([]dto.GroupCount, error)
+	ReviewerThroughput(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter) ([]dto.ReviewerThroughput, error)
+}
+
+// StatsRepository computes the admin dashboard figures with aggregate queries
+type StatsRepository struct {
+	db *gorm.DB
+}
+
+func NewStatsRepository(db *gorm.DB) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+// CountRequestsByType counts the requests submitted in the window by their current status
+func (r *StatsRepository) CountRequestsByType(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter) ([]dto.RequestTypeCount, error) {
+	var counts []dto.RequestTypeCount
+	err := scopeRequests(uow.Conn(ctx, r.db), scope).Model(&domain.Request{}).
+		Where("created_at >= ? AND created_at < ?", filter.From, filter.To).
+		Select("TRIM(type) AS type, " +
+			"SUM(CASE WHEN status = 0 THEN 1 ELSE 0 END) AS pending, " +
+			"SUM(CASE WHEN status = 1 THEN 1 ELSE 0 END) AS approved, " +
+			"SUM(CASE WHEN status = 2 THEN 1 ELSE 0 END) AS rejected").
+		Group("TRIM(type)").Order("type").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// MedianDecisionSeconds ranks the decision times with window functions so only the middle rows leave the database,
+// nil when nothing was decided in the window
+func (r *StatsRepository) MedianDecisionSeconds(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter) (*float64, error) {
+	durations := scopeRequests(uow.Conn(ctx, r.db), scope).Model(&domain.Request{}).
+		Select("TIMESTAMPDIFF(SECOND, created_at, decided_at) AS seconds, "+
+			"ROW_NUMBER() OVER (ORDER BY TIMESTAMPDIFF(SECOND, created_at, decided_at)) AS rn, "+
+			"COUNT(*) OVER () AS cnt").
+		Where("status IN ? AND decided_at >= ? AND decided_at < ?", []int{1, 2}, filter.From, filter.To)
+	var median sql.NullFloat64
+	err := uow.Conn(ctx, r.db).Table("(?) AS d", durations).
+		Select("AVG(seconds)").
+		Where("rn IN (FLOOR((cnt + 1) / 2), CEIL((cnt + 1) / 2))").
+		Row().Scan(&median)
+	if err != nil || !median.Valid {
+		return nil, err
+	}
+	return &median.Float64, nil
+}
+
+// CountRegistrations counts new users per day, or per ISO week when weekly is set
+func (r *StatsRepository) CountRegistrations(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter, weekly bool) ([]dto.PeriodCount, error) {
+	period := "DATE_FORMAT(created_at, '%Y-%m-%d')"
+	if weekly {
+		period = "DATE_FORMAT(created_at, '%x-W%v')"
+	}
+	query := uow.Conn(ctx, r.db).Model(&domain.User{}).Where("created_at >= ? AND created_at < ?", filter.From, filter.To)
+	if !scope.All {
+		query = query.Where("department_id IN ?", scope.DepartmentIDs)
+	}
+	var counts []dto.PeriodCount
+	err := query.Select(period + " AS period, COUNT(*) AS count").
+		Group(period).Order("period").
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *StatsRepository) CountActiveVolunteersByDepartment(ctx context.Context, scope dto.AdminScope) ([]dto.GroupCount, error) {
+	var counts []dto.GroupCount
+	err := activeVolunteers(uow.Conn(ctx, r.db), scope).
+		Select("volunteer_details.department_id AS id, departments.name AS name, COUNT(*) AS count").
+		Joins("LEFT JOIN departments ON departments.id = volunteer_details.department_id").
+		Group("volunteer_details.department_id, departments.name").Order("count DESC").
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *StatsRepository) CountActiveVolunteersByCountry(ctx context.Context, scope dto.AdminScope) ([]dto.GroupCount, error) {
+	var counts []dto.GroupCount
+	err := activeVolunteers(uow.Conn(ctx, r.db), scope).
+		Select("users.country_id AS id, countries.name AS name, COUNT(*) AS count").
+		Joins("JOIN users ON users.id = volunteer_details.user_id").
+		Joins("LEFT JOIN countries ON countries.id = users.country_id").
+		Group("users.country_id, countries.name").Order("count DESC").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// ReviewerThroughput counts the decisions each verifier made in the window
+func (r *StatsRepository) ReviewerThroughput(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter) ([]dto.ReviewerThroughput, error) {
+	var throughput []dto.ReviewerThroughput
+	err := scopeRequests(uow.Conn(ctx, r.db), scope).Model(&domain.Request{}).
+		Select("requests.verifier_id AS verifier_id, CONCAT(users.name, ' ', users.surname) AS name, "+
+			"SUM(CASE WHEN requests.status = 1 THEN 1 ELSE 0 END) AS approved, "+
+			"SUM(CASE WHEN requests.status = 2 THEN 1 ELSE 0 END) AS rejected, "+
+			"COUNT(*) AS total").
+		Joins("JOIN users ON users.id = requests.verifier_id").
+		Where("requests.status IN ? AND requests.decided_at >= ? AND requests.decided_at < ?", []int{1, 2}, filter.From, filter.To).
+		Group("requests.verifier_id, users.name, users.surname").Order("total DESC").
+		Scan(&throughput).Error
+	return throughput, err
+}
+
+func activeVolunteers(db *gorm.DB, scope dto.AdminScope) *gorm.DB {
+	query := db.Model(&domain.VolunteerDetail{}).Where("volunteer_details.status = ?", 1)
+	if !scope.All {
+		query = query.Where("volunteer_details.department_id IN ?", scope.DepartmentIDs)
+	}
+	return query
+}
@@ -1,13 +1,15 @@
 package storage
 
 import (
+	"context"
 	"errors"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"gorm.io/gorm"
 )
 
 type VolunteerRequestRepositoryInterface interface {
-	CreateVolunteerRequest(reqRequest *domain.Request, reqUser *domain.User) error
+	CreateVolunteerRequest(ctx context.Context, reqRequest *domain.Request, reqUser *domain.User) error
 }
 
 type VolunteerRequestRepository struct {
@@ -18,10 +20,10 @@ func NewVolunteerRequestRepository(db *gorm.DB) *VolunteerRequestRepository {
 	return &VolunteerRequestRepository{db: db}
 }
 
-func (r *VolunteerRequestRepository) CreateVolunteerRequest(reqRequest *domain.Request, reqUser *domain.User) error {
+func (r *VolunteerRequestRepository) CreateVolunteerRequest(ctx context.Context, reqRequest *domain.Request, reqUser *domain.User) error {
 	// find request
 	var existingRequests []domain.Request
-	query := r.db.Where("user_id = ?", reqUser.ID).Find(&existingRequests)
+	query := uow.Conn(ctx, r.db).Where("user_id = ?", reqUser.ID).Find(&existingRequests)
 	if query.Error != nil {
 		return query.Error
 	}
@@ -29,11 +31,11 @@ func (r *VolunteerRequestRepository) CreateVolunteerRequest(reqRequest *domain.R
 		return errors.New("this user already has a request")
 	}
 	//find user
-	if err := r.db.First(&domain.User{}, reqUser.ID).Error; err != nil {
+	if err := uow.Conn(ctx, r.db).First(&domain.User{}, reqUser.ID).Error; err != nil {
 		return errors.New("user not found")
 	}
 	// update user
-	result := r.db.Model(&domain.User{}).Where("id = ?", reqUser.ID).Updates(map[string]interface{}{
+	result := uow.Conn(ctx, r.db).Model(&domain.User{}).Where("id = ?", reqUser.ID).Updates(map[string]interface{}{
 		"department_id":       reqUser.DepartmentID,
 		"gender":              reqUser.Gender,
 		"dob":                 reqUser.Dob,
@@ -44,5 +46,5 @@ func (r *VolunteerRequestRepository) CreateVolunteerRequest(reqRequest *domain.R
 	if result.Error != nil {
 		return result.Error
 	}
-	return r.db.Create(reqRequest).Error
+	return uow.Conn(ctx, r.db).Create(reqRequest).Error
 }
@@ -19,18 +19,19 @@ func NewAuthenticationHandler(usecase usecase.AdminUsecaseInterface) *AdminHandl
 
 // GetListPendingRequest godoc
 // @Summary Get list pending request
-// @Description Get list pending request
+// @Description Get list pending request, with the deadline of each request and whether it breached the SLA of its type
 // @Produce json
 // @Tags admin
 // @Security bearerToken
-// @Success 200 {object} dto.ListRequest{}
+// @Success 200 {object} dto.ListPendingRequest{}
 // @Router /api/v1/admin/list-pending-request [get]
 func (h *AdminHandler) GetListPendingRequest(c *gin.Context) {
-	if err := h.checkAdminRole(c); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	scope, err := h.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
 		return
 	}
-	resp, msg := h.usecase.GetListPendingRequest()
+	resp, msg := h.usecase.GetListPendingRequest(c.Request.Context(), *scope)
 	if msg != "" {
 		c.JSON(http.StatusNotFound, gin.H{"error": msg})
 		return
@@ -48,8 +49,9 @@ func (h *AdminHandler) GetListPendingRequest(c *gin.Context) {
 // @Security bearerToken
 // @Router /api/v1/admin/pending-request/{id} [get]
 func (h *AdminHandler) GetPendingRequestById(c *gin.Context) {
-	if err := h.checkAdminRole(c); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	scope, err := h.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
 		return
 	}
 	id, err := strconv.Atoi(c.Param("id"))
@@ -57,7 +59,7 @@ func (h *AdminHandler) GetPendingRequestById(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
 		return
 	}
-	resp, msg := h.usecase.GetPendingRequestById(id)
+	resp, msg := h.usecase.GetPendingRequestById(c.Request.Context(), id, *scope)
 	if msg != "" {
 		c.JSON(http.StatusNotFound, gin.H{"error": msg})
 		return
@@ -74,11 +76,12 @@ func (h *AdminHandler) GetPendingRequestById(c *gin.Context) {
 // @Success 200 {object} dto.ListRequest{}
 // @Router /api/v1/admin/list-request [get]
 func (h *AdminHandler) GetListRequest(c *gin.Context) {
-	if err := h.checkAdminRole(c); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	scope, err := h.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
 		return
 	}
-	resp, msg := h.usecase.GetListRequest()
+	resp, msg := h.usecase.GetListRequest(c.Request.Context(), *scope)
 	if msg != "" {
 		c.JSON(http.StatusNotFound, gin.H{"error": msg})
 		return
@@ -96,8 +99,9 @@ func (h *AdminHandler) GetListRequest(c *gin.Context) {
 // @Security bearerToken
 // @Router /api/v1/admin/request/{id} [get]
 func (h *AdminHandler) GetRequestById(c *gin.Context) {
-	if err := h.checkAdminRole(c); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	scope, err := h.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
 		return
 	}
 	id, err := strconv.Atoi(c.Param("id"))
@@ -105,7 +109,7 @@ func (h *AdminHandler) GetRequestById(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
 		return
 	}
-	resp, msg := h.usecase.GetRequestById(id)
+	resp, msg := h.usecase.GetRequestById(c.Request.Context(), id, *scope)
 	if msg != "" {
 		c.JSON(http.StatusNotFound, gin.H{"error": msg})
 		return
@@ -123,8 +127,9 @@ func (h *AdminHandler) GetRequestById(c *gin.Context) {
 // @Security bearerToken
 // @Router /api/v1/admin/approve-request/{id} [post]
 func (h *AdminHandler) ApproveRequest(c *gin.Context) {
-	if err := h.checkAdminRole(c); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	scope, err := h.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
 		return
 	}
 	id, err := strconv.Atoi(c.Param("id"))
@@ -137,7 +142,7 @@ func (h *AdminHandler) ApproveRequest(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
-	msg := h.usecase.ApproveRequest(id, userId.(int))
+	msg := h.usecase.ApproveRequest(c.Request.Context(), id, userId.(int), *scope)
 	c.JSON(http.StatusOK, gin.H{"message": msg})
 }
 
@@ -151,21 +156,87 @@ func (h *AdminHandler) ApproveRequest(c *gin.Context) {
 // @Security bearerToken
 // @Router /api/v1/admin/reject-request/{id} [post]
 func (h *AdminHandler) RejectRequest(c *gin.Context) {
-	if err := h.checkAdminRole(c); err != nil {
+	scope, err := h.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	msg := h.usecase.RejectRequest(c.Request.Context(), id, userId.(int), *scope)
+	c.JSON(http.StatusOK, gin.H{"message": msg})
+}
+
+// BulkDecide godoc
+// @Summary Approve or reject requests in bulk
+// @Description Approve or reject a list of requests, each request is processed in its own transaction
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param request body dto.BulkDecisionRequest true "Bulk Decision Request"
+// @Success 200 {object} dto.BulkDecisionResponse
+// @Security bearerToken
+// @Router /api/v1/admin/requests/bulk [post]
+func (h *AdminHandler) BulkDecide(c *gin.Context) {
+	scope, err := h.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	var req dto.BulkDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	c.JSON(http.StatusOK, h.usecase.BulkDecide(c.Request.Context(), req, userId.(int), *scope))
+}
+
+// RejectRequestWithReasons godoc
+// @Summary Reject request with reasons
+// @Description Reject a request with one or more catalog reasons, each can point at a field or document
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Param request body dto.RejectRequestDTO true "Reject Request"
+// @Success 200 string message
+// @Security bearerToken
+// @Router /api/v1/admin/requests/{id}/reject [post]
+func (h *AdminHandler) RejectRequestWithReasons(c *gin.Context) {
+	scope, err := h.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
 		return
 	}
+	var req dto.RejectRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	userId, exists := c.Get("userId")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
-	msg := h.usecase.RejectRequest(id, userId.(int))
+	msg := h.usecase.RejectRequestWithReasons(c.Request.Context(), id, userId.(int), req, *scope)
 	c.JSON(http.StatusOK, gin.H{"message": msg})
 }
 
@@ -180,8 +251,9 @@ func (h *AdminHandler) RejectRequest(c *gin.Context) {
 // @Security bearerToken
 // @Router /api/v1/admin/add-reject-notes/{id} [post]
 func (h *AdminHandler) AddRejectNotes(c *gin.Context) {
-	if err := h.checkAdminRole(c); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	scope, err := h.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
 		return
 	}
 	id, err := strconv.Atoi(c.Param("id"))
@@ -194,7 +266,7 @@ func (h *AdminHandler) AddRejectNotes(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	msg := h.usecase.AddRejectNotes(id, req.Notes)
+	msg := h.usecase.AddRejectNotes(c.Request.Context(), id, req.Notes, *scope)
 	c.JSON(http.StatusOK, gin.H{"message": msg})
 }
 
@@ -208,8 +280,9 @@ func (h *AdminHandler) AddRejectNotes(c *gin.Context) {
 // @Security bearerToken
 // @Router /api/v1/admin/delete-request/{id} [delete]
 func (h *AdminHandler) DeleteRequest(c *gin.Context) {
-	if err := h.checkAdminRole(c); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	scope, err := h.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
 		return
 	}
 	id, err := strconv.Atoi(c.Param("id"))
@@ -217,14 +290,51 @@ func (h *AdminHandler) DeleteRequest(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
 		return
 	}
-	msg := h.usecase.DeleteRequest(id)
+	msg := h.usecase.DeleteRequest(c.Request.Context(), id, *scope)
 	c.JSON(http.StatusOK, gin.H{"message": msg})
 }
 
-func (h *AdminHandler) checkAdminRole(c *gin.Context) error {
+// GetListVolunteer godoc
+// @Summary Get list volunteer
+// @Description Get volunteers, department managers only see their department subtree
+// @Produce json
+// @Tags admin
+// @Security bearerToken
+// @Success 200 {object} dto.ListVolunteer{}
+// @Router /api/v1/admin/list-volunteer [get]
+func (h *AdminHandler) GetListVolunteer(c *gin.Context) {
+	scope, err := h.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	resp, msg := h.usecase.GetListVolunteer(c.Request.Context(), *scope)
+	if msg != "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": msg})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// resolveScope allows admins and department managers, limiting managers to their department subtree
+func (h *AdminHandler) resolveScope(c *gin.Context) (*dto.AdminScope, error) {
 	roleId, exists := c.Get("roleId")
-	if !exists || roleId.(int) != 1 {
-		return errors.New("forbidden: only admins can perform this action")
+	if !exists {
+		return nil, usecase.ErrForbidden
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		return nil, usecase.ErrForbidden
+	}
+	return h.usecase.ResolveScope(c.Request.Context(), userId.(int), roleId.(int))
+}
+
+// scopeError answers a failed resolveScope, 403 when the user may not act and 500 when the
+// managed departments could not be loaded
+func scopeError(c *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
 	}
-	return nil
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 }
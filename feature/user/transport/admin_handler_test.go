@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubDepartmentScope struct {
+	departmentIDs []int
+	err           error
+}
+
+func (s stubDepartmentScope) ManagedDepartmentIDs(ctx context.Context, userID int) ([]int, error) {
+	return s.departmentIDs, s.err
+}
+
+func pendingRequestsRouter(scope stubDepartmentScope, roleID int) *gin.Engine {
+	handler := NewAuthenticationHandler(usecase.NewAdminUsecase(nil, scope, nil))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/admin/list-pending-request", func(c *gin.Context) {
+		c.Set("userId", 5)
+		if roleID != 0 {
+			c.Set("roleId", roleID)
+		}
+	}, handler.GetListPendingRequest)
+	return r
+}
+
+func TestResolveScope_Status(t *testing.T) {
+	tests := []struct {
+		name   string
+		scope  stubDepartmentScope
+		roleID int
+		status int
+	}{
+		{name: "no role", roleID: 0, status: http.StatusForbidden},
+		{name: "not a manager", roleID: 2, status: http.StatusForbidden},
+		{name: "managed departments unavailable", scope: stubDepartmentScope{err: errors.New("connection lost")}, roleID: 2, status: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := pendingRequestsRouter(tt.scope, tt.roleID)
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/list-pending-request", nil)
+			assert.NoError(t, err)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
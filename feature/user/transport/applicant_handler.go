@@ -33,7 +33,7 @@ func (h *ApplicantHandler) CreateApplicant(c *gin.Context) {
 		return
 	}
 
-	if err := h.ApplicantUseCaseH.CreateApplicant(request); err != nil {
+	if err := h.ApplicantUseCaseH.CreateApplicant(c.Request.Context(), request); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -63,7 +63,7 @@ func (h *ApplicantHandler) UpdateApplicant(c *gin.Context) {
 		return
 	}
 
-	if err := h.ApplicantUseCaseH.UpdateApplicant(id, request); err != nil {
+	if err := h.ApplicantUseCaseH.UpdateApplicant(c.Request.Context(), id, request); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -86,7 +86,7 @@ func (h *ApplicantHandler) DeleteApplicant(c *gin.Context) {
 		return
 	}
 
-	if err := h.ApplicantUseCaseH.DeleteApplicant(id); err != nil {
+	if err := h.ApplicantUseCaseH.DeleteApplicant(c.Request.Context(), id); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -109,7 +109,7 @@ func (h *ApplicantHandler) FindApplicantByID(c *gin.Context) {
 		return
 	}
 
-	user, err := h.ApplicantUseCaseH.FindApplicantByID(id)
+	user, err := h.ApplicantUseCaseH.FindApplicantByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -32,7 +32,7 @@ func (h *RequestHandler) CreateApplicantRequest(c *gin.Context) {
 		return
 	}
 
-	if err := h.RequestUsecase.CreateApplicantRequest(request); err != nil {
+	if err := h.RequestUsecase.CreateApplicantRequest(c.Request.Context(), request); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -16,7 +17,7 @@ type MockApplicantRequestUsecase struct {
 	mock.Mock
 }
 
-func (m *MockApplicantRequestUsecase) CreateApplicantRequest(input dto.ApplicantRequestCreatingDTO) error {
+func (m *MockApplicantRequestUsecase) CreateApplicantRequest(ctx context.Context, input dto.RequestCreatingDTO) error {
 	args := m.Called(input)
 	return args.Error(0)
 }
@@ -30,14 +31,14 @@ func TestCreateApplicantRequest(t *testing.T) {
 	r.POST("/api/v1/applicant-request", handler.CreateApplicantRequest)
 
 	t.Run("success", func(t *testing.T) {
-		mockInput := dto.ApplicantRequestCreatingDTO{
-			UserID: 1,
-			Type:   "Application",
-			Status: 0,
+		departmentID := 2
+		mockInput := dto.RequestCreatingDTO{
+			UserID:       1,
+			DepartmentID: &departmentID,
 		}
 		mockUsecase.On("CreateApplicantRequest", mockInput).Return(nil)
 
-		body := `{"user_id":1,"type":"Application","status":"Pending"}`
+		body := `{"user_id":1,"department_id":2}`
 		req, err := http.NewRequest(http.MethodPost, "/api/v1/applicant-request", strings.NewReader(body))
 		assert.NoError(t, err)
 		req.Header.Set("Content-Type", "application/json")
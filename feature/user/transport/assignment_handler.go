@@ -0,0 +1,183 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type AssignmentHandler struct {
+	usecase usecase.AssignmentUsecaseInterface
+	admin   *AdminHandler
+}
+
+func NewAssignmentHandler(usecase usecase.AssignmentUsecaseInterface, admin *AdminHandler) *AssignmentHandler {
+	return &AssignmentHandler{usecase: usecase, admin: admin}
+}
+
+// ClaimRequest godoc
+// @Summary Claim a pending request
+// @Description Lock a pending request for the current reviewer until the lease expires, claiming again renews the lease
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Param lease_minutes query int false "Lease in minutes, 30 by default, 480 at most"
+// @Security bearerToken
+// @Success 200 {object} dto.ClaimResponse{}
+// @Router /api/v1/admin/requests/{id}/claim [post]
+func (h *AssignmentHandler) ClaimRequest(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	var lease time.Duration
+	if v := c.Query("lease_minutes"); v != "" {
+		minutes, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lease_minutes"})
+			return
+		}
+		lease = time.Duration(minutes) * time.Minute
+	}
+	claim, err := h.usecase.ClaimRequest(c.Request.Context(), id, c.GetInt("userId"), lease, *scope)
+	if err != nil {
+		c.JSON(assignmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, claim)
+}
+
+// ReleaseRequest godoc
+// @Summary Release a claimed request
+// @Description Give a claimed request back to the pool, admins can release claims of other reviewers
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Security bearerToken
+// @Success 200 string message
+// @Router /api/v1/admin/requests/{id}/claim [delete]
+func (h *AssignmentHandler) ReleaseRequest(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	if err := h.usecase.ReleaseRequest(c.Request.Context(), id, c.GetInt("userId"), *scope); err != nil {
+		c.JSON(assignmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Request released successfully"})
+}
+
+// MarkViewed godoc
+// @Summary Mark a request as viewed
+// @Description Record that the request has been reviewed, the first viewer is kept
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Security bearerToken
+// @Success 200 string message
+// @Router /api/v1/admin/requests/{id}/viewed [post]
+func (h *AssignmentHandler) MarkViewed(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	if err := h.usecase.MarkViewed(c.Request.Context(), id, c.GetInt("userId"), *scope); err != nil {
+		c.JSON(assignmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Request marked as viewed"})
+}
+
+// GetQueue godoc
+// @Summary Get my review queue
+// @Description Pending requests claimed by or assigned to the current reviewer, oldest first
+// @Produce json
+// @Tags admin
+// @Security bearerToken
+// @Success 200 {object} dto.ListRequest{}
+// @Router /api/v1/admin/requests/queue [get]
+func (h *AssignmentHandler) GetQueue(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	queue, err := h.usecase.GetQueue(c.Request.Context(), c.GetInt("userId"), *scope)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, queue)
+}
+
+// AutoAssign godoc
+// @Summary Auto-assign pending requests
+// @Description Distribute unclaimed pending requests round-robin between reviewers, every active admin by default
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param request body dto.AutoAssignRequest true "Auto Assign Request"
+// @Security bearerToken
+// @Success 200 {object} dto.AutoAssignResponse{}
+// @Router /api/v1/admin/requests/auto-assign [post]
+func (h *AssignmentHandler) AutoAssign(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	var req dto.AutoAssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	assignments, err := h.usecase.AutoAssign(c.Request.Context(), req, *scope)
+	if err != nil {
+		c.JSON(assignmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, assignments)
+}
+
+func assignmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrAutoAssignForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, storage.ErrRequestClaimed),
+		errors.Is(err, storage.ErrRequestNotPending):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrClaimLease),
+		errors.Is(err, usecase.ErrNoReviewers):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
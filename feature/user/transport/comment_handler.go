@@ -0,0 +1,321 @@
+package transport
+
+import (
+	"errors"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type CommentHandler struct {
+	usecase usecase.CommentUsecaseInterface
+	admin   *AdminHandler
+}
+
+func NewCommentHandler(usecase usecase.CommentUsecaseInterface, admin *AdminHandler) *CommentHandler {
+	return &CommentHandler{usecase: usecase, admin: admin}
+}
+
+// GetReviewerThread godoc
+// @Summary Get request comments
+// @Description Comment thread of a request with internal and external comments, attachments and read receipts
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Security bearerToken
+// @Success 200 {object} dto.CommentThread{}
+// @Router /api/v1/admin/requests/{id}/comments [get]
+func (h *CommentHandler) GetReviewerThread(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	thread, err := h.usecase.GetReviewerThread(c.Request.Context(), id, c.GetInt("userId"), *scope)
+	if err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, thread)
+}
+
+// AddReviewerComment godoc
+// @Summary Comment on a request
+// @Description Post a comment, internal by default, external comments are visible to the requester. Send multipart/form-data to attach files
+// @Accept json,mpfd
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Param request body dto.CommentCreateDTO true "Comment"
+// @Param attachments formData file false "Attachments"
+// @Security bearerToken
+// @Success 201 {object} dto.CommentResponse{}
+// @Router /api/v1/admin/requests/{id}/comments [post]
+func (h *CommentHandler) AddReviewerComment(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	var req dto.CommentCreateDTO
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	files, closeFiles, err := attachmentUploads(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer closeFiles()
+	comment, err := h.usecase.AddReviewerComment(c.Request.Context(), id, c.GetInt("userId"), req, files, *scope)
+	if err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, comment)
+}
+
+// MarkReviewerThreadRead godoc
+// @Summary Mark request comments as read
+// @Description Record read receipts for every comment of the thread
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Security bearerToken
+// @Success 200 string message
+// @Router /api/v1/admin/requests/{id}/comments/read [post]
+func (h *CommentHandler) MarkReviewerThreadRead(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	if err := h.usecase.MarkReviewerThreadRead(c.Request.Context(), id, c.GetInt("userId"), *scope); err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Comments marked as read"})
+}
+
+// GetReviewerAttachment godoc
+// @Summary Download a comment attachment
+// @Produce octet-stream
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Security bearerToken
+// @Success 200 {file} file
+// @Router /api/v1/admin/requests/{id}/attachments/{attachmentId} [get]
+func (h *CommentHandler) GetReviewerAttachment(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, attachmentID, ok := attachmentParams(c)
+	if !ok {
+		return
+	}
+	download, err := h.usecase.OpenReviewerAttachment(c.Request.Context(), id, attachmentID, *scope)
+	if err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	sendAttachment(c, download)
+}
+
+// GetRequesterThread godoc
+// @Summary Get comments on my request
+// @Description External comments of a request owned by the logged in user
+// @Produce json
+// @Tags request
+// @Param id path int true "Request ID"
+// @Security bearerToken
+// @Success 200 {object} dto.CommentThread{}
+// @Router /api/v1/applicant-request/{id}/comments [get]
+func (h *CommentHandler) GetRequesterThread(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	thread, err := h.usecase.GetRequesterThread(c.Request.Context(), id, c.GetInt("userId"))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, thread)
+}
+
+// AddRequesterComment godoc
+// @Summary Comment on my request
+// @Description Reply to the reviewers of a request owned by the logged in user. Send multipart/form-data to attach files
+// @Accept json,mpfd
+// @Produce json
+// @Tags request
+// @Param id path int true "Request ID"
+// @Param request body dto.CommentCreateDTO true "Comment"
+// @Param attachments formData file false "Attachments"
+// @Security bearerToken
+// @Success 201 {object} dto.CommentResponse{}
+// @Router /api/v1/applicant-request/{id}/comments [post]
+func (h *CommentHandler) AddRequesterComment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	var req dto.CommentCreateDTO
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	files, closeFiles, err := attachmentUploads(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer closeFiles()
+	comment, err := h.usecase.AddRequesterComment(c.Request.Context(), id, c.GetInt("userId"), req, files)
+	if err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, comment)
+}
+
+// MarkRequesterThreadRead godoc
+// @Summary Mark comments on my request as read
+// @Produce json
+// @Tags request
+// @Param id path int true "Request ID"
+// @Security bearerToken
+// @Success 200 string message
+// @Router /api/v1/applicant-request/{id}/comments/read [post]
+func (h *CommentHandler) MarkRequesterThreadRead(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	if err := h.usecase.MarkRequesterThreadRead(c.Request.Context(), id, c.GetInt("userId")); err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Comments marked as read"})
+}
+
+// GetRequesterAttachment godoc
+// @Summary Download an attachment of my request
+// @Produce octet-stream
+// @Tags request
+// @Param id path int true "Request ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Security bearerToken
+// @Success 200 {file} file
+// @Router /api/v1/applicant-request/{id}/attachments/{attachmentId} [get]
+func (h *CommentHandler) GetRequesterAttachment(c *gin.Context) {
+	id, attachmentID, ok := attachmentParams(c)
+	if !ok {
+		return
+	}
+	download, err := h.usecase.OpenRequesterAttachment(c.Request.Context(), id, attachmentID, c.GetInt("userId"))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	sendAttachment(c, download)
+}
+
+// attachmentUploads opens the files of the "attachments" form field, JSON requests have none
+func attachmentUploads(c *gin.Context) ([]dto.AttachmentUpload, func(), error) {
+	var opened []multipart.File
+	closeFiles := func() {
+		for _, file := range opened {
+			file.Close()
+		}
+	}
+	form, err := c.MultipartForm()
+	if errors.Is(err, http.ErrNotMultipart) {
+		return nil, closeFiles, nil
+	}
+	if err != nil {
+		return nil, closeFiles, err
+	}
+	headers := form.File["attachments"]
+	uploads := make([]dto.AttachmentUpload, 0, len(headers))
+	for _, header := range headers {
+		file, err := header.Open()
+		if err != nil {
+			closeFiles()
+			return nil, func() {}, err
+		}
+		opened = append(opened, file)
+		uploads = append(uploads, dto.AttachmentUpload{
+			FileName:    header.Filename,
+			ContentType: header.Header.Get("Content-Type"),
+			Size:        header.Size,
+			Content:     file,
+		})
+	}
+	return uploads, closeFiles, nil
+}
+
+func attachmentParams(c *gin.Context) (int, int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return 0, 0, false
+	}
+	attachmentID, err := strconv.Atoi(c.Param("attachmentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return 0, 0, false
+	}
+	return id, attachmentID, true
+}
+
+// sendAttachment always downloads the file so uploaded content is never rendered by the browser
+func sendAttachment(c *gin.Context, download *dto.AttachmentDownload) {
+	defer download.Content.Close()
+	c.DataFromReader(http.StatusOK, download.Size, download.ContentType, download.Content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": download.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func commentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrCommentParentInvalid),
+		errors.Is(err, usecase.ErrTooManyAttachments):
+		return http.StatusBadRequest
+	case errors.Is(err, usecase.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,117 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/export"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	usecase usecase.ExportUsecaseInterface
+	admin   *AdminHandler
+}
+
+func NewExportHandler(usecase usecase.ExportUsecaseInterface, admin *AdminHandler) *ExportHandler {
+	return &ExportHandler{usecase: usecase, admin: admin}
+}
+
+// ExportRequests godoc
+// @Summary Export requests
+// @Description Stream the request list as CSV or XLSX
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Tags admin
+// @Param format query string false "csv (default) or xlsx"
+// @Param status query int false "0: pending, 1: approved, 2: rejected"
+// @Param type query string false "registration or verification"
+// @Param department_id query int false "Department ID of the requester"
+// @Security bearerToken
+// @Success 200 {file} file
+// @Router /api/v1/admin/export/requests [get]
+func (h *ExportHandler) ExportRequests(c *gin.Context) {
+	h.export(c, "requests", h.usecase.ExportRequests)
+}
+
+// ExportVolunteers godoc
+// @Summary Export volunteers
+// @Description Stream the volunteer directory as CSV or XLSX
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Tags admin
+// @Param format query string false "csv (default) or xlsx"
+// @Param status query int false "0: inactive, 1: active"
+// @Param department_id query int false "Department ID"
+// @Security bearerToken
+// @Success 200 {file} file
+// @Router /api/v1/admin/export/volunteers [get]
+func (h *ExportHandler) ExportVolunteers(c *gin.Context) {
+	h.export(c, "volunteers", h.usecase.ExportVolunteers)
+}
+
+// ExportApplicants godoc
+// @Summary Export applicants
+// @Description Stream the users who are not volunteers yet as CSV or XLSX
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Tags admin
+// @Param format query string false "csv (default) or xlsx"
+// @Param verification_status query int false "0: unverified, 1: verified"
+// @Param department_id query int false "Department ID"
+// @Security bearerToken
+// @Success 200 {file} file
+// @Router /api/v1/admin/export/applicants [get]
+func (h *ExportHandler) ExportApplicants(c *gin.Context) {
+	h.export(c, "applicants", h.usecase.ExportApplicants)
+}
+
+type exportFunc func(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, format string, w io.Writer) error
+
+func (h *ExportHandler) export(c *gin.Context, name string, run exportFunc) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	filter, err := parseExportFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	format := c.DefaultQuery("format", export.CSV)
+	export.Download(c, name, format, func(w io.Writer) error {
+		return run(c.Request.Context(), *scope, filter, format, w)
+	})
+}
+
+func parseExportFilter(c *gin.Context) (dto.ExportFilter, error) {
+	var filter dto.ExportFilter
+	var err error
+	if v := c.Query("status"); v != "" {
+		status, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, errors.New("invalid status")
+		}
+		filter.Status = &status
+	}
+	if v := c.Query("verification_status"); v != "" {
+		status, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, errors.New("invalid verification_status")
+		}
+		filter.VerificationStatus = &status
+	}
+	if v := c.Query("department_id"); v != "" {
+		if filter.DepartmentID, err = strconv.Atoi(v); err != nil {
+			return filter, errors.New("invalid department_id")
+		}
+	}
+	filter.Type = c.Query("type")
+	return filter, nil
+}
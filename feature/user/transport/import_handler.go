@@ -0,0 +1,70 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ImportHandler struct {
+	usecase usecase.ImportUsecaseInterface
+	admin   *AdminHandler
+}
+
+func NewImportHandler(usecase usecase.ImportUsecaseInterface, admin *AdminHandler) *ImportHandler {
+	return &ImportHandler{usecase: usecase, admin: admin}
+}
+
+// ImportVolunteers godoc
+// @Summary Bulk import volunteers
+// @Description Create verified volunteers from a CSV file (columns: email, name, surname, gender, dob, mobile, department, country, resident_country, password).
+// @Description Departments and countries are matched by name, every row is validated and nothing is created when a row is invalid.
+// @Description Rows with an empty password column get an invite token in the report once created, redeemed on /api/v1/auth/accept-invite.
+// @Accept multipart/form-data
+// @Produce json
+// @Tags admin
+// @Param file formData file true "CSV file"
+// @Param dry_run query bool false "Only validate the file"
+// @Security bearerToken
+// @Success 200 {object} dto.ImportReport{}
+// @Failure 422 {object} dto.ImportReport{}
+// @Router /api/v1/admin/import/volunteers [post]
+func (h *ImportHandler) ImportVolunteers(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	dryRun := false
+	if v := c.Query("dry_run"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid dry_run"})
+			return
+		}
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	report, err := h.usecase.ImportVolunteers(c.Request.Context(), *scope, file, dryRun)
+	if errors.Is(err, usecase.ErrImportHasErrors) {
+		c.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,302 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ReviewHandler struct {
+	usecase usecase.ReviewUsecaseInterface
+	admin   *AdminHandler
+}
+
+func NewReviewHandler(usecase usecase.ReviewUsecaseInterface, admin *AdminHandler) *ReviewHandler {
+	return &ReviewHandler{usecase: usecase, admin: admin}
+}
+
+// ListReasons godoc
+// @Summary List rejection reasons
+// @Description Rejection reason catalog, active reasons only unless all=true
+// @Produce json
+// @Tags admin
+// @Param all query bool false "Include inactive reasons"
+// @Security bearerToken
+// @Success 200 {array} dto.RejectionReasonResponseDTO{}
+// @Router /api/v1/admin/rejection-reasons [get]
+func (h *ReviewHandler) ListReasons(c *gin.Context) {
+	if _, err := h.admin.resolveScope(c); err != nil {
+		scopeError(c, err)
+		return
+	}
+	reasons, err := h.usecase.ListReasons(c.Request.Context(), c.Query("all") != "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reasons)
+}
+
+// CreateReason godoc
+// @Summary Create rejection reason
+// @Description Add a reason to the catalog, request_type limits it to one request type
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param request body dto.RejectionReasonCreateDTO true "Rejection Reason"
+// @Security bearerToken
+// @Success 201 {object} dto.RejectionReasonResponseDTO{}
+// @Router /api/v1/admin/rejection-reasons [post]
+func (h *ReviewHandler) CreateReason(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	var req dto.RejectionReasonCreateDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	reason, err := h.usecase.CreateReason(c.Request.Context(), req, *scope)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, reason)
+}
+
+// UpdateReason godoc
+// @Summary Update rejection reason
+// @Description Change the wording, request type or status of a catalog reason
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param id path int true "Rejection Reason ID"
+// @Param request body dto.RejectionReasonUpdateDTO true "Rejection Reason"
+// @Security bearerToken
+// @Success 200 {object} dto.RejectionReasonResponseDTO{}
+// @Router /api/v1/admin/rejection-reasons/{id} [put]
+func (h *ReviewHandler) UpdateReason(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rejection reason ID"})
+		return
+	}
+	var req dto.RejectionReasonUpdateDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	reason, err := h.usecase.UpdateReason(c.Request.Context(), id, req, *scope)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reason)
+}
+
+// ListChecklistItems godoc
+// @Summary List checklist items
+// @Description Reviewer checklist items, active items only unless all=true
+// @Produce json
+// @Tags admin
+// @Param type query string false "Request type (registration, verification)"
+// @Param all query bool false "Include inactive items"
+// @Security bearerToken
+// @Success 200 {array} dto.ChecklistItemResponseDTO{}
+// @Router /api/v1/admin/checklist-items [get]
+func (h *ReviewHandler) ListChecklistItems(c *gin.Context) {
+	if _, err := h.admin.resolveScope(c); err != nil {
+		scopeError(c, err)
+		return
+	}
+	items, err := h.usecase.ListChecklistItems(c.Request.Context(), c.Query("type"), c.Query("all") != "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// CreateChecklistItem godoc
+// @Summary Create checklist item
+// @Description Add an item reviewers must check before deciding requests of the given type
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param request body dto.ChecklistItemCreateDTO true "Checklist Item"
+// @Security bearerToken
+// @Success 201 {object} dto.ChecklistItemResponseDTO{}
+// @Router /api/v1/admin/checklist-items [post]
+func (h *ReviewHandler) CreateChecklistItem(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	var req dto.ChecklistItemCreateDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	item, err := h.usecase.CreateChecklistItem(c.Request.Context(), req, *scope)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, item)
+}
+
+// UpdateChecklistItem godoc
+// @Summary Update checklist item
+// @Description Change the label, position or status of a checklist item
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param id path int true "Checklist Item ID"
+// @Param request body dto.ChecklistItemUpdateDTO true "Checklist Item"
+// @Security bearerToken
+// @Success 200 {object} dto.ChecklistItemResponseDTO{}
+// @Router /api/v1/admin/checklist-items/{id} [put]
+func (h *ReviewHandler) UpdateChecklistItem(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid checklist item ID"})
+		return
+	}
+	var req dto.ChecklistItemUpdateDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	item, err := h.usecase.UpdateChecklistItem(c.Request.Context(), id, req, *scope)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// GetRequestReview godoc
+// @Summary Get request review
+// @Description Checklist progress of a request and the rejection reasons given
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Security bearerToken
+// @Success 200 {object} dto.RequestReview{}
+// @Router /api/v1/admin/requests/{id}/review [get]
+func (h *ReviewHandler) GetRequestReview(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	review, err := h.usecase.GetRequestReview(c.Request.Context(), id, *scope)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// CheckItem godoc
+// @Summary Check a checklist item
+// @Description Tick or untick a checklist item of a pending request
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param id path int true "Request ID"
+// @Param itemId path int true "Checklist Item ID"
+// @Param request body dto.ChecklistCheckDTO true "Checklist Check"
+// @Security bearerToken
+// @Success 200 {object} dto.RequestReview{}
+// @Router /api/v1/admin/requests/{id}/checklist/{itemId} [put]
+func (h *ReviewHandler) CheckItem(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+	itemID, err := strconv.Atoi(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid checklist item ID"})
+		return
+	}
+	var req dto.ChecklistCheckDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	review, err := h.usecase.CheckItem(c.Request.Context(), id, itemID, *req.Checked, c.GetInt("userId"), *scope)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// GetMyRequests godoc
+// @Summary Get my requests
+// @Description Status of the registration and verification requests of the logged in user, with the reasons of a rejection
+// @Produce json
+// @Tags request
+// @Security bearerToken
+// @Success 200 {array} dto.ApplicantRequestStatus{}
+// @Router /api/v1/applicant-request/mine [get]
+func (h *ReviewHandler) GetMyRequests(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	requests, err := h.usecase.GetApplicantRequests(c.Request.Context(), userId.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+func reviewErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrReviewCatalogForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, storage.ErrRequestClaimed),
+		errors.Is(err, storage.ErrRequestNotPending):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrChecklistItemInvalid):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type SLAHandler struct {
+	usecase usecase.SLAUsecaseInterface
+	admin   *AdminHandler
+}
+
+func NewSLAHandler(usecase usecase.SLAUsecaseInterface, admin *AdminHandler) *SLAHandler {
+	return &SLAHandler{usecase: usecase, admin: admin}
+}
+
+// ListSLAs godoc
+// @Summary List request SLAs
+// @Description How long pending requests of each type may wait for a decision and when their reviewer is reminded
+// @Produce json
+// @Tags admin
+// @Security bearerToken
+// @Success 200 {array} dto.RequestSLAResponseDTO{}
+// @Router /api/v1/admin/request-slas [get]
+func (h *SLAHandler) ListSLAs(c *gin.Context) {
+	if _, err := h.admin.resolveScope(c); err != nil {
+		scopeError(c, err)
+		return
+	}
+	slas, err := h.usecase.ListSLAs(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, slas)
+}
+
+// UpdateSLA godoc
+// @Summary Set request SLA
+// @Description Set the SLA of a request type, reminder_hours before the deadline the assigned reviewer is reminded, 0 for no reminder
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param type path string true "Request type (registration, verification)"
+// @Param request body dto.RequestSLAUpdateDTO true "Request SLA"
+// @Security bearerToken
+// @Success 200 {object} dto.RequestSLAResponseDTO{}
+// @Router /api/v1/admin/request-slas/{type} [put]
+func (h *SLAHandler) UpdateSLA(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	var req dto.RequestSLAUpdateDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sla, err := h.usecase.UpdateSLA(c.Request.Context(), c.Param("type"), req, *scope)
+	if err != nil {
+		c.JSON(slaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, sla)
+}
+
+// DeleteSLA godoc
+// @Summary Delete request SLA
+// @Description Remove the SLA of a request type, its requests are no longer reminded nor escalated
+// @Produce json
+// @Tags admin
+// @Param type path string true "Request type (registration, verification)"
+// @Security bearerToken
+// @Success 200 {object} map[string]string
+// @Router /api/v1/admin/request-slas/{type} [delete]
+func (h *SLAHandler) DeleteSLA(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	if err := h.usecase.DeleteSLA(c.Request.Context(), c.Param("type"), *scope); err != nil {
+		c.JSON(slaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Request SLA deleted successfully"})
+}
+
+func slaErrorStatus(err error) int {
+	if errors.Is(err, usecase.ErrRequestTypeInvalid) {
+		return http.StatusBadRequest
+	}
+	return reviewErrorStatus(err)
+}
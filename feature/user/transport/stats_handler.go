@@ -0,0 +1,73 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type StatsHandler struct {
+	usecase usecase.StatsUsecaseInterface
+	admin   *AdminHandler
+}
+
+func NewStatsHandler(usecase usecase.StatsUsecaseInterface, admin *AdminHandler) *StatsHandler {
+	return &StatsHandler{usecase: usecase, admin: admin}
+}
+
+// GetStats godoc
+// @Summary Get admin dashboard statistics
+// @Description Counts of the requests submitted in the window by type and status, median time-to-decision, registrations per day and week, active volunteers per department and country, reviewer throughput
+// @Produce json
+// @Tags admin
+// @Param from query string false "From date (YYYY-MM-DD), defaults to 30 days before to"
+// @Param to query string false "To date inclusive (YYYY-MM-DD), defaults to now"
+// @Security bearerToken
+// @Success 200 {object} dto.AdminStats{}
+// @Router /api/v1/admin/stats [get]
+func (h *StatsHandler) GetStats(c *gin.Context) {
+	scope, err := h.admin.resolveScope(c)
+	if err != nil {
+		scopeError(c, err)
+		return
+	}
+	filter, err := parseStatsFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	stats, err := h.usecase.GetStats(c.Request.Context(), *scope, filter)
+	if errors.Is(err, usecase.ErrStatsRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// parseStatsFilter reads the from/to query dates, to is inclusive so it is moved to the next midnight
+func parseStatsFilter(c *gin.Context) (dto.StatsFilter, error) {
+	var filter dto.StatsFilter
+	if v := c.Query("from"); v != "" {
+		from, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return filter, errors.New("invalid from date")
+		}
+		filter.From = from
+	}
+	if v := c.Query("to"); v != "" {
+		to, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return filter, errors.New("invalid to date")
+		}
+		filter.To = to.AddDate(0, 0, 1)
+	}
+	return filter, nil
+}
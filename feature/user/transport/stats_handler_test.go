@@ -0,0 +1,30 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStats_InvalidRange(t *testing.T) {
+	admin := NewAuthenticationHandler(usecase.NewAdminUsecase(nil, nil, nil))
+	handler := NewStatsHandler(usecase.NewStatsUsecase(nil), admin)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/admin/stats", func(c *gin.Context) {
+		c.Set("userId", 1)
+		c.Set("roleId", 1)
+	}, handler.GetStats)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/admin/stats?from=2024-06-10&to=2024-06-01", nil)
+	assert.NoError(t, err)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -32,7 +32,7 @@ func (h *VolunteerRequestHandler) CreateVolunteerRequest(c *gin.Context) {
 		return
 	}
 
-	if err := h.VolRequestUsecase.CreateVolunteerRequest(request); err != nil {
+	if err := h.VolRequestUsecase.CreateVolunteerRequest(c.Request.Context(), request); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
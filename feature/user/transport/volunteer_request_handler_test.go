@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -16,7 +17,7 @@ type MockVolunteerRequestUsecase struct {
 	mock.Mock
 }
 
-func (m *MockVolunteerRequestUsecase) CreateVolunteerRequest(input dto.VoluteerRequestCreatingDTO) error {
+func (m *MockVolunteerRequestUsecase) CreateVolunteerRequest(ctx context.Context, input dto.RequestCreatingDTO) error {
 	args := m.Called(input)
 	return args.Error(0)
 }
@@ -30,14 +31,14 @@ func TestCreateVolunteerRequest(t *testing.T)  {
 	r.POST("/api/v1/volunteer-request", handler.CreateVolunteerRequest)
 
 	t.Run("success", func(t *testing.T) {
-		mockInput := dto.VoluteerRequestCreatingDTO{
-			UserID: 1,
-			Type:   "Verification",
-			Status: 0,
+		departmentID := 2
+		mockInput := dto.RequestCreatingDTO{
+			UserID:       1,
+			DepartmentID: &departmentID,
 		}
 		mockUsecase.On("CreateVolunteerRequest", mockInput).Return(nil)
 
-		body := `{"user_id":1,"type":"Verification","status":"Pending"}`
+		body := `{"user_id":1,"department_id":2}`
 		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer-request", strings.NewReader(body))
 		assert.NoError(t, err)
 		req.Header.Set("Content-Type", "application/json")
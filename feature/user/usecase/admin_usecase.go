@@ -1,51 +1,113 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
 )
 
 type AdminUsecaseInterface interface {
-	GetListPendingRequest() (*dto.ListRequest, string)
-	GetPendingRequestById(id int) (*dto.RequestResponse, string)
-	GetListRequest() (*dto.ListRequest, string)
-	GetRequestById(id int) (*dto.RequestResponse, string)
-	ApproveRequest(id int, verifier_id int) string
-	RejectRequest(id int, verifier_id int) string
-	AddRejectNotes(id int, notes string) string
-	DeleteRequest(id int) string
+	ResolveScope(ctx context.Context, userID int, roleID int) (*dto.AdminScope, error)
+	GetListPendingRequest(ctx context.Context, scope dto.AdminScope) (*dto.ListPendingRequest, string)
+	GetPendingRequestById(ctx context.Context, id int, scope dto.AdminScope) (*dto.RequestResponse, string)
+	GetListRequest(ctx context.Context, scope dto.AdminScope) (*dto.ListRequest, string)
+	GetRequestById(ctx context.Context, id int, scope dto.AdminScope) (*dto.RequestResponse, string)
+	GetListVolunteer(ctx context.Context, scope dto.AdminScope) (*dto.ListVolunteer, string)
+	ApproveRequest(ctx context.Context, id int, verifier_id int, scope dto.AdminScope) string
+	RejectRequest(ctx context.Context, id int, verifier_id int, scope dto.AdminScope) string
+	RejectRequestWithReasons(ctx context.Context, id int, verifier_id int, input dto.RejectRequestDTO, scope dto.AdminScope) string
+	AddRejectNotes(ctx context.Context, id int, notes string, scope dto.AdminScope) string
+	BulkDecide(ctx context.Context, input dto.BulkDecisionRequest, verifier_id int, scope dto.AdminScope) *dto.BulkDecisionResponse
+	DeleteRequest(ctx context.Context, id int, scope dto.AdminScope) string
+}
+
+// ErrForbidden is returned by ResolveScope for a user who is neither an admin nor a department manager
+var ErrForbidden = errors.New("forbidden: only admins can perform this action")
+
+// DepartmentScopeResolver returns the departments a user manages, sub-departments included
+type DepartmentScopeResolver interface {
+	ManagedDepartmentIDs(ctx context.Context, userID int) ([]int, error)
 }
 
 type AdminUsecase struct {
-	repo storage.AdminRepositoryInterface
+	repo      storage.AdminRepositoryInterface
+	deptScope DepartmentScopeResolver
+	store     storage.AttachmentStore
 }
 
-func NewAdminUsecase(repo storage.AdminRepositoryInterface) *AdminUsecase {
-	return &AdminUsecase{repo: repo}
+// NewAdminUsecase creates the admin usecase, store holds the comment attachments removed with their request
+func NewAdminUsecase(repo storage.AdminRepositoryInterface, deptScope DepartmentScopeResolver, store storage.AttachmentStore) *AdminUsecase {
+	return &AdminUsecase{repo: repo, deptScope: deptScope, store: store}
 }
-func (u *AdminUsecase) GetListPendingRequest() (*dto.ListRequest, string) {
-	requests, msg := u.repo.GetListPendingRequest()
-	if requests != nil {
-		return &dto.ListRequest{
-			Requests: requests,
-		}, msg
-	} else {
-		msg = "No request found"
+
+// ResolveScope gives admins (role 1) access to everything and department managers
+// access to their department subtree, anyone else is forbidden
+func (u *AdminUsecase) ResolveScope(ctx context.Context, userID int, roleID int) (*dto.AdminScope, error) {
+	if roleID == 1 {
+		return &dto.AdminScope{All: true}, nil
 	}
-	return nil, msg
+	departmentIDs, err := u.deptScope.ManagedDepartmentIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(departmentIDs) == 0 {
+		return nil, ErrForbidden
+	}
+	return &dto.AdminScope{DepartmentIDs: departmentIDs}, nil
+}
+
+// GetListPendingRequest returns the pending requests with where each one stands against the SLA of its type
+func (u *AdminUsecase) GetListPendingRequest(ctx context.Context, scope dto.AdminScope) (*dto.ListPendingRequest, string) {
+	requests, msg := u.repo.GetListPendingRequest(ctx, scope)
+	if requests == nil {
+		return nil, msg
+	}
+	slas, err := u.repo.ListRequestSLAs(ctx)
+	if err != nil {
+		return nil, err.Error()
+	}
+	byType := make(map[string]domain.RequestSLA, len(slas))
+	for _, sla := range slas {
+		byType[sla.RequestType] = sla
+	}
+	now := time.Now()
+	list := &dto.ListPendingRequest{Requests: make([]dto.PendingRequestSLA, 0, len(requests))}
+	for _, request := range requests {
+		item := dto.PendingRequestSLA{Request: request}
+		if sla, ok := byType[strings.TrimSpace(request.Type)]; ok {
+			dueAt := sla.DueAt(request.CreatedAt)
+			item.SLADueAt = &dueAt
+			item.SLAStatus = sla.State(request.CreatedAt, now)
+			if item.SLAStatus == domain.SLABreached {
+				item.SLABreachedFor = int64(now.Sub(dueAt).Seconds())
+			}
+		}
+		list.Requests = append(list.Requests, item)
+	}
+	return list, msg
 }
-func (u *AdminUsecase) GetPendingRequestById(id int) (*dto.RequestResponse, string) {
-	request, msg := u.repo.GetPendingRequestByID(id)
+func (u *AdminUsecase) GetPendingRequestById(ctx context.Context, id int, scope dto.AdminScope) (*dto.RequestResponse, string) {
+	request, msg := u.repo.GetPendingRequestByID(ctx, id, scope)
 	if request != nil {
 		return &dto.RequestResponse{
-			ID:          request.ID,
-			UserID:      request.UserID,
-			Type:        request.Type,
-			Status:      request.Status,
-			RejectNotes: request.RejectNotes,
-			VerifierID:  request.VerifierID,
-			CreateAt:    request.CreatedAt,
-			UpdateAt:    request.UpdatedAt,
+			ID:             request.ID,
+			UserID:         request.UserID,
+			Type:           request.Type,
+			Status:         request.Status,
+			RejectNotes:    request.RejectNotes,
+			VerifierID:     request.VerifierID,
+			ClaimedBy:      request.ClaimedBy(time.Now()),
+			ClaimExpiresAt: request.ClaimExpiresAt,
+			ViewedAt:       request.ViewedAt,
+			ViewedBy:       request.ViewedBy,
+			CreateAt:       request.CreatedAt,
+			UpdateAt:       request.UpdatedAt,
 		}, msg
 	} else {
 		msg = "Request not found"
@@ -53,8 +115,8 @@ func (u *AdminUsecase) GetPendingRequestById(id int) (*dto.RequestResponse, stri
 	return nil, msg
 }
 
-func (u *AdminUsecase) GetListRequest() (*dto.ListRequest, string) {
-	requests, msg := u.repo.GetListAllRequest()
+func (u *AdminUsecase) GetListRequest(ctx context.Context, scope dto.AdminScope) (*dto.ListRequest, string) {
+	requests, msg := u.repo.GetListAllRequest(ctx, scope)
 	if requests != nil {
 		return &dto.ListRequest{
 			Requests: requests,
@@ -64,18 +126,22 @@ func (u *AdminUsecase) GetListRequest() (*dto.ListRequest, string) {
 	}
 	return nil, msg
 }
-func (u *AdminUsecase) GetRequestById(id int) (*dto.RequestResponse, string) {
-	request, msg := u.repo.GetRequestByID(id)
+func (u *AdminUsecase) GetRequestById(ctx context.Context, id int, scope dto.AdminScope) (*dto.RequestResponse, string) {
+	request, msg := u.repo.GetRequestByID(ctx, id, scope)
 	if request != nil {
 		return &dto.RequestResponse{
-			ID:          request.ID,
-			UserID:      request.UserID,
-			Type:        request.Type,
-			Status:      request.Status,
-			RejectNotes: request.RejectNotes,
-			VerifierID:  request.VerifierID,
-			CreateAt:    request.CreatedAt,
-			UpdateAt:    request.UpdatedAt,
+			ID:             request.ID,
+			UserID:         request.UserID,
+			Type:           request.Type,
+			Status:         request.Status,
+			RejectNotes:    request.RejectNotes,
+			VerifierID:     request.VerifierID,
+			ClaimedBy:      request.ClaimedBy(time.Now()),
+			ClaimExpiresAt: request.ClaimExpiresAt,
+			ViewedAt:       request.ViewedAt,
+			ViewedBy:       request.ViewedBy,
+			CreateAt:       request.CreatedAt,
+			UpdateAt:       request.UpdatedAt,
 		}, msg
 	} else {
 		msg = "Request not found"
@@ -83,15 +149,107 @@ func (u *AdminUsecase) GetRequestById(id int) (*dto.RequestResponse, string) {
 	return nil, msg
 }
 
-func (u *AdminUsecase) ApproveRequest(id int, verifier_id int) string {
-	return u.repo.ApproveRequest(id, verifier_id)
+func (u *AdminUsecase) GetListVolunteer(ctx context.Context, scope dto.AdminScope) (*dto.ListVolunteer, string) {
+	volunteers, msg := u.repo.GetListVolunteer(ctx, scope)
+	if volunteers != nil {
+		return &dto.ListVolunteer{
+			Volunteers: volunteers,
+		}, msg
+	}
+	return nil, msg
+}
+
+func (u *AdminUsecase) ApproveRequest(ctx context.Context, id int, verifier_id int, scope dto.AdminScope) string {
+	if !u.inScope(ctx, id, scope) {
+		return "Request not found"
+	}
+	return u.repo.ApproveRequest(ctx, id, verifier_id)
 }
-func (u *AdminUsecase) RejectRequest(id int, verifier_id int) string {
-	return u.repo.RejectRequest(id, verifier_id)
+func (u *AdminUsecase) RejectRequest(ctx context.Context, id int, verifier_id int, scope dto.AdminScope) string {
+	if !u.inScope(ctx, id, scope) {
+		return "Request not found"
+	}
+	return u.repo.RejectRequest(ctx, id, verifier_id)
 }
-func (u *AdminUsecase) AddRejectNotes(id int, notes string) string {
-	return u.repo.AddRejectNotes(id, notes)
+func (u *AdminUsecase) RejectRequestWithReasons(ctx context.Context, id int, verifier_id int, input dto.RejectRequestDTO, scope dto.AdminScope) string {
+	if !u.inScope(ctx, id, scope) {
+		return "Request not found"
+	}
+	return u.repo.RejectRequestWithReasons(ctx, id, verifier_id, rejectionReasons(input.Reasons), input.Notes)
+}
+func (u *AdminUsecase) AddRejectNotes(ctx context.Context, id int, notes string, scope dto.AdminScope) string {
+	if !u.inScope(ctx, id, scope) {
+		return "Request not found"
+	}
+	return u.repo.AddRejectNotes(ctx, id, notes)
 }
-func (u *AdminUsecase) DeleteRequest(id int) string {
-	return u.repo.DeleteRequest(id)
+
+// BulkDecide approves or rejects every request in input.IDs, each request is decided
+// in its own transaction so one failure does not roll back the others
+func (u *AdminUsecase) BulkDecide(ctx context.Context, input dto.BulkDecisionRequest, verifier_id int, scope dto.AdminScope) *dto.BulkDecisionResponse {
+	response := &dto.BulkDecisionResponse{Action: input.Action, Results: []dto.BulkDecisionResult{}}
+	seen := make(map[int]bool, len(input.IDs))
+	for _, id := range input.IDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		var msg string
+		var success bool
+		switch {
+		case !u.inScope(ctx, id, scope):
+			msg = "Request not found"
+		case input.Action == "approve":
+			msg = u.repo.ApproveRequest(ctx, id, verifier_id)
+			success = msg == storage.ApproveRequestSuccess
+		default:
+			msg = u.repo.RejectRequestWithReasons(ctx, id, verifier_id, rejectionReasons(input.Reasons), input.Notes)
+			success = msg == storage.RejectRequestSuccess
+		}
+		if success {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+		response.Results = append(response.Results, dto.BulkDecisionResult{ID: id, Success: success, Message: msg})
+	}
+	return response
+}
+func (u *AdminUsecase) DeleteRequest(ctx context.Context, id int, scope dto.AdminScope) string {
+	if !u.inScope(ctx, id, scope) {
+		return "Request not found"
+	}
+	attachments, msg := u.repo.DeleteRequest(ctx, id)
+	for _, attachment := range attachments {
+		if err := u.store.Remove(attachment.StoragePath); err != nil {
+			slog.ErrorContext(ctx, "cannot remove attachment", "request", id, "path", attachment.StoragePath, "error", err)
+		}
+	}
+	return msg
+}
+
+// inScope hides requests outside the admin's departments as if they did not exist
+func (u *AdminUsecase) inScope(ctx context.Context, id int, scope dto.AdminScope) bool {
+	if scope.All {
+		return true
+	}
+	request, _ := u.repo.GetRequestByID(ctx, id, scope)
+	return request != nil
+}
+
+// rejectionReasons builds fresh rows for one rejected request
+func rejectionReasons(inputs []dto.RejectionReasonInput) []domain.RequestRejectionReason {
+	reasons := make([]domain.RequestRejectionReason, 0, len(inputs))
+	for _, input := range inputs {
+		reason := domain.RequestRejectionReason{RejectionReasonID: input.ReasonID}
+		if field := strings.TrimSpace(input.Field); field != "" {
+			reason.Field = &field
+		}
+		if comment := strings.TrimSpace(input.Comment); comment != "" {
+			reason.Comment = &comment
+		}
+		reasons = append(reasons, reason)
+	}
+	return reasons
 }
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *mockAdminRepository) GetListPendingRequest(ctx context.Context, scope dto.AdminScope) ([]*domain.Request, string) {
+	args := m.Called(scope)
+	requests, _ := args.Get(0).([]*domain.Request)
+	return requests, args.String(1)
+}
+
+func (m *mockAdminRepository) ListRequestSLAs(ctx context.Context) ([]domain.RequestSLA, error) {
+	args := m.Called()
+	slas, _ := args.Get(0).([]domain.RequestSLA)
+	return slas, args.Error(1)
+}
+
+func (m *mockAdminRepository) GetPendingRequestByID(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, string) {
+	args := m.Called(id, scope)
+	request, _ := args.Get(0).(*domain.Request)
+	return request, args.String(1)
+}
+
+func (m *mockAdminRepository) GetListAllRequest(ctx context.Context, scope dto.AdminScope) ([]*domain.Request, string) {
+	args := m.Called(scope)
+	requests, _ := args.Get(0).([]*domain.Request)
+	return requests, args.String(1)
+}
+
+func (m *mockAdminRepository) GetRequestByID(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, string) {
+	args := m.Called(id, scope)
+	request, _ := args.Get(0).(*domain.Request)
+	return request, args.String(1)
+}
+
+func (m *mockAdminRepository) GetListVolunteer(ctx context.Context, scope dto.AdminScope) ([]*domain.VolunteerDetail, string) {
+	args := m.Called(scope)
+	volunteers, _ := args.Get(0).([]*domain.VolunteerDetail)
+	return volunteers, args.String(1)
+}
+
+func (m *mockAdminRepository) ApproveRequest(ctx context.Context, id int, verifier_id int) string {
+	return m.Called(id, verifier_id).String(0)
+}
+
+func (m *mockAdminRepository) RejectRequest(ctx context.Context, id int, verifier_id int) string {
+	return m.Called(id, verifier_id).String(0)
+}
+
+func (m *mockAdminRepository) AddRejectNotes(ctx context.Context, id int, notes string) string {
+	return m.Called(id, notes).String(0)
+}
+
+func (m *mockAdminRepository) RejectRequestWithReasons(ctx context.Context, id int, verifier_id int, reasons []domain.RequestRejectionReason, notes string) string {
+	return m.Called(id, verifier_id, reasons, notes).String(0)
+}
+
+func (m *mockAdminRepository) DeleteRequest(ctx context.Context, id int) ([]domain.CommentAttachment, string) {
+	args := m.Called(id)
+	attachments, _ := args.Get(0).([]domain.CommentAttachment)
+	return attachments, args.String(1)
+}
+
+func TestBulkDecide_Approve(t *testing.T) {
+	repo := new(mockAdminRepository)
+	usecase := NewAdminUsecase(repo, nil, nil)
+	scope := dto.AdminScope{All: true}
+
+	repo.On("ApproveRequest", 1, 9).Return(storage.ApproveRequestSuccess)
+	repo.On("ApproveRequest", 2, 9).Return("Request already processed")
+
+	response := usecase.BulkDecide(context.Background(), dto.BulkDecisionRequest{IDs: []int{1, 2, 1}, Action: "approve"}, 9, scope)
+
+	assert.Equal(t, 1, response.Succeeded)
+	assert.Equal(t, 1, response.Failed)
+	assert.Equal(t, []dto.BulkDecisionResult{
+		{ID: 1, Success: true, Message: storage.ApproveRequestSuccess},
+		{ID: 2, Success: false, Message: "Request already processed"},
+	}, response.Results)
+	repo.AssertNumberOfCalls(t, "ApproveRequest", 2)
+}
+
+func TestBulkDecide_RejectOutOfScope(t *testing.T) {
+	repo := new(mockAdminRepository)
+	usecase := NewAdminUsecase(repo, nil, nil)
+	scope := dto.AdminScope{DepartmentIDs: []int{3}}
+
+	repo.On("GetRequestByID", 1, scope).Return(&domain.Request{ID: 1}, "")
+	repo.On("GetRequestByID", 2, scope).Return(nil, "record not found")
+	field := "dob"
+	repo.On("RejectRequestWithReasons", 1, 9, []domain.RequestRejectionReason{{RejectionReasonID: 2, Field: &field}}, "incomplete profile").
+		Return(storage.RejectRequestSuccess)
+
+	response := usecase.BulkDecide(context.Background(), dto.BulkDecisionRequest{
+		IDs:     []int{1, 2},
+		Action:  "reject",
+		Reasons: []dto.RejectionReasonInput{{ReasonID: 2, Field: " dob "}},
+		Notes:   "incomplete profile",
+	}, 9, scope)
+
+	assert.Equal(t, 1, response.Succeeded)
+	assert.Equal(t, 1, response.Failed)
+	assert.Equal(t, "Request not found", response.Results[1].Message)
+	repo.AssertNumberOfCalls(t, "RejectRequestWithReasons", 1)
+	repo.AssertExpectations(t)
+}
+
+func TestGetListPendingRequest_SLA(t *testing.T) {
+	repo := new(mockAdminRepository)
+	usecase := NewAdminUsecase(repo, nil, nil)
+	scope := dto.AdminScope{All: true}
+	now := time.Now()
+
+	repo.On("GetListPendingRequest", scope).Return([]*domain.Request{
+		{ID: 1, Type: "registration", CreatedAt: now.Add(-50 * time.Hour)},
+		{ID: 2, Type: "registration", CreatedAt: now.Add(-42 * time.Hour)},
+		{ID: 3, Type: "verification", CreatedAt: now.Add(-time.Hour)},
+		{ID: 4, Type: "registration", CreatedAt: now.Add(-time.Hour)},
+	}, "")
+	repo.On("ListRequestSLAs").Return([]domain.RequestSLA{{RequestType: "registration", TargetHours: 48, ReminderHours: 8}}, nil)
+
+	list, msg := usecase.GetListPendingRequest(context.Background(), scope)
+
+	assert.Empty(t, msg)
+	assert.Len(t, list.Requests, 4)
+	assert.Equal(t, domain.SLABreached, list.Requests[0].SLAStatus)
+	assert.InDelta(t, (2 * time.Hour).Seconds(), list.Requests[0].SLABreachedFor, 5)
+	assert.Equal(t, domain.SLADueSoon, list.Requests[1].SLAStatus)
+	assert.Zero(t, list.Requests[1].SLABreachedFor)
+	// verification has no SLA
+	assert.Nil(t, list.Requests[2].SLADueAt)
+	assert.Empty(t, list.Requests[2].SLAStatus)
+	assert.Equal(t, domain.SLAOnTrack, list.Requests[3].SLAStatus)
+	assert.Equal(t, now.Add(47*time.Hour), *list.Requests[3].SLADueAt)
+}
+
+func TestGetListPendingRequest_KeepsRepositoryMessage(t *testing.T) {
+	repo := new(mockAdminRepository)
+	usecase := NewAdminUsecase(repo, nil, nil)
+	scope := dto.AdminScope{All: true}
+	repo.On("GetListPendingRequest", scope).Return(nil, "connection refused")
+
+	list, msg := usecase.GetListPendingRequest(context.Background(), scope)
+
+	assert.Nil(t, list)
+	assert.Equal(t, "connection refused", msg)
+	repo.AssertNotCalled(t, "ListRequestSLAs")
+}
+
+func TestDeleteRequest_RemovesAttachmentFiles(t *testing.T) {
+	repo := new(mockAdminRepository)
+	store := new(mockAttachmentStore)
+	usecase := NewAdminUsecase(repo, nil, store)
+
+	repo.On("DeleteRequest", 7).Return([]domain.CommentAttachment{{ID: 1, StoragePath: "a.pdf"}, {ID: 2, StoragePath: "b.png"}}, "Delete request success")
+	store.On("Remove", "a.pdf").Return(errors.New("file missing"))
+	store.On("Remove", "b.png").Return(nil)
+
+	msg := usecase.DeleteRequest(context.Background(), 7, dto.AdminScope{All: true})
+
+	assert.Equal(t, "Delete request success", msg)
+	store.AssertExpectations(t)
+}
+
+func TestDeleteRequest_OutOfScope(t *testing.T) {
+	repo := new(mockAdminRepository)
+	usecase := NewAdminUsecase(repo, nil, new(mockAttachmentStore))
+	scope := dto.AdminScope{DepartmentIDs: []int{3}}
+
+	repo.On("GetRequestByID", 7, scope).Return(nil, "Request not found")
+
+	msg := usecase.DeleteRequest(context.Background(), 7, scope)
+
+	assert.Equal(t, "Request not found", msg)
+	repo.AssertNotCalled(t, "DeleteRequest", mock.Anything)
+}
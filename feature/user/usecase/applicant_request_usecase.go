@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
@@ -9,7 +10,7 @@ import (
 )
 
 type ApplicantRequestUsecaseInterface interface {
-	CreateApplicantRequest(request dto.RequestCreatingDTO) error
+	CreateApplicantRequest(ctx context.Context, request dto.RequestCreatingDTO) error
 }
 
 type ApplicantRequestUsecase struct {
@@ -20,7 +21,7 @@ func NewApplicantRequestUsecase(requestRepo storage.ApplicantRequestRepositoryIn
 	return &ApplicantRequestUsecase{RequestRepo: requestRepo}
 }
 
-func (u *ApplicantRequestUsecase) CreateApplicantRequest(request dto.RequestCreatingDTO) error {
+func (u *ApplicantRequestUsecase) CreateApplicantRequest(ctx context.Context, request dto.RequestCreatingDTO) error {
 	err := ValidateInput(request)
 	if err != nil {
 		return err
@@ -46,7 +47,7 @@ func (u *ApplicantRequestUsecase) CreateApplicantRequest(request dto.RequestCrea
 		ResidentCountryID: request.ResidentCountryID,
 		RoleID:            &roleID,
 	}
-	return u.RequestRepo.CreateApplicantRequest(reqRequest, reqUser)
+	return u.RequestRepo.CreateApplicantRequest(ctx, reqRequest, reqUser)
 }
 
 // StringToTimePtr Convert string to *time.Time
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"testing"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
@@ -13,25 +14,46 @@ type mockApplicantRequestRepository struct {
 	mock.Mock
 }
 
-func (m *mockApplicantRequestRepository) CreateApplicantRequest(appliRequest *domain.ApplicantRequestDomain) error {
-	args := m.Called(appliRequest)
+func (m *mockApplicantRequestRepository) CreateApplicantRequest(ctx context.Context, reqRequest *domain.Request, reqUser *domain.User) error {
+	args := m.Called(reqRequest, reqUser)
 	return args.Error(0)
 }
 
 func TestCreateApplicantRequest(t *testing.T) {
 	mockRepo := new(mockApplicantRequestRepository)
 	usecase := NewApplicantRequestUsecase(mockRepo)
-	
-	input := dto.ApplicantRequestCreatingDTO{
-		UserID: 1,
-		Type:   "application",
-		Status: 0,
+
+	gender := "Female"
+	mobile := "0912345678"
+	departmentID := 2
+	input := dto.RequestCreatingDTO{
+		UserID:       1,
+		DepartmentID: &departmentID,
+		Gender:       &gender,
+		DOB:          "2000-01-02",
+		Mobile:       &mobile,
 	}
 
-	mockRepo.On("CreateApplicantRequest", mock.Anything).Return(nil)
+	mockRepo.On("CreateApplicantRequest", mock.MatchedBy(func(r *domain.Request) bool {
+		return r.UserID == 1 && r.Type == "registration" && r.Status == 0
+	}), mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == 1 && *u.RoleID == 1 && u.Dob.Format("2006-01-02") == "2000-01-02"
+	})).Return(nil)
 
-	err := usecase.CreateApplicantRequest(input)
+	err := usecase.CreateApplicantRequest(context.Background(), input)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestCreateApplicantRequest_InvalidInput(t *testing.T) {
+	mockRepo := new(mockApplicantRequestRepository)
+	usecase := NewApplicantRequestUsecase(mockRepo)
+
+	gender := "Female"
+	mobile := "12345"
+	err := usecase.CreateApplicantRequest(context.Background(), dto.RequestCreatingDTO{UserID: 1, Gender: &gender, Mobile: &mobile})
+
+	assert.EqualError(t, err, "invalid mobile number")
+	mockRepo.AssertNotCalled(t, "CreateApplicantRequest", mock.Anything, mock.Anything)
+}
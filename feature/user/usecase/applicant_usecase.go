@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
@@ -8,10 +9,10 @@ import (
 )
 
 type ApplicantUsecaseInterface interface {
-	CreateApplicant(request dto.ApplicantCreateDTO) error
-	UpdateApplicant(id int, request dto.AppplicantUpdateDTO) error
-	DeleteApplicant(id int) error
-	FindApplicantByID(id int) (*dto.ApplicantResponseDTO, error)
+	CreateApplicant(ctx context.Context, request dto.ApplicantCreateDTO) error
+	UpdateApplicant(ctx context.Context, id int, request dto.AppplicantUpdateDTO) error
+	DeleteApplicant(ctx context.Context, id int) error
+	FindApplicantByID(ctx context.Context, id int) (*dto.ApplicantResponseDTO, error)
 }
 
 type ApplicantUsecase struct {
@@ -22,17 +23,17 @@ func NewApplicantUsecase(userRepo storage.ApplicantRepositoryInterface) *Applica
 	return &ApplicantUsecase{ApplicantRepo: userRepo}
 }
 
-func (u *ApplicantUsecase) CreateApplicant(request dto.ApplicantCreateDTO) error {
+func (u *ApplicantUsecase) CreateApplicant(ctx context.Context, request dto.ApplicantCreateDTO) error {
 	user := &domain.User{
 		Email:   request.Email,
 		Name:    request.Name,
 		Surname: request.Surname,
 	}
-	return u.ApplicantRepo.CreateApplicant(user)
+	return u.ApplicantRepo.CreateApplicant(ctx, user)
 }
 
-func (u *ApplicantUsecase) UpdateApplicant(id int, request dto.AppplicantUpdateDTO) error {
-	user, err := u.ApplicantRepo.FindApplicantByID(id)
+func (u *ApplicantUsecase) UpdateApplicant(ctx context.Context, id int, request dto.AppplicantUpdateDTO) error {
+	user, err := u.ApplicantRepo.FindApplicantByID(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -55,15 +56,15 @@ func (u *ApplicantUsecase) UpdateApplicant(id int, request dto.AppplicantUpdateD
 	user.ResidentCountryID = request.ResidentCountryID
 	user.DepartmentID = request.DepartmentID
 
-	return u.ApplicantRepo.UpdateApplicant(user)
+	return u.ApplicantRepo.UpdateApplicant(ctx, user)
 }
 
-func (u *ApplicantUsecase) DeleteApplicant(id int) error {
-	return u.ApplicantRepo.DeleteApplicant(id)
+func (u *ApplicantUsecase) DeleteApplicant(ctx context.Context, id int) error {
+	return u.ApplicantRepo.DeleteApplicant(ctx, id)
 }
 
-func (u *ApplicantUsecase) FindApplicantByID(id int) (*dto.ApplicantResponseDTO, error) {
-	user, err := u.ApplicantRepo.FindApplicantByID(id)
+func (u *ApplicantUsecase) FindApplicantByID(ctx context.Context, id int) (*dto.ApplicantResponseDTO, error) {
+	user, err := u.ApplicantRepo.FindApplicantByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+)
+
+const (
+	// DefaultClaimLease is how long a manual claim lasts when no lease is given
+	DefaultClaimLease = 30 * time.Minute
+	// MaxClaimLease caps manual claims so a forgotten claim does not block a request for long
+	MaxClaimLease = 8 * time.Hour
+	// autoAssignLease is longer than a manual claim since reviewers work through a whole batch
+	autoAssignLease = 24 * time.Hour
+	// defaultAutoAssignLimit is how many requests one auto-assign run hands out by default
+	defaultAutoAssignLimit = 100
+)
+
+var (
+	ErrClaimLease          = errors.New("lease must be between 1 minute and 8 hours")
+	ErrAutoAssignForbidden = errors.New("forbidden: only admins can auto-assign requests")
+	ErrNoReviewers         = errors.New("no reviewers to assign requests to")
+)
+
+type AssignmentUsecaseInterface interface {
+	ClaimRequest(ctx context.Context, id int, reviewerID int, lease time.Duration, scope dto.AdminScope) (*dto.ClaimResponse, error)
+	ReleaseRequest(ctx context.Context, id int, reviewerID int, scope dto.AdminScope) error
+	MarkViewed(ctx context.Context, id int, reviewerID int, scope dto.AdminScope) error
+	GetQueue(ctx context.Context, reviewerID int, scope dto.AdminScope) (*dto.ListRequest, error)
+	AutoAssign(ctx context.Context, input dto.AutoAssignRequest, scope dto.AdminScope) (*dto.AutoAssignResponse, error)
+}
+
+type AssignmentUsecase struct {
+	repo storage.AssignmentRepositoryInterface
+}
+
+func NewAssignmentUsecase(repo storage.AssignmentRepositoryInterface) *AssignmentUsecase {
+	return &AssignmentUsecase{repo: repo}
+}
+
+func (u *AssignmentUsecase) ClaimRequest(ctx context.Context, id int, reviewerID int, lease time.Duration, scope dto.AdminScope) (*dto.ClaimResponse, error) {
+	if lease == 0 {
+		lease = DefaultClaimLease
+	}
+	if lease < time.Minute || lease > MaxClaimLease {
+		return nil, ErrClaimLease
+	}
+	if _, err := u.repo.FindRequest(ctx, id, scope); err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(lease)
+	if err := u.repo.ClaimRequest(ctx, id, reviewerID, expiresAt); err != nil {
+		return nil, err
+	}
+	return &dto.ClaimResponse{RequestID: id, ClaimedBy: reviewerID, ExpiresAt: expiresAt}, nil
+}
+
+// ReleaseRequest gives the request back to the pool, global admins can release anyone's claim
+func (u *AssignmentUsecase) ReleaseRequest(ctx context.Context, id int, reviewerID int, scope dto.AdminScope) error {
+	if _, err := u.repo.FindRequest(ctx, id, scope); err != nil {
+		return err
+	}
+	return u.repo.ReleaseRequest(ctx, id, reviewerID, scope.All)
+}
+
+func (u *AssignmentUsecase) MarkViewed(ctx context.Context, id int, reviewerID int, scope dto.AdminScope) error {
+	if _, err := u.repo.FindRequest(ctx, id, scope); err != nil {
+		return err
+	}
+	return u.repo.MarkViewed(ctx, id, reviewerID)
+}
+
+func (u *AssignmentUsecase) GetQueue(ctx context.Context, reviewerID int, scope dto.AdminScope) (*dto.ListRequest, error) {
+	requests, err := u.repo.ListQueue(ctx, reviewerID, scope)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ListRequest{Requests: requests}, nil
+}
+
+// AutoAssign hands unclaimed pending requests out round-robin, starting with the reviewers
+// holding the fewest requests so repeated runs even out the queues
+func (u *AssignmentUsecase) AutoAssign(ctx context.Context, input dto.AutoAssignRequest, scope dto.AdminScope) (*dto.AutoAssignResponse, error) {
+	if !scope.All {
+		return nil, ErrAutoAssignForbidden
+	}
+	reviewers := uniqueIDs(input.ReviewerIDs)
+	if len(reviewers) == 0 {
+		ids, err := u.repo.ListActiveAdminIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		reviewers = ids
+	}
+	if len(reviewers) == 0 {
+		return nil, ErrNoReviewers
+	}
+	load, err := u.repo.CountActiveClaims(ctx, reviewers)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(reviewers, func(i, j int) bool { return load[reviewers[i]] < load[reviewers[j]] })
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultAutoAssignLimit
+	}
+	requests, err := u.repo.ListUnclaimed(ctx, scope, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AutoAssignResponse{ExpiresAt: time.Now().Add(autoAssignLease), Assignments: []dto.Assignment{}}
+	next := 0
+	for _, request := range requests {
+		reviewerID := reviewers[next%len(reviewers)]
+		err := u.repo.ClaimRequest(ctx, request.ID, reviewerID, response.ExpiresAt)
+		if errors.Is(err, storage.ErrRequestClaimed) || errors.Is(err, storage.ErrRequestNotPending) {
+			// someone claimed or decided it since it was listed
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		response.Assignments = append(response.Assignments, dto.Assignment{RequestID: request.ID, ReviewerID: reviewerID})
+		next++
+	}
+	return response, nil
+}
+
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
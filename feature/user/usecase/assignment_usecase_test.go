@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type mockAssignmentRepository struct {
+	mock.Mock
+}
+
+func (m *mockAssignmentRepository) FindRequest(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, error) {
+	args := m.Called(id, scope)
+	request, _ := args.Get(0).(*domain.Request)
+	return request, args.Error(1)
+}
+
+func (m *mockAssignmentRepository) ClaimRequest(ctx context.Context, id int, reviewerID int, expiresAt time.Time) error {
+	return m.Called(id, reviewerID, expiresAt).Error(0)
+}
+
+func (m *mockAssignmentRepository) ReleaseRequest(ctx context.Context, id int, reviewerID int, force bool) error {
+	return m.Called(id, reviewerID, force).Error(0)
+}
+
+func (m *mockAssignmentRepository) MarkViewed(ctx context.Context, id int, reviewerID int) error {
+	return m.Called(id, reviewerID).Error(0)
+}
+
+func (m *mockAssignmentRepository) ListQueue(ctx context.Context, reviewerID int, scope dto.AdminScope) ([]*domain.Request, error) {
+	args := m.Called(reviewerID, scope)
+	return args.Get(0).([]*domain.Request), args.Error(1)
+}
+
+func (m *mockAssignmentRepository) ListUnclaimed(ctx context.Context, scope dto.AdminScope, limit int) ([]*domain.Request, error) {
+	args := m.Called(scope, limit)
+	return args.Get(0).([]*domain.Request), args.Error(1)
+}
+
+func (m *mockAssignmentRepository) ListActiveAdminIDs(ctx context.Context) ([]int, error) {
+	args := m.Called()
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *mockAssignmentRepository) CountActiveClaims(ctx context.Context, reviewerIDs []int) (map[int]int, error) {
+	args := m.Called(reviewerIDs)
+	return args.Get(0).(map[int]int), args.Error(1)
+}
+
+func TestClaimRequest(t *testing.T) {
+	repo := new(mockAssignmentRepository)
+	usecase := NewAssignmentUsecase(repo)
+	scope := dto.AdminScope{DepartmentIDs: []int{2}}
+
+	repo.On("FindRequest", 1, scope).Return(&domain.Request{ID: 1}, nil)
+	repo.On("ClaimRequest", 1, 7, mock.AnythingOfType("time.Time")).Return(nil)
+	repo.On("FindRequest", 2, scope).Return(&domain.Request{ID: 2}, nil)
+	repo.On("ClaimRequest", 2, 7, mock.AnythingOfType("time.Time")).Return(storage.ErrRequestClaimed)
+	repo.On("FindRequest", 3, scope).Return(nil, gorm.ErrRecordNotFound)
+
+	claim, err := usecase.ClaimRequest(context.Background(), 1, 7, 0, scope)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, claim.ClaimedBy)
+	assert.WithinDuration(t, time.Now().Add(DefaultClaimLease), claim.ExpiresAt, time.Minute)
+
+	_, err = usecase.ClaimRequest(context.Background(), 2, 7, 0, scope)
+	assert.ErrorIs(t, err, storage.ErrRequestClaimed)
+
+	_, err = usecase.ClaimRequest(context.Background(), 3, 7, 0, scope)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	_, err = usecase.ClaimRequest(context.Background(), 1, 7, 9*time.Hour, scope)
+	assert.ErrorIs(t, err, ErrClaimLease)
+}
+
+func TestAutoAssign_RoundRobinLeastLoadedFirst(t *testing.T) {
+	repo := new(mockAssignmentRepository)
+	usecase := NewAssignmentUsecase(repo)
+	scope := dto.AdminScope{All: true}
+
+	repo.On("ListActiveAdminIDs").Return([]int{1, 2, 3}, nil)
+	repo.On("CountActiveClaims", []int{1, 2, 3}).Return(map[int]int{1: 4, 2: 1}, nil)
+	repo.On("ListUnclaimed", scope, defaultAutoAssignLimit).Return([]*domain.Request{{ID: 10}, {ID: 11}, {ID: 12}, {ID: 13}}, nil)
+	repo.On("ClaimRequest", 10, 3, mock.Anything).Return(nil)
+	repo.On("ClaimRequest", 11, 2, mock.Anything).Return(storage.ErrRequestClaimed)
+	repo.On("ClaimRequest", 12, 2, mock.Anything).Return(nil)
+	repo.On("ClaimRequest", 13, 1, mock.Anything).Return(nil)
+
+	response, err := usecase.AutoAssign(context.Background(), dto.AutoAssignRequest{}, scope)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.Assignment{
+		{RequestID: 10, ReviewerID: 3},
+		{RequestID: 12, ReviewerID: 2},
+		{RequestID: 13, ReviewerID: 1},
+	}, response.Assignments)
+	repo.AssertExpectations(t)
+}
+
+func TestAutoAssign_ManagersForbidden(t *testing.T) {
+	usecase := NewAssignmentUsecase(new(mockAssignmentRepository))
+
+	_, err := usecase.AutoAssign(context.Background(), dto.AutoAssignRequest{}, dto.AdminScope{DepartmentIDs: []int{2}})
+
+	assert.ErrorIs(t, err, ErrAutoAssignForbidden)
+}
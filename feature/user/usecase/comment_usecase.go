@@ -0,0 +1,349 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxCommentAttachments is how many files a single comment can carry
+	MaxCommentAttachments = 5
+	// MaxAttachmentSize is the size limit of one attachment
+	MaxAttachmentSize = 10 << 20
+)
+
+var (
+	ErrCommentParentInvalid = errors.New("parent comment does not belong to this thread")
+	ErrTooManyAttachments   = fmt.Errorf("a comment can have at most %d attachments", MaxCommentAttachments)
+	ErrAttachmentTooLarge   = fmt.Errorf("attachments are limited to %d MB", MaxAttachmentSize>>20)
+)
+
+// CommentNotifier tells the other side of a thread that a new comment was posted
+type CommentNotifier interface {
+	NotifyComment(ctx context.Context, userIDs []int, comment *domain.RequestComment)
+}
+
+type CommentUsecaseInterface interface {
+	GetReviewerThread(ctx context.Context, requestID int, userID int, scope dto.AdminScope) (*dto.CommentThread, error)
+	GetRequesterThread(ctx context.Context, requestID int, userID int) (*dto.CommentThread, error)
+	AddReviewerComment(ctx context.Context, requestID int, userID int, input dto.CommentCreateDTO, files []dto.AttachmentUpload, scope dto.AdminScope) (*dto.CommentResponse, error)
+	AddRequesterComment(ctx context.Context, requestID int, userID int, input dto.CommentCreateDTO, files []dto.AttachmentUpload) (*dto.CommentResponse, error)
+	MarkReviewerThreadRead(ctx context.Context, requestID int, userID int, scope dto.AdminScope) error
+	MarkRequesterThreadRead(ctx context.Context, requestID int, userID int) error
+	OpenReviewerAttachment(ctx context.Context, requestID int, attachmentID int, scope dto.AdminScope) (*dto.AttachmentDownload, error)
+	OpenRequesterAttachment(ctx context.Context, requestID int, attachmentID int, userID int) (*dto.AttachmentDownload, error)
+}
+
+type CommentUsecase struct {
+	repo     storage.CommentRepositoryInterface
+	store    storage.AttachmentStore
+	notifier CommentNotifier
+}
+
+func NewCommentUsecase(repo storage.CommentRepositoryInterface, store storage.AttachmentStore, notifier CommentNotifier) *CommentUsecase {
+	return &CommentUsecase{repo: repo, store: store, notifier: notifier}
+}
+
+func (u *CommentUsecase) GetReviewerThread(ctx context.Context, requestID int, userID int, scope dto.AdminScope) (*dto.CommentThread, error) {
+	request, err := u.repo.FindRequest(ctx, requestID, scope)
+	if err != nil {
+		return nil, err
+	}
+	return u.thread(ctx, request, userID, false)
+}
+
+// GetRequesterThread only shows external comments to the owner of the request
+func (u *CommentUsecase) GetRequesterThread(ctx context.Context, requestID int, userID int) (*dto.CommentThread, error) {
+	request, err := u.repo.FindUserRequest(ctx, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.thread(ctx, request, userID, true)
+}
+
+// AddReviewerComment posts an internal comment unless visibility is external,
+// the requester is notified of external comments
+func (u *CommentUsecase) AddReviewerComment(ctx context.Context, requestID int, userID int, input dto.CommentCreateDTO, files []dto.AttachmentUpload, scope dto.AdminScope) (*dto.CommentResponse, error) {
+	request, err := u.repo.FindRequest(ctx, requestID, scope)
+	if err != nil {
+		return nil, err
+	}
+	visibility := domain.CommentInternal
+	if input.Visibility == "external" {
+		visibility = domain.CommentExternal
+	}
+	comment, err := u.addComment(ctx, request, userID, visibility, input, files)
+	if err != nil {
+		return nil, err
+	}
+	if visibility == domain.CommentExternal {
+		u.notifier.NotifyComment(ctx, []int{request.UserID}, comment)
+	}
+	return u.commentResponse(ctx, request, comment)
+}
+
+// AddRequesterComment posts an external comment and notifies the reviewers of the request
+func (u *CommentUsecase) AddRequesterComment(ctx context.Context, requestID int, userID int, input dto.CommentCreateDTO, files []dto.AttachmentUpload) (*dto.CommentResponse, error) {
+	request, err := u.repo.FindUserRequest(ctx, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := u.addComment(ctx, request, userID, domain.CommentExternal, input, files)
+	if err != nil {
+		return nil, err
+	}
+	reviewers, err := u.repo.ListReviewerIDs(ctx, request.ID)
+	if err != nil {
+		log.Printf("request %d comment %d: cannot list reviewers: %v", request.ID, comment.ID, err)
+	} else if recipients := uniqueIDs(withoutID(reviewers, userID)); len(recipients) > 0 {
+		u.notifier.NotifyComment(ctx, recipients, comment)
+	}
+	return u.commentResponse(ctx, request, comment)
+}
+
+func (u *CommentUsecase) MarkReviewerThreadRead(ctx context.Context, requestID int, userID int, scope dto.AdminScope) error {
+	request, err := u.repo.FindRequest(ctx, requestID, scope)
+	if err != nil {
+		return err
+	}
+	return u.markRead(ctx, request, userID, false)
+}
+
+func (u *CommentUsecase) MarkRequesterThreadRead(ctx context.Context, requestID int, userID int) error {
+	request, err := u.repo.FindUserRequest(ctx, requestID, userID)
+	if err != nil {
+		return err
+	}
+	return u.markRead(ctx, request, userID, true)
+}
+
+func (u *CommentUsecase) OpenReviewerAttachment(ctx context.Context, requestID int, attachmentID int, scope dto.AdminScope) (*dto.AttachmentDownload, error) {
+	request, err := u.repo.FindRequest(ctx, requestID, scope)
+	if err != nil {
+		return nil, err
+	}
+	return u.openAttachment(ctx, request, attachmentID, false)
+}
+
+// OpenRequesterAttachment hides attachments of internal comments as if they did not exist
+func (u *CommentUsecase) OpenRequesterAttachment(ctx context.Context, requestID int, attachmentID int, userID int) (*dto.AttachmentDownload, error) {
+	request, err := u.repo.FindUserRequest(ctx, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.openAttachment(ctx, request, attachmentID, true)
+}
+
+func (u *CommentUsecase) addComment(ctx context.Context, request *domain.Request, userID int, visibility int, input dto.CommentCreateDTO, files []dto.AttachmentUpload) (*domain.RequestComment, error) {
+	if len(files) > MaxCommentAttachments {
+		return nil, ErrTooManyAttachments
+	}
+	for _, file := range files {
+		if file.Size > MaxAttachmentSize {
+			return nil, ErrAttachmentTooLarge
+		}
+	}
+	if input.ParentID != nil {
+		parent, err := u.repo.FindComment(ctx, request.ID, *input.ParentID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentParentInvalid
+		}
+		if err != nil {
+			return nil, err
+		}
+		// a reply to an internal comment must stay internal, otherwise it would leak the thread
+		if parent.Visibility == domain.CommentInternal && visibility != domain.CommentInternal {
+			return nil, ErrCommentParentInvalid
+		}
+	}
+
+	attachments := make([]domain.CommentAttachment, 0, len(files))
+	for _, file := range files {
+		path, err := u.store.Save(file.FileName, file.Content)
+		if err != nil {
+			u.removeFiles(attachments)
+			return nil, err
+		}
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		attachments = append(attachments, domain.CommentAttachment{
+			FileName:    filepath.Base(file.FileName),
+			ContentType: contentType,
+			Size:        file.Size,
+			StoragePath: path,
+		})
+	}
+	comment := &domain.RequestComment{
+		RequestID:  request.ID,
+		ParentID:   input.ParentID,
+		AuthorID:   userID,
+		Visibility: visibility,
+		Body:       strings.TrimSpace(input.Body),
+	}
+	if err := u.repo.CreateComment(ctx, comment, attachments); err != nil {
+		u.removeFiles(attachments)
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (u *CommentUsecase) removeFiles(attachments []domain.CommentAttachment) {
+	for _, attachment := range attachments {
+		if err := u.store.Remove(attachment.StoragePath); err != nil {
+			log.Printf("cannot remove attachment %s: %v", attachment.StoragePath, err)
+		}
+	}
+}
+
+func (u *CommentUsecase) markRead(ctx context.Context, request *domain.Request, userID int, externalOnly bool) error {
+	comments, err := u.repo.ListComments(ctx, request.ID, externalOnly)
+	if err != nil {
+		return err
+	}
+	ids := make([]int, 0, len(comments))
+	for _, comment := range comments {
+		if comment.AuthorID != userID {
+			ids = append(ids, comment.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return u.repo.MarkRead(ctx, ids, userID)
+}
+
+func (u *CommentUsecase) openAttachment(ctx context.Context, request *domain.Request, attachmentID int, externalOnly bool) (*dto.AttachmentDownload, error) {
+	attachment, comment, err := u.repo.FindAttachment(ctx, request.ID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if externalOnly && comment.Visibility != domain.CommentExternal {
+		return nil, gorm.ErrRecordNotFound
+	}
+	content, err := u.store.Open(attachment.StoragePath)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AttachmentDownload{
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Content:     content,
+	}, nil
+}
+
+// thread nests replies under their parent, a reply whose parent is not visible is shown at the top level
+func (u *CommentUsecase) thread(ctx context.Context, request *domain.Request, userID int, externalOnly bool) (*dto.CommentThread, error) {
+	comments, err := u.repo.ListComments(ctx, request.ID, externalOnly)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := u.commentResponses(ctx, request, comments, userID)
+	if err != nil {
+		return nil, err
+	}
+	thread := &dto.CommentThread{RequestID: request.ID, Comments: []*dto.CommentResponse{}}
+	byID := make(map[int]*dto.CommentResponse, len(responses))
+	for _, response := range responses {
+		byID[response.ID] = response
+	}
+	for _, response := range responses {
+		if !response.Read {
+			thread.Unread++
+		}
+		if response.ParentID != nil {
+			if parent, ok := byID[*response.ParentID]; ok {
+				parent.Replies = append(parent.Replies, response)
+				continue
+			}
+		}
+		thread.Comments = append(thread.Comments, response)
+	}
+	return thread, nil
+}
+
+func (u *CommentUsecase) commentResponse(ctx context.Context, request *domain.Request, comment *domain.RequestComment) (*dto.CommentResponse, error) {
+	responses, err := u.commentResponses(ctx, request, []domain.RequestComment{*comment}, comment.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	return responses[0], nil
+}
+
+// commentResponses attaches files and read receipts, Read tells whether userID has seen the comment
+func (u *CommentUsecase) commentResponses(ctx context.Context, request *domain.Request, comments []domain.RequestComment, userID int) ([]*dto.CommentResponse, error) {
+	responses := make([]*dto.CommentResponse, 0, len(comments))
+	if len(comments) == 0 {
+		return responses, nil
+	}
+	ids := make([]int, 0, len(comments))
+	byID := make(map[int]*dto.CommentResponse, len(comments))
+	for _, comment := range comments {
+		visibility := "internal"
+		if comment.Visibility == domain.CommentExternal {
+			visibility = "external"
+		}
+		response := &dto.CommentResponse{
+			ID:            comment.ID,
+			ParentID:      comment.ParentID,
+			AuthorID:      comment.AuthorID,
+			FromRequester: comment.AuthorID == request.UserID,
+			Visibility:    visibility,
+			Body:          comment.Body,
+			Attachments:   []dto.AttachmentResponse{},
+			ReadBy:        []dto.CommentReadResponse{},
+			Read:          comment.AuthorID == userID,
+			CreatedAt:     comment.CreatedAt,
+			Replies:       []*dto.CommentResponse{},
+		}
+		ids = append(ids, comment.ID)
+		byID[comment.ID] = response
+		responses = append(responses, response)
+	}
+
+	attachments, err := u.repo.ListAttachments(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, attachment := range attachments {
+		byID[attachment.CommentID].Attachments = append(byID[attachment.CommentID].Attachments, dto.AttachmentResponse{
+			ID:          attachment.ID,
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+		})
+	}
+	reads, err := u.repo.ListReads(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, read := range reads {
+		response := byID[read.CommentID]
+		response.ReadBy = append(response.ReadBy, dto.CommentReadResponse{UserID: read.UserID, ReadAt: read.ReadAt})
+		if read.UserID == userID {
+			response.Read = true
+		}
+	}
+	return responses, nil
+}
+
+func withoutID(ids []int, id int) []int {
+	filtered := make([]int, 0, len(ids))
+	for _, v := range ids {
+		if v != id {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type mockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *mockCommentRepository) FindRequest(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, error) {
+	args := m.Called(id, scope)
+	request, _ := args.Get(0).(*domain.Request)
+	return request, args.Error(1)
+}
+
+func (m *mockCommentRepository) FindUserRequest(ctx context.Context, id int, userID int) (*domain.Request, error) {
+	args := m.Called(id, userID)
+	request, _ := args.Get(0).(*domain.Request)
+	return request, args.Error(1)
+}
+
+func (m *mockCommentRepository) FindComment(ctx context.Context, requestID int, id int) (*domain.RequestComment, error) {
+	args := m.Called(requestID, id)
+	comment, _ := args.Get(0).(*domain.RequestComment)
+	return comment, args.Error(1)
+}
+
+func (m *mockCommentRepository) CreateComment(ctx context.Context, comment *domain.RequestComment, attachments []domain.CommentAttachment) error {
+	return m.Called(comment, attachments).Error(0)
+}
+
+func (m *mockCommentRepository) ListComments(ctx context.Context, requestID int, externalOnly bool) ([]domain.RequestComment, error) {
+	args := m.Called(requestID, externalOnly)
+	return args.Get(0).([]domain.RequestComment), args.Error(1)
+}
+
+func (m *mockCommentRepository) ListAttachments(ctx context.Context, commentIDs []int) ([]domain.CommentAttachment, error) {
+	args := m.Called(commentIDs)
+	return args.Get(0).([]domain.CommentAttachment), args.Error(1)
+}
+
+func (m *mockCommentRepository) ListReads(ctx context.Context, commentIDs []int) ([]domain.CommentRead, error) {
+	args := m.Called(commentIDs)
+	return args.Get(0).([]domain.CommentRead), args.Error(1)
+}
+
+func (m *mockCommentRepository) MarkRead(ctx context.Context, commentIDs []int, userID int) error {
+	return m.Called(commentIDs, userID).Error(0)
+}
+
+func (m *mockCommentRepository) FindAttachment(ctx context.Context, requestID int, id int) (*domain.CommentAttachment, *domain.RequestComment, error) {
+	args := m.Called(requestID, id)
+	attachment, _ := args.Get(0).(*domain.CommentAttachment)
+	comment, _ := args.Get(1).(*domain.RequestComment)
+	return attachment, comment, args.Error(2)
+}
+
+func (m *mockCommentRepository) ListReviewerIDs(ctx context.Context, requestID int) ([]int, error) {
+	args := m.Called(requestID)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+type mockAttachmentStore struct {
+	mock.Mock
+}
+
+func (m *mockAttachmentStore) Save(fileName string, content io.Reader) (string, error) {
+	args := m.Called(fileName, content)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockAttachmentStore) Open(path string) (io.ReadCloser, error) {
+	args := m.Called(path)
+	content, _ := args.Get(0).(io.ReadCloser)
+	return content, args.Error(1)
+}
+
+func (m *mockAttachmentStore) Remove(path string) error {
+	return m.Called(path).Error(0)
+}
+
+type mockCommentNotifier struct {
+	mock.Mock
+}
+
+func (m *mockCommentNotifier) NotifyComment(ctx context.Context, userIDs []int, comment *domain.RequestComment) {
+	m.Called(userIDs, comment)
+}
+
+func TestAddRequesterComment_NotifiesReviewers(t *testing.T) {
+	repo := new(mockCommentRepository)
+	store := new(mockAttachmentStore)
+	notifier := new(mockCommentNotifier)
+	usecase := NewCommentUsecase(repo, store, notifier)
+	content := strings.NewReader("%PDF")
+
+	repo.On("FindUserRequest", 1, 5).Return(&domain.Request{ID: 1, UserID: 5}, nil)
+	store.On("Save", "passport.pdf", content).Return("ab12.pdf", nil)
+	repo.On("CreateComment", mock.MatchedBy(func(comment *domain.RequestComment) bool {
+		return comment.Visibility == domain.CommentExternal && comment.Body == "uploaded again" && comment.AuthorID == 5
+	}), []domain.CommentAttachment{{FileName: "passport.pdf", ContentType: "application/pdf", Size: 4, StoragePath: "ab12.pdf"}}).
+		Run(func(args mock.Arguments) { args.Get(0).(*domain.RequestComment).ID = 9 }).Return(nil)
+	repo.On("ListReviewerIDs", 1).Return([]int{2, 3, 2}, nil)
+	notifier.On("NotifyComment", []int{2, 3}, mock.Anything).Return()
+	repo.On("ListAttachments", []int{9}).Return([]domain.CommentAttachment{{ID: 4, CommentID: 9, FileName: "passport.pdf"}}, nil)
+	repo.On("ListReads", []int{9}).Return([]domain.CommentRead{}, nil)
+
+	comment, err := usecase.AddRequesterComment(context.Background(), 1, 5, dto.CommentCreateDTO{Body: " uploaded again ", Visibility: "internal"}, []dto.AttachmentUpload{
+		{FileName: "passport.pdf", ContentType: "application/pdf", Size: 4, Content: content},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "external", comment.Visibility)
+	assert.True(t, comment.FromRequester)
+	assert.True(t, comment.Read)
+	assert.Len(t, comment.Attachments, 1)
+	notifier.AssertExpectations(t)
+}
+
+func TestAddReviewerComment_ExternalReplyToInternalComment(t *testing.T) {
+	repo := new(mockCommentRepository)
+	usecase := NewCommentUsecase(repo, new(mockAttachmentStore), new(mockCommentNotifier))
+	scope := dto.AdminScope{All: true}
+	parentID := 3
+
+	repo.On("FindRequest", 1, scope).Return(&domain.Request{ID: 1, UserID: 5}, nil)
+	repo.On("FindComment", 1, 3).Return(&domain.RequestComment{ID: 3, Visibility: domain.CommentInternal}, nil)
+
+	_, err := usecase.AddReviewerComment(context.Background(), 1, 2, dto.CommentCreateDTO{Body: "hi", ParentID: &parentID, Visibility: "external"}, nil, scope)
+
+	assert.ErrorIs(t, err, ErrCommentParentInvalid)
+	repo.AssertNotCalled(t, "CreateComment", mock.Anything, mock.Anything)
+}
+
+func TestGetRequesterThread(t *testing.T) {
+	repo := new(mockCommentRepository)
+	usecase := NewCommentUsecase(repo, new(mockAttachmentStore), new(mockCommentNotifier))
+	parentID := 1
+
+	repo.On("FindUserRequest", 1, 5).Return(&domain.Request{ID: 1, UserID: 5}, nil)
+	repo.On("ListComments", 1, true).Return([]domain.RequestComment{
+		{ID: 1, RequestID: 1, AuthorID: 2, Visibility: domain.CommentExternal, Body: "please upload a clearer copy"},
+		{ID: 2, RequestID: 1, AuthorID: 5, ParentID: &parentID, Visibility: domain.CommentExternal, Body: "done"},
+		{ID: 3, RequestID: 1, AuthorID: 2, ParentID: &parentID, Visibility: domain.CommentExternal, Body: "thanks"},
+	}, nil)
+	repo.On("ListAttachments", []int{1, 2, 3}).Return([]domain.CommentAttachment{}, nil)
+	repo.On("ListReads", []int{1, 2, 3}).Return([]domain.CommentRead{{CommentID: 1, UserID: 5}, {CommentID: 2, UserID: 2}}, nil)
+
+	thread, err := usecase.GetRequesterThread(context.Background(), 1, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, thread.Unread)
+	assert.Len(t, thread.Comments, 1)
+	assert.Len(t, thread.Comments[0].Replies, 2)
+	assert.True(t, thread.Comments[0].Replies[0].FromRequester)
+	assert.False(t, thread.Comments[0].Replies[1].Read)
+}
+
+func TestGetRequesterThread_OtherUsersRequest(t *testing.T) {
+	repo := new(mockCommentRepository)
+	usecase := NewCommentUsecase(repo, new(mockAttachmentStore), new(mockCommentNotifier))
+
+	repo.On("FindUserRequest", 1, 6).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := usecase.GetRequesterThread(context.Background(), 1, 6)
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
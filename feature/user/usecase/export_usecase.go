@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/export"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+)
+
+type ExportUsecaseInterface interface {
+	ExportRequests(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, format string, w io.Writer) error
+	ExportVolunteers(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, format string, w io.Writer) error
+	ExportApplicants(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, format string, w io.Writer) error
+}
+
+type ExportUsecase struct {
+	repo storage.ExportRepositoryInterface
+}
+
+func NewExportUsecase(repo storage.ExportRepositoryInterface) *ExportUsecase {
+	return &ExportUsecase{repo: repo}
+}
+
+func (u *ExportUsecase) ExportRequests(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, format string, w io.Writer) error {
+	header := []string{"id", "user_id", "email", "name", "surname", "department", "type", "status", "verifier_id", "reject_notes", "created_at", "decided_at"}
+	return export.Write(format, w, header, func(write func([]string) error) error {
+		return u.repo.StreamRequests(ctx, scope, filter, func(row *dto.RequestExportRow) error {
+			return write([]string{
+				strconv.Itoa(row.ID),
+				strconv.Itoa(row.UserID),
+				row.Email,
+				row.Name,
+				row.Surname,
+				optionalString(row.Department),
+				row.Type,
+				requestStatusName(row.Status),
+				optionalInt(row.VerifierID),
+				optionalString(row.RejectNotes),
+				row.CreatedAt.Format(time.RFC3339),
+				optionalTime(row.DecidedAt, time.RFC3339),
+			})
+		})
+	})
+}
+
+func (u *ExportUsecase) ExportVolunteers(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, format string, w io.Writer) error {
+	header := []string{"id", "user_id", "email", "name", "surname", "mobile", "department", "country", "status", "created_at"}
+	return export.Write(format, w, header, func(write func([]string) error) error {
+		return u.repo.StreamVolunteers(ctx, scope, filter, func(row *dto.VolunteerExportRow) error {
+			return write([]string{
+				strconv.Itoa(row.ID),
+				strconv.Itoa(row.UserID),
+				row.Email,
+				row.Name,
+				row.Surname,
+				optionalString(row.Mobile),
+				optionalString(row.Department),
+				optionalString(row.Country),
+				activeStatusName(row.Status),
+				row.CreatedAt.Format(time.RFC3339),
+			})
+		})
+	})
+}
+
+func (u *ExportUsecase) ExportApplicants(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, format string, w io.Writer) error {
+	header := []string{"id", "email", "name", "surname", "gender", "dob", "mobile", "department", "country", "verification_status", "created_at"}
+	return export.Write(format, w, header, func(write func([]string) error) error {
+		return u.repo.StreamApplicants(ctx, scope, filter, func(row *dto.ApplicantExportRow) error {
+			verification := "unverified"
+			if row.VerificationStatus == 1 {
+				verification = "verified"
+			}
+			return write([]string{
+				strconv.Itoa(row.ID),
+				row.Email,
+				row.Name,
+				row.Surname,
+				optionalString(row.Gender),
+				optionalTime(row.Dob, "2006-01-02"),
+				optionalString(row.Mobile),
+				optionalString(row.Department),
+				optionalString(row.Country),
+				verification,
+				row.CreatedAt.Format(time.RFC3339),
+			})
+		})
+	})
+}
+
+func requestStatusName(status int) string {
+	switch status {
+	case 1:
+		return "approved"
+	case 2:
+		return "rejected"
+	default:
+		return "pending"
+	}
+}
+
+func activeStatusName(status int) string {
+	if status == 1 {
+		return "active"
+	}
+	return "inactive"
+}
+
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func optionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func optionalTime(value *time.Time, layout string) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(layout)
+}
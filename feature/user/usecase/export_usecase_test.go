@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/export"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xuri/excelize/v2"
+)
+
+type mockExportRepository struct {
+	mock.Mock
+	requests []*dto.RequestExportRow
+}
+
+func (m *mockExportRepository) StreamRequests(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, fn func(row *dto.RequestExportRow) error) error {
+	args := m.Called(scope, filter)
+	for _, row := range m.requests {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return args.Error(0)
+}
+
+func (m *mockExportRepository) StreamVolunteers(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, fn func(row *dto.VolunteerExportRow) error) error {
+	args := m.Called(scope, filter)
+	return args.Error(0)
+}
+
+func (m *mockExportRepository) StreamApplicants(ctx context.Context, scope dto.AdminScope, filter dto.ExportFilter, fn func(row *dto.ApplicantExportRow) error) error {
+	args := m.Called(scope, filter)
+	return args.Error(0)
+}
+
+func exportFixture() *mockExportRepository {
+	department := "Logistics"
+	verifier := 3
+	decided := time.Date(2024, 6, 2, 10, 0, 0, 0, time.UTC)
+	repo := &mockExportRepository{requests: []*dto.RequestExportRow{{
+		ID:         1,
+		UserID:     7,
+		Email:      "jane@example.com",
+		Name:       "Jane",
+		Surname:    "Doe, Jr",
+		Department: &department,
+		Type:       "verification",
+		Status:     1,
+		VerifierID: &verifier,
+		CreatedAt:  time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC),
+		DecidedAt:  &decided,
+	}}}
+	repo.On("StreamRequests", dto.AdminScope{All: true}, dto.ExportFilter{}).Return(nil)
+	return repo
+}
+
+func TestExportRequests_CSV(t *testing.T) {
+	repo := exportFixture()
+	usecase := NewExportUsecase(repo)
+
+	var buf bytes.Buffer
+	err := usecase.ExportRequests(context.Background(), dto.AdminScope{All: true}, dto.ExportFilter{}, export.CSV, &buf)
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, `1,7,jane@example.com,Jane,"Doe, Jr",Logistics,verification,approved,3,,2024-06-01T09:00:00Z,2024-06-02T10:00:00Z`, lines[1])
+}
+
+func TestExportRequests_XLSX(t *testing.T) {
+	repo := exportFixture()
+	usecase := NewExportUsecase(repo)
+
+	var buf bytes.Buffer
+	err := usecase.ExportRequests(context.Background(), dto.AdminScope{All: true}, dto.ExportFilter{}, export.XLSX, &buf)
+	assert.NoError(t, err)
+
+	file, err := excelize.OpenReader(&buf)
+	assert.NoError(t, err)
+	defer file.Close()
+	rows, err := file.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "id", rows[0][0])
+	assert.Equal(t, "Doe, Jr", rows[1][4])
+}
+
+func TestExport_UnsupportedFormat(t *testing.T) {
+	repo := new(mockExportRepository)
+	usecase := NewExportUsecase(repo)
+
+	err := usecase.ExportVolunteers(context.Background(), dto.AdminScope{All: true}, dto.ExportFilter{}, "pdf", &bytes.Buffer{})
+
+	assert.ErrorIs(t, err, export.ErrFormat)
+	repo.AssertNotCalled(t, "StreamVolunteers", mock.Anything, mock.Anything)
+}
+
+func TestExportRequests_EscapesFormulas(t *testing.T) {
+	repo := exportFixture()
+	repo.requests[0].Name = "=HYPERLINK(\"http://evil\")"
+	repo.requests[0].Surname = "@SUM(A1)"
+	notes := "-1+2"
+	repo.requests[0].RejectNotes = &notes
+	usecase := NewExportUsecase(repo)
+
+	var csvBuf bytes.Buffer
+	err := usecase.ExportRequests(context.Background(), dto.AdminScope{All: true}, dto.ExportFilter{}, export.CSV, &csvBuf)
+	assert.NoError(t, err)
+	assert.Contains(t, csvBuf.String(), `"'=HYPERLINK(""http://evil"")",'@SUM(A1),`)
+	assert.Contains(t, csvBuf.String(), ",'-1+2,")
+
+	var xlsxBuf bytes.Buffer
+	err = usecase.ExportRequests(context.Background(), dto.AdminScope{All: true}, dto.ExportFilter{}, export.XLSX, &xlsxBuf)
+	assert.NoError(t, err)
+	file, err := excelize.OpenReader(&xlsxBuf)
+	assert.NoError(t, err)
+	defer file.Close()
+	rows, err := file.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "'@SUM(A1)", rows[1][4])
+}
+
+func TestExportRequests_StreamError(t *testing.T) {
+	repo := &mockExportRepository{}
+	repo.On("StreamRequests", dto.AdminScope{All: true}, dto.ExportFilter{}).Return(errors.New("connection lost"))
+	usecase := NewExportUsecase(repo)
+
+	var buf bytes.Buffer
+	err := usecase.ExportRequests(context.Background(), dto.AdminScope{All: true}, dto.ExportFilter{}, export.XLSX, &buf)
+
+	assert.EqualError(t, err, "connection lost")
+	assert.Zero(t, buf.Len())
+}
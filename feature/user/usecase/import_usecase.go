@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+)
+
+const (
+	// maxImportRows bounds a single import job
+	maxImportRows = 5000
+	// InviteTTL is how long a volunteer imported without password has to choose one
+	InviteTTL = 7 * 24 * time.Hour
+)
+
+// Import row statuses
+const (
+	ImportRowValid   = "valid"
+	ImportRowCreated = "created"
+	ImportRowError   = "error"
+)
+
+var (
+	ErrImportHasErrors = errors.New("import has invalid rows, nothing was created")
+	importColumns      = []string{"email", "name", "surname", "gender", "dob", "mobile", "department", "country", "resident_country", "password"}
+	requiredColumns    = []string{"email", "name", "surname", "department", "country"}
+)
+
+type ImportUsecaseInterface interface {
+	ImportVolunteers(ctx context.Context, scope dto.AdminScope, r io.Reader, dryRun bool) (*dto.ImportReport, error)
+}
+
+type ImportUsecase struct {
+	repo storage.ImportRepositoryInterface
+}
+
+func NewImportUsecase(repo storage.ImportRepositoryInterface) *ImportUsecase {
+	return &ImportUsecase{repo: repo}
+}
+
+// ImportVolunteers validates every CSV row and, unless dryRun, creates all of them as verified volunteers.
+// Nothing is created when any row is invalid, the report then returns ErrImportHasErrors alongside
+func (u *ImportUsecase) ImportVolunteers(ctx context.Context, scope dto.AdminScope, r io.Reader, dryRun bool) (*dto.ImportReport, error) {
+	records, err := readImportCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	departments, err := u.repo.ListDepartments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	countries, err := u.repo.ListCountries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	emails := make([]string, 0, len(records))
+	for _, record := range records {
+		emails = append(emails, strings.ToLower(record.values["email"]))
+	}
+	existing, err := u.repo.FindExistingEmails(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := &importValidator{
+		scope:       scope,
+		departments: indexByName(departments),
+		countries:   indexByName(countries),
+		seen:        make(map[string]int),
+		existing:    make(map[string]bool),
+	}
+	for _, email := range existing {
+		validator.existing[strings.ToLower(email)] = true
+	}
+
+	report := &dto.ImportReport{DryRun: dryRun, Total: len(records)}
+	volunteers := make([]*dto.VolunteerImport, 0, len(records))
+	for _, record := range records {
+		result := dto.ImportRowResult{Row: record.line, Email: record.values["email"]}
+		volunteer, errs := validator.validate(record.line, record.values)
+		if len(errs) > 0 {
+			result.Status = ImportRowError
+			result.Errors = errs
+			report.Invalid++
+		} else {
+			result.Status = ImportRowValid
+			report.Valid++
+			volunteers = append(volunteers, volunteer)
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	if report.Invalid > 0 {
+		return report, ErrImportHasErrors
+	}
+	if dryRun || len(volunteers) == 0 {
+		return report, nil
+	}
+	if err := u.repo.CreateVolunteers(ctx, volunteers); err != nil {
+		return nil, err
+	}
+	for i, volunteer := range volunteers {
+		report.Rows[i].Status = ImportRowCreated
+		if volunteer.Invite != nil {
+			report.Rows[i].InviteToken = volunteer.InviteToken
+			report.Rows[i].InviteExpiresAt = &volunteer.Invite.ExpiresAt
+		}
+	}
+	report.Created = len(volunteers)
+	return report, nil
+}
+
+// importRecord is a CSV row keyed by column, line is its line in the file
+type importRecord struct {
+	line   int
+	values map[string]string
+}
+
+// readImportCSV maps every row to its header columns, headers are matched case-insensitively
+func readImportCSV(r io.Reader) ([]importRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, len(header))
+	present := make(map[string]bool)
+	for i, name := range header {
+		columns[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		present[columns[i]] = true
+	}
+	for _, name := range requiredColumns {
+		if !present[name] {
+			return nil, fmt.Errorf("missing required column %q, expected columns: %s", name, strings.Join(importColumns, ", "))
+		}
+	}
+
+	var records []importRecord
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		record := make(map[string]string, len(columns))
+		blank := true
+		for i, value := range values {
+			if i < len(columns) {
+				record[columns[i]] = strings.TrimSpace(value)
+				blank = blank && record[columns[i]] == ""
+			}
+		}
+		if blank {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, importRecord{line: line, values: record})
+		if len(records) > maxImportRows {
+			return nil, fmt.Errorf("too many rows, at most %d per import", maxImportRows)
+		}
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the file has no rows")
+	}
+	return records, nil
+}
+
+type importValidator struct {
+	scope       dto.AdminScope
+	departments map[string]int
+	countries   map[string]int
+	existing    map[string]bool
+	// seen maps emails to the row that first used them
+	seen map[string]int
+}
+
+func (v *importValidator) validate(row int, record map[string]string) (*dto.VolunteerImport, []string) {
+	var errs []string
+	email := strings.ToLower(record["email"])
+	if email == "" {
+		errs = append(errs, "email is required")
+	} else if _, err := mail.ParseAddress(email); err != nil {
+		errs = append(errs, "email is invalid")
+	} else if v.existing[email] {
+		errs = append(errs, "email is already registered")
+	} else if first, ok := v.seen[email]; ok {
+		errs = append(errs, fmt.Sprintf("email duplicates row %d", first))
+	} else {
+		v.seen[email] = row
+	}
+	if record["name"] == "" {
+		errs = append(errs, "name is required")
+	}
+	if record["surname"] == "" {
+		errs = append(errs, "surname is required")
+	}
+
+	departmentID, ok := v.departments[strings.ToLower(record["department"])]
+	if record["department"] == "" {
+		errs = append(errs, "department is required")
+	} else if !ok {
+		errs = append(errs, fmt.Sprintf("unknown department %q", record["department"]))
+	} else if !v.scope.Allows(&departmentID) {
+		errs = append(errs, fmt.Sprintf("department %q is outside your departments", record["department"]))
+	}
+
+	countryID, ok := v.countries[strings.ToLower(record["country"])]
+	if record["country"] == "" {
+		errs = append(errs, "country is required")
+	} else if !ok {
+		errs = append(errs, fmt.Sprintf("unknown country %q", record["country"]))
+	}
+	residentCountryID := countryID
+	if name := record["resident_country"]; name != "" {
+		if residentCountryID, ok = v.countries[strings.ToLower(name)]; !ok {
+			errs = append(errs, fmt.Sprintf("unknown resident country %q", name))
+		}
+	}
+
+	var dob *time.Time
+	if value := record["dob"]; value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			errs = append(errs, "dob must be YYYY-MM-DD")
+		} else if parsed.After(time.Now()) {
+			errs = append(errs, "dob is in the future")
+		} else {
+			dob = &parsed
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	// a volunteer without password gets one nobody knows and an invite to choose theirs
+	password := record["password"]
+	var invite *dto.VolunteerImport
+	if password == "" {
+		var err error
+		if invite, err = newImportInvite(); err != nil {
+			return nil, []string{fmt.Sprintf("cannot create the invite: %v", err)}
+		}
+		if password, err = randomToken(16); err != nil {
+			return nil, []string{fmt.Sprintf("cannot generate a password: %v", err)}
+		}
+	}
+	roleID := 2
+	volunteer := &dto.VolunteerImport{
+		User: domain.User{
+			RoleID:             &roleID,
+			DepartmentID:       &departmentID,
+			Email:              email,
+			Password:           password,
+			Name:               record["name"],
+			Surname:            record["surname"],
+			Gender:             optionalValue(record["gender"]),
+			Dob:                dob,
+			Mobile:             optionalValue(record["mobile"]),
+			CountryID:          &countryID,
+			ResidentCountryID:  &residentCountryID,
+			VerificationStatus: 1,
+			Status:             1,
+		},
+		DepartmentID: departmentID,
+	}
+	if invite != nil {
+		volunteer.Invite, volunteer.InviteToken = invite.Invite, invite.InviteToken
+	}
+	return volunteer, nil
+}
+
+// newImportInvite returns an invite valid for InviteTTL with its token
+func newImportInvite() (*dto.VolunteerImport, error) {
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(token))
+	return &dto.VolunteerImport{
+		Invite:      &domain.UserInvite{TokenHash: hex.EncodeToString(sum[:]), ExpiresAt: time.Now().Add(InviteTTL)},
+		InviteToken: token,
+	}, nil
+}
+
+func indexByName(items []dto.NamedID) map[string]int {
+	index := make(map[string]int, len(items))
+	for _, item := range items {
+		index[strings.ToLower(strings.TrimSpace(item.Name))] = item.ID
+	}
+	return index
+}
+
+func optionalValue(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// randomToken returns size random bytes hex encoded
+func randomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
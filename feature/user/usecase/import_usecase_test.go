@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockImportRepository struct {
+	mock.Mock
+}
+
+func (m *mockImportRepository) ListDepartments(ctx context.Context) ([]dto.NamedID, error) {
+	args := m.Called()
+	return args.Get(0).([]dto.NamedID), args.Error(1)
+}
+
+func (m *mockImportRepository) ListCountries(ctx context.Context) ([]dto.NamedID, error) {
+	args := m.Called()
+	return args.Get(0).([]dto.NamedID), args.Error(1)
+}
+
+func (m *mockImportRepository) FindExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	args := m.Called(emails)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockImportRepository) CreateVolunteers(ctx context.Context, volunteers []*dto.VolunteerImport) error {
+	args := m.Called(volunteers)
+	return args.Error(0)
+}
+
+func newImportFixture(existing ...string) *mockImportRepository {
+	repo := new(mockImportRepository)
+	repo.On("ListDepartments").Return([]dto.NamedID{{ID: 1, Name: "Logistics"}, {ID: 2, Name: "Kitchen"}}, nil)
+	repo.On("ListCountries").Return([]dto.NamedID{{ID: 84, Name: "Vietnam"}, {ID: 33, Name: "France"}}, nil)
+	repo.On("FindExistingEmails", mock.Anything).Return(append([]string{}, existing...), nil)
+	return repo
+}
+
+func TestImportVolunteers_Creates(t *testing.T) {
+	repo := newImportFixture()
+	usecase := NewImportUsecase(repo)
+	csv := "Email,Name,Surname,Department,Country,Resident_Country,DOB\n" +
+		"An@Example.com,An,Nguyen,logistics,vietnam,France,1990-02-03\n" +
+		"\n" +
+		"bo@example.com,Bo,Tran,Kitchen,Vietnam,,\n"
+	repo.On("CreateVolunteers", mock.MatchedBy(func(volunteers []*dto.VolunteerImport) bool {
+		first := volunteers[0]
+		return len(volunteers) == 2 &&
+			first.User.Email == "an@example.com" && first.DepartmentID == 1 &&
+			*first.User.CountryID == 84 && *first.User.ResidentCountryID == 33 &&
+			*volunteers[1].User.ResidentCountryID == 84 && *first.User.RoleID == 2 &&
+			first.Invite != nil && first.Invite.TokenHash != first.InviteToken && first.User.Password != ""
+	})).Return(nil)
+
+	report, err := usecase.ImportVolunteers(context.Background(), dto.AdminScope{All: true}, strings.NewReader(csv), false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, ImportRowCreated, report.Rows[1].Status)
+	assert.Equal(t, 4, report.Rows[1].Row)
+	assert.Len(t, report.Rows[0].InviteToken, 64)
+	assert.WithinDuration(t, time.Now().Add(InviteTTL), *report.Rows[0].InviteExpiresAt, time.Minute)
+	repo.AssertExpectations(t)
+}
+
+func TestImportVolunteers_ReportsEveryError(t *testing.T) {
+	repo := newImportFixture("taken@example.com")
+	usecase := NewImportUsecase(repo)
+	csv := "email,name,surname,department,country,dob\n" +
+		"taken@example.com,A,B,Logistics,Vietnam,\n" +
+		"new@example.com,C,D,Kitchen,Vietnam,\n" +
+		"NEW@example.com,E,F,Kitchen,Vietnam,\n" +
+		"not-an-email,,G,Garden,Mars,03/02/1990\n" +
+		"ok@example.com,H,I,Logistics,Vietnam,\n"
+
+	report, err := usecase.ImportVolunteers(context.Background(), dto.AdminScope{DepartmentIDs: []int{2}}, strings.NewReader(csv), false)
+
+	assert.ErrorIs(t, err, ErrImportHasErrors)
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 1, report.Valid)
+	assert.Equal(t, []string{"email is already registered", `department "Logistics" is outside your departments`}, report.Rows[0].Errors)
+	assert.Equal(t, ImportRowValid, report.Rows[1].Status)
+	assert.Equal(t, []string{"email duplicates row 3"}, report.Rows[2].Errors)
+	assert.Len(t, report.Rows[3].Errors, 5)
+	repo.AssertNotCalled(t, "CreateVolunteers", mock.Anything)
+}
+
+func TestImportVolunteers_DryRun(t *testing.T) {
+	repo := newImportFixture()
+	usecase := NewImportUsecase(repo)
+	csv := "email,name,surname,department,country\nan@example.com,An,Nguyen,Logistics,Vietnam\n"
+
+	report, err := usecase.ImportVolunteers(context.Background(), dto.AdminScope{All: true}, strings.NewReader(csv), true)
+
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 0, report.Created)
+	assert.Equal(t, ImportRowValid, report.Rows[0].Status)
+	assert.Empty(t, report.Rows[0].InviteToken)
+	repo.AssertNotCalled(t, "CreateVolunteers", mock.Anything)
+}
+
+func TestImportVolunteers_MissingColumn(t *testing.T) {
+	usecase := NewImportUsecase(newImportFixture())
+
+	_, err := usecase.ImportVolunteers(context.Background(), dto.AdminScope{All: true}, strings.NewReader("email,name\na@example.com,A\n"), false)
+
+	assert.ErrorContains(t, err, `missing required column "surname"`)
+}
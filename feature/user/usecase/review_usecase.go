@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+)
+
+var (
+	ErrReviewCatalogForbidden = errors.New("forbidden: only admins can change the review catalog")
+	ErrChecklistItemInvalid   = errors.New("checklist item does not apply to this request")
+)
+
+// requestStatusNames is how request statuses are shown to applicants
+var requestStatusNames = map[int]string{0: "pending", 1: "approved", 2: "rejected"}
+
+type ReviewUsecaseInterface interface {
+	ListReasons(ctx context.Context, activeOnly bool) ([]dto.RejectionReasonResponseDTO, error)
+	CreateReason(ctx context.Context, input dto.RejectionReasonCreateDTO, scope dto.AdminScope) (*dto.RejectionReasonResponseDTO, error)
+	UpdateReason(ctx context.Context, id int, input dto.RejectionReasonUpdateDTO, scope dto.AdminScope) (*dto.RejectionReasonResponseDTO, error)
+	ListChecklistItems(ctx context.Context, requestType string, activeOnly bool) ([]dto.ChecklistItemResponseDTO, error)
+	CreateChecklistItem(ctx context.Context, input dto.ChecklistItemCreateDTO, scope dto.AdminScope) (*dto.ChecklistItemResponseDTO, error)
+	UpdateChecklistItem(ctx context.Context, id int, input dto.ChecklistItemUpdateDTO, scope dto.AdminScope) (*dto.ChecklistItemResponseDTO, error)
+	GetRequestReview(ctx context.Context, id int, scope dto.AdminScope) (*dto.RequestReview, error)
+	CheckItem(ctx context.Context, id int, itemID int, checked bool, reviewerID int, scope dto.AdminScope) (*dto.RequestReview, error)
+	GetApplicantRequests(ctx context.Context, userID int) ([]dto.ApplicantRequestStatus, error)
+}
+
+type ReviewUsecase struct {
+	repo storage.ReviewRepositoryInterface
+}
+
+func NewReviewUsecase(repo storage.ReviewRepositoryInterface) *ReviewUsecase {
+	return &ReviewUsecase{repo: repo}
+}
+
+func (u *ReviewUsecase) ListReasons(ctx context.Context, activeOnly bool) ([]dto.RejectionReasonResponseDTO, error) {
+	reasons, err := u.repo.ListReasons(ctx, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.RejectionReasonResponseDTO, 0, len(reasons))
+	for i := range reasons {
+		response = append(response, reasonResponse(&reasons[i]))
+	}
+	return response, nil
+}
+
+func (u *ReviewUsecase) CreateReason(ctx context.Context, input dto.RejectionReasonCreateDTO, scope dto.AdminScope) (*dto.RejectionReasonResponseDTO, error) {
+	if !scope.All {
+		return nil, ErrReviewCatalogForbidden
+	}
+	reason := &domain.RejectionReason{
+		Code:             strings.ToUpper(strings.TrimSpace(input.Code)),
+		Label:            input.Label,
+		ApplicantMessage: input.ApplicantMessage,
+		RequestType:      input.RequestType,
+		Status:           1,
+	}
+	if err := u.repo.CreateReason(ctx, reason); err != nil {
+		return nil, err
+	}
+	response := reasonResponse(reason)
+	return &response, nil
+}
+
+// UpdateReason changes a catalog entry, the code is kept so reasons already given stay meaningful
+func (u *ReviewUsecase) UpdateReason(ctx context.Context, id int, input dto.RejectionReasonUpdateDTO, scope dto.AdminScope) (*dto.RejectionReasonResponseDTO, error) {
+	if !scope.All {
+		return nil, ErrReviewCatalogForbidden
+	}
+	reason, err := u.repo.FindReason(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	reason.Label = input.Label
+	reason.ApplicantMessage = input.ApplicantMessage
+	reason.RequestType = input.RequestType
+	reason.Status = input.Status
+	if err := u.repo.UpdateReason(ctx, reason); err != nil {
+		return nil, err
+	}
+	response := reasonResponse(reason)
+	return &response, nil
+}
+
+func (u *ReviewUsecase) ListChecklistItems(ctx context.Context, requestType string, activeOnly bool) ([]dto.ChecklistItemResponseDTO, error) {
+	items, err := u.repo.ListChecklistItems(ctx, requestType, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.ChecklistItemResponseDTO, 0, len(items))
+	for i := range items {
+		response = append(response, checklistItemResponse(&items[i]))
+	}
+	return response, nil
+}
+
+func (u *ReviewUsecase) CreateChecklistItem(ctx context.Context, input dto.ChecklistItemCreateDTO, scope dto.AdminScope) (*dto.ChecklistItemResponseDTO, error) {
+	if !scope.All {
+		return nil, ErrReviewCatalogForbidden
+	}
+	item := &domain.ChecklistItem{
+		RequestType: input.RequestType,
+		Label:       input.Label,
+		Position:    input.Position,
+		Status:      1,
+	}
+	if err := u.repo.CreateChecklistItem(ctx, item); err != nil {
+		return nil, err
+	}
+	response := checklistItemResponse(item)
+	return &response, nil
+}
+
+func (u *ReviewUsecase) UpdateChecklistItem(ctx context.Context, id int, input dto.ChecklistItemUpdateDTO, scope dto.AdminScope) (*dto.ChecklistItemResponseDTO, error) {
+	if !scope.All {
+		return nil, ErrReviewCatalogForbidden
+	}
+	item, err := u.repo.FindChecklistItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	item.Label = input.Label
+	item.Position = input.Position
+	item.Status = input.Status
+	if err := u.repo.UpdateChecklistItem(ctx, item); err != nil {
+		return nil, err
+	}
+	response := checklistItemResponse(item)
+	return &response, nil
+}
+
+// GetRequestReview returns the checklist of the request with its progress and the rejection reasons given
+func (u *ReviewUsecase) GetRequestReview(ctx context.Context, id int, scope dto.AdminScope) (*dto.RequestReview, error) {
+	request, err := u.repo.FindRequest(ctx, id, scope)
+	if err != nil {
+		return nil, err
+	}
+	return u.review(ctx, request)
+}
+
+// CheckItem ticks or unticks a checklist item, only while the request is pending and not held by someone else
+func (u *ReviewUsecase) CheckItem(ctx context.Context, id int, itemID int, checked bool, reviewerID int, scope dto.AdminScope) (*dto.RequestReview, error) {
+	request, err := u.repo.FindRequest(ctx, id, scope)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != 0 {
+		return nil, storage.ErrRequestNotPending
+	}
+	if holder := request.ClaimedBy(time.Now()); holder != nil && *holder != reviewerID {
+		return nil, storage.ErrRequestClaimed
+	}
+	item, err := u.repo.FindChecklistItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != 1 || item.RequestType != strings.TrimSpace(request.Type) {
+		return nil, ErrChecklistItemInvalid
+	}
+	if err := u.repo.SetChecklistItem(ctx, id, itemID, checked, reviewerID); err != nil {
+		return nil, err
+	}
+	return u.review(ctx, request)
+}
+
+// GetApplicantRequests renders the requests of a user with the applicant-facing wording of rejection reasons
+func (u *ReviewUsecase) GetApplicantRequests(ctx context.Context, userID int) ([]dto.ApplicantRequestStatus, error) {
+	requests, err := u.repo.ListUserRequests(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]dto.ApplicantRequestStatus, 0, len(requests))
+	if len(requests) == 0 {
+		return statuses, nil
+	}
+	ids := make([]int, 0, len(requests))
+	for _, request := range requests {
+		ids = append(ids, request.ID)
+	}
+	reasons, err := u.repo.ListRequestReasons(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byRequest := make(map[int][]dto.ApplicantRejectionReason)
+	for _, reason := range reasons {
+		byRequest[reason.RequestID] = append(byRequest[reason.RequestID], dto.ApplicantRejectionReason{
+			Message: reason.ApplicantMessage,
+			Field:   reason.Field,
+			Comment: reason.Comment,
+		})
+	}
+	for _, request := range requests {
+		status := dto.ApplicantRequestStatus{
+			ID:          request.ID,
+			Type:        strings.TrimSpace(request.Type),
+			Status:      requestStatusNames[request.Status],
+			SubmittedAt: request.CreatedAt,
+			DecidedAt:   request.DecidedAt,
+			Reasons:     []dto.ApplicantRejectionReason{},
+		}
+		if request.Status == 2 {
+			status.Notes = request.RejectNotes
+			if reasons := byRequest[request.ID]; reasons != nil {
+				status.Reasons = reasons
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (u *ReviewUsecase) review(ctx context.Context, request *domain.Request) (*dto.RequestReview, error) {
+	requestType := strings.TrimSpace(request.Type)
+	items, err := u.repo.ListChecklistItems(ctx, requestType, true)
+	if err != nil {
+		return nil, err
+	}
+	states, err := u.repo.ListRequestChecklist(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	byItem := make(map[int]domain.RequestChecklistItem, len(states))
+	for _, state := range states {
+		byItem[state.ChecklistItemID] = state
+	}
+	reasons, err := u.repo.ListRequestReasons(ctx, []int{request.ID})
+	if err != nil {
+		return nil, err
+	}
+	if reasons == nil {
+		reasons = []dto.RejectionReasonDetail{}
+	}
+
+	review := &dto.RequestReview{
+		RequestID:         request.ID,
+		Type:              requestType,
+		Status:            request.Status,
+		ChecklistComplete: true,
+		Checklist:         make([]dto.ChecklistItemState, 0, len(items)),
+		Reasons:           reasons,
+	}
+	for _, item := range items {
+		state := byItem[item.ID]
+		review.Checklist = append(review.Checklist, dto.ChecklistItemState{
+			ItemID:    item.ID,
+			Label:     item.Label,
+			Position:  item.Position,
+			Checked:   state.Checked,
+			CheckedBy: state.CheckedBy,
+			CheckedAt: state.CheckedAt,
+		})
+		if !state.Checked {
+			review.ChecklistComplete = false
+		}
+	}
+	return review, nil
+}
+
+func reasonResponse(reason *domain.RejectionReason) dto.RejectionReasonResponseDTO {
+	return dto.RejectionReasonResponseDTO{
+		ID:               reason.ID,
+		Code:             reason.Code,
+		Label:            reason.Label,
+		ApplicantMessage: reason.ApplicantMessage,
+		RequestType:      reason.RequestType,
+		Status:           reason.Status,
+	}
+}
+
+func checklistItemResponse(item *domain.ChecklistItem) dto.ChecklistItemResponseDTO {
+	return dto.ChecklistItemResponseDTO{
+		ID:          item.ID,
+		RequestType: item.RequestType,
+		Label:       item.Label,
+		Position:    item.Position,
+		Status:      item.Status,
+	}
+}
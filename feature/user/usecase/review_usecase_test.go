@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockReviewRepository struct {
+	mock.Mock
+}
+
+func (m *mockReviewRepository) ListReasons(ctx context.Context, activeOnly bool) ([]domain.RejectionReason, error) {
+	args := m.Called(activeOnly)
+	return args.Get(0).([]domain.RejectionReason), args.Error(1)
+}
+
+func (m *mockReviewRepository) FindReason(ctx context.Context, id int) (*domain.RejectionReason, error) {
+	args := m.Called(id)
+	reason, _ := args.Get(0).(*domain.RejectionReason)
+	return reason, args.Error(1)
+}
+
+func (m *mockReviewRepository) CreateReason(ctx context.Context, reason *domain.RejectionReason) error {
+	return m.Called(reason).Error(0)
+}
+
+func (m *mockReviewRepository) UpdateReason(ctx context.Context, reason *domain.RejectionReason) error {
+	return m.Called(reason).Error(0)
+}
+
+func (m *mockReviewRepository) ListChecklistItems(ctx context.Context, requestType string, activeOnly bool) ([]domain.ChecklistItem, error) {
+	args := m.Called(requestType, activeOnly)
+	return args.Get(0).([]domain.ChecklistItem), args.Error(1)
+}
+
+func (m *mockReviewRepository) FindChecklistItem(ctx context.Context, id int) (*domain.ChecklistItem, error) {
+	args := m.Called(id)
+	item, _ := args.Get(0).(*domain.ChecklistItem)
+	return item, args.Error(1)
+}
+
+func (m *mockReviewRepository) CreateChecklistItem(ctx context.Context, item *domain.ChecklistItem) error {
+	return m.Called(item).Error(0)
+}
+
+func (m *mockReviewRepository) UpdateChecklistItem(ctx context.Context, item *domain.ChecklistItem) error {
+	return m.Called(item).Error(0)
+}
+
+func (m *mockReviewRepository) FindRequest(ctx context.Context, id int, scope dto.AdminScope) (*domain.Request, error) {
+	args := m.Called(id, scope)
+	request, _ := args.Get(0).(*domain.Request)
+	return request, args.Error(1)
+}
+
+func (m *mockReviewRepository) ListRequestChecklist(ctx context.Context, requestID int) ([]domain.RequestChecklistItem, error) {
+	args := m.Called(requestID)
+	return args.Get(0).([]domain.RequestChecklistItem), args.Error(1)
+}
+
+func (m *mockReviewRepository) SetChecklistItem(ctx context.Context, requestID int, itemID int, checked bool, reviewerID int) error {
+	return m.Called(requestID, itemID, checked, reviewerID).Error(0)
+}
+
+func (m *mockReviewRepository) ListRequestReasons(ctx context.Context, requestIDs []int) ([]dto.RejectionReasonDetail, error) {
+	args := m.Called(requestIDs)
+	return args.Get(0).([]dto.RejectionReasonDetail), args.Error(1)
+}
+
+func (m *mockReviewRepository) ListUserRequests(ctx context.Context, userID int) ([]*domain.Request, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*domain.Request), args.Error(1)
+}
+
+func TestCheckItem(t *testing.T) {
+	repo := new(mockReviewRepository)
+	usecase := NewReviewUsecase(repo)
+	scope := dto.AdminScope{All: true}
+
+	repo.On("FindRequest", 1, scope).Return(&domain.Request{ID: 1, Type: "verification "}, nil)
+	repo.On("FindChecklistItem", 4).Return(&domain.ChecklistItem{ID: 4, RequestType: "verification", Status: 1}, nil)
+	repo.On("FindChecklistItem", 5).Return(&domain.ChecklistItem{ID: 5, RequestType: "registration", Status: 1}, nil)
+	repo.On("SetChecklistItem", 1, 4, true, 7).Return(nil)
+	repo.On("ListChecklistItems", "verification", true).Return([]domain.ChecklistItem{
+		{ID: 4, Label: "Identity document is readable", Position: 1},
+		{ID: 6, Label: "Identity document is not expired", Position: 2},
+	}, nil)
+	repo.On("ListRequestChecklist", 1).Return([]domain.RequestChecklistItem{{ChecklistItemID: 4, Checked: true}}, nil)
+	repo.On("ListRequestReasons", []int{1}).Return([]dto.RejectionReasonDetail(nil), nil)
+
+	review, err := usecase.CheckItem(context.Background(), 1, 4, true, 7, scope)
+	assert.NoError(t, err)
+	assert.False(t, review.ChecklistComplete)
+	assert.Len(t, review.Checklist, 2)
+	assert.True(t, review.Checklist[0].Checked)
+	assert.NotNil(t, review.Reasons)
+
+	_, err = usecase.CheckItem(context.Background(), 1, 5, true, 7, scope)
+	assert.ErrorIs(t, err, ErrChecklistItemInvalid)
+}
+
+func TestCheckItem_ClaimedByAnotherReviewer(t *testing.T) {
+	repo := new(mockReviewRepository)
+	usecase := NewReviewUsecase(repo)
+	scope := dto.AdminScope{All: true}
+	holder := 8
+	expires := time.Now().Add(time.Hour)
+
+	repo.On("FindRequest", 1, scope).Return(&domain.Request{ID: 1, Type: "verification", AssigneeID: &holder, ClaimExpiresAt: &expires}, nil)
+
+	_, err := usecase.CheckItem(context.Background(), 1, 4, true, 7, scope)
+
+	assert.ErrorIs(t, err, storage.ErrRequestClaimed)
+}
+
+func TestGetApplicantRequests(t *testing.T) {
+	repo := new(mockReviewRepository)
+	usecase := NewReviewUsecase(repo)
+	field := "identity document"
+
+	repo.On("ListUserRequests", 3).Return([]*domain.Request{
+		{ID: 2, Type: "verification", Status: 2, RejectNotes: "please upload again"},
+		{ID: 1, Type: "registration", Status: 1},
+	}, nil)
+	repo.On("ListRequestReasons", []int{2, 1}).Return([]dto.RejectionReasonDetail{
+		{RequestID: 2, Code: "DOCUMENT_UNREADABLE", Label: "Identity document unreadable", ApplicantMessage: "We could not read your identity document.", Field: &field},
+	}, nil)
+
+	requests, err := usecase.GetApplicantRequests(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Len(t, requests, 2)
+	assert.Equal(t, "rejected", requests[0].Status)
+	assert.Equal(t, "please upload again", requests[0].Notes)
+	assert.Equal(t, []dto.ApplicantRejectionReason{{Message: "We could not read your identity document.", Field: &field}}, requests[0].Reasons)
+	assert.Equal(t, "approved", requests[1].Status)
+	assert.Empty(t, requests[1].Reasons)
+}
+
+func TestCreateReason_ManagersForbidden(t *testing.T) {
+	usecase := NewReviewUsecase(new(mockReviewRepository))
+
+	_, err := usecase.CreateReason(context.Background(), dto.RejectionReasonCreateDTO{Code: "x"}, dto.AdminScope{DepartmentIDs: []int{1}})
+
+	assert.ErrorIs(t, err, ErrReviewCatalogForbidden)
+}
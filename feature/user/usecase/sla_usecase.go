@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+)
+
+var ErrRequestTypeInvalid = errors.New("request type must be registration or verification")
+
+// requestTypes are the types a request can have, each one can have an SLA
+var requestTypes = map[string]bool{"registration": true, "verification": true}
+
+// SLANotifier tells reviewers about deadlines, implemented by the notification feature
+type SLANotifier interface {
+	NotifySLAReminder(ctx context.Context, userIDs []int, request *domain.Request, dueAt time.Time)
+	NotifySLABreached(ctx context.Context, userIDs []int, request *domain.Request, dueAt time.Time)
+}
+
+type SLAUsecaseInterface interface {
+	ListSLAs(ctx context.Context) ([]dto.RequestSLAResponseDTO, error)
+	UpdateSLA(ctx context.Context, requestType string, input dto.RequestSLAUpdateDTO, scope dto.AdminScope) (*dto.RequestSLAResponseDTO, error)
+	DeleteSLA(ctx context.Context, requestType string, scope dto.AdminScope) error
+}
+
+type SLAUsecase struct {
+	repo     storage.SLARepositoryInterface
+	notifier SLANotifier
+	now      func() time.Time
+}
+
+func NewSLAUsecase(repo storage.SLARepositoryInterface, notifier SLANotifier) *SLAUsecase {
+	return &SLAUsecase{repo: repo, notifier: notifier, now: time.Now}
+}
+
+func (u *SLAUsecase) ListSLAs(ctx context.Context) ([]dto.RequestSLAResponseDTO, error) {
+	slas, err := u.repo.ListSLAs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.RequestSLAResponseDTO, 0, len(slas))
+	for i := range slas {
+		response = append(response, slaResponse(&slas[i]))
+	}
+	return response, nil
+}
+
+// UpdateSLA sets the SLA of a request type. Requests already reminded or escalated are not
+// notified again under the new SLA.
+func (u *SLAUsecase) UpdateSLA(ctx context.Context, requestType string, input dto.RequestSLAUpdateDTO, scope dto.AdminScope) (*dto.RequestSLAResponseDTO, error) {
+	if !scope.All {
+		return nil, ErrReviewCatalogForbidden
+	}
+	if !requestTypes[requestType] {
+		return nil, ErrRequestTypeInvalid
+	}
+	sla := &domain.RequestSLA{RequestType: requestType, TargetHours: input.TargetHours, ReminderHours: input.ReminderHours}
+	if err := u.repo.SaveSLA(ctx, sla); err != nil {
+		return nil, err
+	}
+	response := slaResponse(sla)
+	return &response, nil
+}
+
+// DeleteSLA removes the SLA of a request type, its requests are no longer reminded nor escalated
+func (u *SLAUsecase) DeleteSLA(ctx context.Context, requestType string, scope dto.AdminScope) error {
+	if !scope.All {
+		return ErrReviewCatalogForbidden
+	}
+	return u.repo.DeleteSLA(ctx, requestType)
+}
+
+// CheckSLAs reminds the reviewers holding requests close to their deadline and tells the
+// department managers about the requests that breached it, it is the request-sla job.
+// Each request is reminded and escalated once.
+func (u *SLAUsecase) CheckSLAs(ctx context.Context) error {
+	slas, err := u.repo.ListSLAs(ctx)
+	if err != nil {
+		return err
+	}
+	now := u.now()
+	for _, sla := range slas {
+		if sla.ReminderHours > 0 {
+			if err := u.remind(ctx, sla, now); err != nil {
+				return err
+			}
+		}
+		if err := u.escalate(ctx, sla, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *SLAUsecase) remind(ctx context.Context, sla domain.RequestSLA, now time.Time) error {
+	requests, err := u.repo.ListReminderDue(ctx, sla, now)
+	if err != nil {
+		return err
+	}
+	for _, request := range requests {
+		reviewer := request.ClaimedBy(now)
+		if reviewer == nil {
+			continue
+		}
+		marked, err := u.repo.MarkReminded(ctx, request.ID, now)
+		if err != nil {
+			return err
+		}
+		if marked {
+			u.notifier.NotifySLAReminder(ctx, []int{*reviewer}, request, sla.DueAt(request.CreatedAt))
+		}
+	}
+	return nil
+}
+
+func (u *SLAUsecase) escalate(ctx context.Context, sla domain.RequestSLA, now time.Time) error {
+	requests, err := u.repo.ListBreached(ctx, sla, now)
+	if err != nil {
+		return err
+	}
+	for _, request := range requests {
+		recipients, err := u.repo.EscalationRecipients(ctx, request.UserID)
+		if err != nil {
+			slog.ErrorContext(ctx, "cannot find who to escalate the request to", "request", request.ID, "error", err)
+			continue
+		}
+		marked, err := u.repo.MarkEscalated(ctx, request.ID, now)
+		if err != nil {
+			return err
+		}
+		if marked {
+			u.notifier.NotifySLABreached(ctx, recipients, request, sla.DueAt(request.CreatedAt))
+		}
+	}
+	return nil
+}
+
+func slaResponse(sla *domain.RequestSLA) dto.RequestSLAResponseDTO {
+	return dto.RequestSLAResponseDTO{
+		RequestType:   sla.RequestType,
+		TargetHours:   sla.TargetHours,
+		ReminderHours: sla.ReminderHours,
+	}
+}
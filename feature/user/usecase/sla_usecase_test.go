@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockSLARepository struct {
+	mock.Mock
+}
+
+func (m *mockSLARepository) ListSLAs(ctx context.Context) ([]domain.RequestSLA, error) {
+	args := m.Called()
+	slas, _ := args.Get(0).([]domain.RequestSLA)
+	return slas, args.Error(1)
+}
+
+func (m *mockSLARepository) SaveSLA(ctx context.Context, sla *domain.RequestSLA) error {
+	return m.Called(sla).Error(0)
+}
+
+func (m *mockSLARepository) DeleteSLA(ctx context.Context, requestType string) error {
+	return m.Called(requestType).Error(0)
+}
+
+func (m *mockSLARepository) ListReminderDue(ctx context.Context, sla domain.RequestSLA, now time.Time) ([]*domain.Request, error) {
+	args := m.Called(sla.RequestType)
+	requests, _ := args.Get(0).([]*domain.Request)
+	return requests, args.Error(1)
+}
+
+func (m *mockSLARepository) ListBreached(ctx context.Context, sla domain.RequestSLA, now time.Time) ([]*domain.Request, error) {
+	args := m.Called(sla.RequestType)
+	requests, _ := args.Get(0).([]*domain.Request)
+	return requests, args.Error(1)
+}
+
+func (m *mockSLARepository) MarkReminded(ctx context.Context, id int, at time.Time) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockSLARepository) MarkEscalated(ctx context.Context, id int, at time.Time) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockSLARepository) EscalationRecipients(ctx context.Context, userID int) ([]int, error) {
+	args := m.Called(userID)
+	recipients, _ := args.Get(0).([]int)
+	return recipients, args.Error(1)
+}
+
+type mockSLANotifier struct {
+	mock.Mock
+}
+
+func (m *mockSLANotifier) NotifySLAReminder(ctx context.Context, userIDs []int, request *domain.Request, dueAt time.Time) {
+	m.Called(userIDs, request.ID, dueAt)
+}
+
+func (m *mockSLANotifier) NotifySLABreached(ctx context.Context, userIDs []int, request *domain.Request, dueAt time.Time) {
+	m.Called(userIDs, request.ID, dueAt)
+}
+
+func TestCheckSLAs(t *testing.T) {
+	repo := new(mockSLARepository)
+	notifier := new(mockSLANotifier)
+	usecase := NewSLAUsecase(repo, notifier)
+	now := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	usecase.now = func() time.Time { return now }
+
+	reviewer := 7
+	claimExpiresAt := now.Add(time.Hour)
+	submitted := now.Add(-42 * time.Hour)
+	late := now.Add(-50 * time.Hour)
+	repo.On("ListSLAs").Return([]domain.RequestSLA{
+		{RequestType: "registration", TargetHours: 48, ReminderHours: 8},
+		{RequestType: "verification", TargetHours: 72},
+	}, nil)
+	repo.On("ListReminderDue", "registration").Return([]*domain.Request{
+		{ID: 1, UserID: 11, AssigneeID: &reviewer, ClaimExpiresAt: &claimExpiresAt, CreatedAt: submitted},
+		// reminded by an earlier run that failed to record the next one
+		{ID: 2, UserID: 12, AssigneeID: &reviewer, ClaimExpiresAt: &claimExpiresAt, CreatedAt: submitted},
+	}, nil)
+	repo.On("MarkReminded", 1).Return(true, nil)
+	repo.On("MarkReminded", 2).Return(false, nil)
+	repo.On("ListBreached", "registration").Return([]*domain.Request{{ID: 3, UserID: 13, CreatedAt: late}}, nil)
+	repo.On("ListBreached", "verification").Return(nil, nil)
+	repo.On("EscalationRecipients", 13).Return([]int{4, 5}, nil)
+	repo.On("MarkEscalated", 3).Return(true, nil)
+	notifier.On("NotifySLAReminder", []int{7}, 1, submitted.Add(48*time.Hour)).Return()
+	notifier.On("NotifySLABreached", []int{4, 5}, 3, late.Add(48*time.Hour)).Return()
+
+	assert.NoError(t, usecase.CheckSLAs(context.Background()))
+
+	repo.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+	notifier.AssertNumberOfCalls(t, "NotifySLAReminder", 1)
+	// verification has no reminder
+	repo.AssertNotCalled(t, "ListReminderDue", "verification")
+}
+
+func TestUpdateSLA(t *testing.T) {
+	repo := new(mockSLARepository)
+	usecase := NewSLAUsecase(repo, new(mockSLANotifier))
+	input := dto.RequestSLAUpdateDTO{TargetHours: 24, ReminderHours: 4}
+
+	_, err := usecase.UpdateSLA(context.Background(), "registration", input, dto.AdminScope{DepartmentIDs: []int{1}})
+	assert.ErrorIs(t, err, ErrReviewCatalogForbidden)
+	_, err = usecase.UpdateSLA(context.Background(), "transfer", input, dto.AdminScope{All: true})
+	assert.ErrorIs(t, err, ErrRequestTypeInvalid)
+
+	repo.On("SaveSLA", &domain.RequestSLA{RequestType: "registration", TargetHours: 24, ReminderHours: 4}).Return(nil)
+	sla, err := usecase.UpdateSLA(context.Background(), "registration", input, dto.AdminScope{All: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RequestSLAResponseDTO{RequestType: "registration", TargetHours: 24, ReminderHours: 4}, sla)
+	repo.AssertExpectations(t)
+}
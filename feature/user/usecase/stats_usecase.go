@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+)
+
+// defaultStatsWindow is used when no date range is given
+const defaultStatsWindow = 30 * 24 * time.Hour
+
+var ErrStatsRange = errors.New("from must be before to")
+
+type StatsUsecaseInterface interface {
+	GetStats(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter) (*dto.AdminStats, error)
+}
+
+type StatsUsecase struct {
+	repo storage.StatsRepositoryInterface
+}
+
+func NewStatsUsecase(repo storage.StatsRepositoryInterface) *StatsUsecase {
+	return &StatsUsecase{repo: repo}
+}
+
+// GetStats collects the dashboard figures, time based ones cover [filter.From, filter.To)
+func (u *StatsUsecase) GetStats(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter) (*dto.AdminStats, error) {
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-defaultStatsWindow)
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, ErrStatsRange
+	}
+
+	stats := &dto.AdminStats{
+		From: filter.From.Format(time.RFC3339),
+		To:   filter.To.Format(time.RFC3339),
+	}
+	var err error
+	if stats.Requests, err = u.repo.CountRequestsByType(ctx, scope, filter); err != nil {
+		return nil, err
+	}
+	if stats.MedianDecisionSeconds, err = u.repo.MedianDecisionSeconds(ctx, scope, filter); err != nil {
+		return nil, err
+	}
+	if stats.RegistrationsPerDay, err = u.repo.CountRegistrations(ctx, scope, filter, false); err != nil {
+		return nil, err
+	}
+	if stats.RegistrationsPerWeek, err = u.repo.CountRegistrations(ctx, scope, filter, true); err != nil {
+		return nil, err
+	}
+	if stats.VolunteersByDepartment, err = u.repo.CountActiveVolunteersByDepartment(ctx, scope); err != nil {
+		return nil, err
+	}
+	if stats.VolunteersByCountry, err = u.repo.CountActiveVolunteersByCountry(ctx, scope); err != nil {
+		return nil, err
+	}
+	if stats.ReviewerThroughput, err = u.repo.ReviewerThroughput(ctx, scope, filter); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
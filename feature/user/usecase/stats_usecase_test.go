@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockStatsRepository struct {
+	mock.Mock
+}
+
+func (m *mockStatsRepository) CountRequestsByType(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter) ([]dto.RequestTypeCount, error) {
+	args := m.Called(scope, filter)
+	return args.Get(0).([]dto.RequestTypeCount), args.Error(1)
+}
+
+func (m *mockStatsRepository) MedianDecisionSeconds(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter) (*float64, error) {
+	args := m.Called(scope, filter)
+	median, _ := args.Get(0).(*float64)
+	return median, args.Error(1)
+}
+
+func (m *mockStatsRepository) CountRegistrations(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter, weekly bool) ([]dto.PeriodCount, error) {
+	args := m.Called(scope, filter, weekly)
+	return args.Get(0).([]dto.PeriodCount), args.Error(1)
+}
+
+func (m *mockStatsRepository) CountActiveVolunteersByDepartment(ctx context.Context, scope dto.AdminScope) ([]dto.GroupCount, error) {
+	args := m.Called(scope)
+	return args.Get(0).([]dto.GroupCount), args.Error(1)
+}
+
+func (m *mockStatsRepository) CountActiveVolunteersByCountry(ctx context.Context, scope dto.AdminScope) ([]dto.GroupCount, error) {
+	args := m.Called(scope)
+	return args.Get(0).([]dto.GroupCount), args.Error(1)
+}
+
+func (m *mockStatsRepository) ReviewerThroughput(ctx context.Context, scope dto.AdminScope, filter dto.StatsFilter) ([]dto.ReviewerThroughput, error) {
+	args := m.Called(scope, filter)
+	return args.Get(0).([]dto.ReviewerThroughput), args.Error(1)
+}
+
+func TestGetStats_DefaultWindow(t *testing.T) {
+	repo := new(mockStatsRepository)
+	usecase := NewStatsUsecase(repo)
+	scope := dto.AdminScope{DepartmentIDs: []int{2, 3}}
+	median := 3600.0
+
+	window := mock.MatchedBy(func(filter dto.StatsFilter) bool {
+		return filter.To.Sub(filter.From) == defaultStatsWindow && time.Since(filter.To) < time.Minute
+	})
+	repo.On("CountRequestsByType", scope, window).Return([]dto.RequestTypeCount{{Type: "registration", Pending: 2, Approved: 1}}, nil)
+	repo.On("MedianDecisionSeconds", scope, window).Return(&median, nil)
+	repo.On("CountRegistrations", scope, window, false).Return([]dto.PeriodCount{{Period: "2024-06-01", Count: 4}}, nil)
+	repo.On("CountRegistrations", scope, window, true).Return([]dto.PeriodCount{{Period: "2024-W22", Count: 4}}, nil)
+	repo.On("CountActiveVolunteersByDepartment", scope).Return([]dto.GroupCount{}, nil)
+	repo.On("CountActiveVolunteersByCountry", scope).Return([]dto.GroupCount{}, nil)
+	repo.On("ReviewerThroughput", scope, window).Return([]dto.ReviewerThroughput{}, nil)
+
+	stats, err := usecase.GetStats(context.Background(), scope, dto.StatsFilter{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3600.0, *stats.MedianDecisionSeconds)
+	assert.Equal(t, "2024-W22", stats.RegistrationsPerWeek[0].Period)
+	repo.AssertExpectations(t)
+}
+
+func TestGetStats_InvalidRange(t *testing.T) {
+	repo := new(mockStatsRepository)
+	usecase := NewStatsUsecase(repo)
+	now := time.Now()
+
+	_, err := usecase.GetStats(context.Background(), dto.AdminScope{All: true}, dto.StatsFilter{From: now, To: now.Add(-time.Hour)})
+
+	assert.ErrorIs(t, err, ErrStatsRange)
+	repo.AssertNotCalled(t, "CountRequestsByType", mock.Anything, mock.Anything)
+}
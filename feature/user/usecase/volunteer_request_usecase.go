@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
@@ -8,7 +9,7 @@ import (
 )
 
 type VolunteerRequestUsecaseInterface interface {
-	CreateVolunteerRequest(request dto.RequestCreatingDTO) error
+	CreateVolunteerRequest(ctx context.Context, request dto.RequestCreatingDTO) error
 }
 
 type VolunteerRequestUsecase struct {
@@ -19,7 +20,7 @@ func NewVolunteerRequestUsecase(volRequestRepo storage.VolunteerRequestRepositor
 	return &VolunteerRequestUsecase{VolRequestRepo: volRequestRepo}
 }
 
-func (u *VolunteerRequestUsecase) CreateVolunteerRequest(request dto.RequestCreatingDTO) error {
+func (u *VolunteerRequestUsecase) CreateVolunteerRequest(ctx context.Context, request dto.RequestCreatingDTO) error {
 	err := ValidateInput(request)
 	if err != nil {
 		return err
@@ -45,7 +46,7 @@ func (u *VolunteerRequestUsecase) CreateVolunteerRequest(request dto.RequestCrea
 		ResidentCountryID: request.ResidentCountryID,
 		RoleID:            &roleID,
 	}
-	return u.VolRequestRepo.CreateVolunteerRequest(reqRequest, reqUser)
+	return u.VolRequestRepo.CreateVolunteerRequest(ctx, reqRequest, reqUser)
 }
 
 func ValidateInput(request dto.RequestCreatingDTO) error {
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"testing"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
@@ -13,25 +14,42 @@ type mockVolunteerRequestRepository struct {
 	mock.Mock
 }
 
-func (m *mockVolunteerRequestRepository) CreateVolunteerRequest(volRequest *domain.VolunteerRequest) error {
-	args := m.Called(volRequest)
+func (m *mockVolunteerRequestRepository) CreateVolunteerRequest(ctx context.Context, reqRequest *domain.Request, reqUser *domain.User) error {
+	args := m.Called(reqRequest, reqUser)
 	return args.Error(0)
 }
 
-func TestCreateVolunteerRequest(t *testing.T)  {
+func TestCreateVolunteerRequest(t *testing.T) {
 	mockRepo := new(mockVolunteerRequestRepository)
 	usecase := NewVolunteerRequestUsecase(mockRepo)
-	
-	input := dto.VoluteerRequestCreatingDTO{
+
+	gender := "male"
+	mobile := "0912345678"
+	input := dto.RequestCreatingDTO{
 		UserID: 1,
-		Type:   "verification",
-		Status: 0,
+		Gender: &gender,
+		Mobile: &mobile,
 	}
 
-	mockRepo.On("CreateApplicantRequest", mock.Anything).Return(nil)
+	mockRepo.On("CreateVolunteerRequest", mock.MatchedBy(func(r *domain.Request) bool {
+		return r.UserID == 1 && r.Type == "verification" && r.Status == 0
+	}), mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == 1 && *u.RoleID == 2 && u.Dob == nil
+	})).Return(nil)
 
-	err := usecase.CreateVolunteerRequest(input)
+	err := usecase.CreateVolunteerRequest(context.Background(), input)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestCreateVolunteerRequest_InvalidGender(t *testing.T) {
+	mockRepo := new(mockVolunteerRequestRepository)
+	usecase := NewVolunteerRequestUsecase(mockRepo)
+
+	mobile := "0912345678"
+	err := usecase.CreateVolunteerRequest(context.Background(), dto.RequestCreatingDTO{UserID: 1, Mobile: &mobile})
+
+	assert.EqualError(t, err, "invalid gender")
+	mockRepo.AssertNotCalled(t, "CreateVolunteerRequest", mock.Anything, mock.Anything)
+}
@@ -1,14 +1,16 @@
 package storage
 
 import (
+	"context"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"gorm.io/gorm"
 )
 
 type UserIndentityRepositoryInterface interface {
-	CreateUserIdentity(identity *domain.UserIdentity) error
-	UpdateUserIdentity(identity *domain.UserIdentity) error
-	FindUserIdentityByID(id int) (*domain.UserIdentity, error)
+	CreateUserIdentity(ctx context.Context, identity *domain.UserIdentity) error
+	UpdateUserIdentity(ctx context.Context, identity *domain.UserIdentity) error
+	FindUserIdentityByID(ctx context.Context, id int) (*domain.UserIdentity, error)
 }
 
 type UserIdentityRepository struct {
@@ -19,17 +21,17 @@ func NewUserIdentityRepository(db *gorm.DB) *UserIdentityRepository {
 	return &UserIdentityRepository{DB: db}
 }
 
-func (r *UserIdentityRepository) CreateUserIdentity(identity *domain.UserIdentity) error {
-	return r.DB.Create(identity).Error
+func (r *UserIdentityRepository) CreateUserIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	return uow.Conn(ctx, r.DB).Create(identity).Error
 }
 
-func (r *UserIdentityRepository) UpdateUserIdentity(identity *domain.UserIdentity) error {
-	return r.DB.Save(identity).Error
+func (r *UserIdentityRepository) UpdateUserIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	return uow.Conn(ctx, r.DB).Save(identity).Error
 }
 
-func (r *UserIdentityRepository) FindUserIdentityByID(id int) (*domain.UserIdentity, error) {
+func (r *UserIdentityRepository) FindUserIdentityByID(ctx context.Context, id int) (*domain.UserIdentity, error) {
 	var identity domain.UserIdentity
-	if err := r.DB.First(&identity, id).Error; err != nil {
+	if err := uow.Conn(ctx, r.DB).First(&identity, id).Error; err != nil {
 		return nil, err
 	}
 	return &identity, nil
@@ -32,7 +32,7 @@ func (h *UserIdentityHandler) CreateUserIdentity(c *gin.Context) {
 		return
 	}
 
-	if err := h.UserIdentityUsecase.CreateUserIdentity(request); err != nil {
+	if err := h.UserIdentityUsecase.CreateUserIdentity(c.Request.Context(), request); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -62,7 +62,7 @@ func (h *UserIdentityHandler) UpdateUserIdentity(c *gin.Context) {
 		return
 	}
 
-	if err := h.UserIdentityUsecase.UpdateUserIdentity(id, request); err != nil {
+	if err := h.UserIdentityUsecase.UpdateUserIdentity(c.Request.Context(), id, request); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -85,7 +85,7 @@ func (h *UserIdentityHandler) FindUserIdentity(c *gin.Context) {
 		return
 	}
 
-	identity, err := h.UserIdentityUsecase.FindUserIdentityByID(id)
+	identity, err := h.UserIdentityUsecase.FindUserIdentityByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
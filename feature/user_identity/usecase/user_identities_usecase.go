@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"time"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
@@ -9,9 +10,9 @@ import (
 )
 
 type UserIdentityUsecaseInterface interface {
-	CreateUserIdentity(request dto.CreateUserIdentityRequest) error
-	UpdateUserIdentity(id int, request dto.UpdateUserIdentityRequest) error
-	FindUserIdentityByID(id int) (*dto.UserIdentityResponse, error)
+	CreateUserIdentity(ctx context.Context, request dto.CreateUserIdentityRequest) error
+	UpdateUserIdentity(ctx context.Context, id int, request dto.UpdateUserIdentityRequest) error
+	FindUserIdentityByID(ctx context.Context, id int) (*dto.UserIdentityResponse, error)
 }
 
 type UserIdentityUsecase struct {
@@ -22,7 +23,7 @@ func NewUserIdentityUsecase(userIdentityRepo storage.UserIndentityRepositoryInte
 	return &UserIdentityUsecase{UserIdentityRepo: userIdentityRepo}
 }
 
-func (u *UserIdentityUsecase) CreateUserIdentity(request dto.CreateUserIdentityRequest) error {
+func (u *UserIdentityUsecase) CreateUserIdentity(ctx context.Context, request dto.CreateUserIdentityRequest) error {
 	expiryDate, err := time.Parse("2006-01-02", request.ExpiryDate)
 	if err != nil {
 		return err
@@ -36,10 +37,10 @@ func (u *UserIdentityUsecase) CreateUserIdentity(request dto.CreateUserIdentityR
 		ExpiryDate:  expiryDate,
 		PlaceIssued: request.PlaceIssued,
 	}
-	return u.UserIdentityRepo.CreateUserIdentity(identity)
+	return u.UserIdentityRepo.CreateUserIdentity(ctx, identity)
 }
 
-func (u *UserIdentityUsecase) UpdateUserIdentity(id int, request dto.UpdateUserIdentityRequest) error {
+func (u *UserIdentityUsecase) UpdateUserIdentity(ctx context.Context, id int, request dto.UpdateUserIdentityRequest) error {
 	expiryDate, err := time.Parse("2006-01-02", request.ExpiryDate)
 	if err != nil {
 		return err
@@ -53,12 +54,12 @@ func (u *UserIdentityUsecase) UpdateUserIdentity(id int, request dto.UpdateUserI
 		ExpiryDate:  expiryDate,
 		PlaceIssued: request.PlaceIssued,
 	}
-	return u.UserIdentityRepo.UpdateUserIdentity(identity)
+	return u.UserIdentityRepo.UpdateUserIdentity(ctx, identity)
 }
 
-func (u *UserIdentityUsecase) FindUserIdentityByID(id int) (*dto.UserIdentityResponse, error) {
+func (u *UserIdentityUsecase) FindUserIdentityByID(ctx context.Context, id int) (*dto.UserIdentityResponse, error) {
 
-	identity, err := u.UserIdentityRepo.FindUserIdentityByID(id)
+	identity, err := u.UserIdentityRepo.FindUserIdentityByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
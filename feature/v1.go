@@ -2,19 +2,31 @@ package feature
 
 import (
 	"net/http"
+	"time"
 
 	_ "github.com/cesc1802/onboarding-and-volunteer-service/docs"
+	auditStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/storage"
 	authStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
 	authTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/transport"
 	authUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/cache"
 	countryStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/country/storage"
 	countryTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/country/transport"
 	countryUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/country/usecase"
 	deptStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/storage"
 	deptTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/transport"
 	deptUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/event"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/health"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/metrics"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/middleware"
+	notificationStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/storage"
+	notificationTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/transport"
+	notificationUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/ratelimit"
 	roleStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/storage"
+	schedulerTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/scheduler/transport"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/security"
 	userStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
 	userTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/transport"
 	userUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
@@ -27,71 +39,189 @@ import (
 
 	roleTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/transport"
 	roleUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/usecase"
+	skillStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/skill/storage"
+	skillTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/skill/transport"
+	skillUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/skill/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/tracing"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
 	volunteerStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
 	volunteerTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/transport"
 	volunteerUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	webhookStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/storage"
+	webhookTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/transport"
+	webhookUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/worker"
+	"github.com/cesc1802/onboarding-and-volunteer-service/migration"
 
-	"github.com/cesc1802/share-module/system"
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
+// RegisterHandlerV1 registers the routes of the API on mono. The drain of the requests on
+// shutdown is added to workers and so are the background jobs unless WORKERS_IN_SERVER is false.
+//
 // @host localhost:8080
 // @BasePath /api/v1
-func RegisterHandlerV1(mono system.Service) {
+func RegisterHandlerV1(mono Service, workers *worker.Manager) error {
 	router := mono.Router()
+	bus := event.NewBus(mono.DB())
+	// added before the drain of the requests so it is stopped after it and waits for the
+	// subscribers of the events they published
+	workers.Add(worker.Worker{Name: "event-bus", Stop: bus.Drain})
+	requests := worker.NewRequests()
+	workers.Add(requests.Worker())
+	router.Use(requests.Middleware())
 	secretKey := authStorage.GetSecretKey()
-	router.Use(cors.Default())
+	metricsRegistry := metrics.New(mono.DB())
+	router.Use(middleware.RequestID(), tracing.Middleware(), tracing.ResponseTraceID(), middleware.AccessLog(mono.Slog()), metricsRegistry.Middleware())
+	if err := tracing.InstrumentDB(mono.DB()); err != nil {
+		mono.Slog().Error("cannot trace database queries", "error", err)
+	}
+	securityConfig, err := security.ConfigFromEnv()
+	if err != nil {
+		return err
+	}
+	if err := securityConfig.Apply(router); err != nil {
+		return err
+	}
 	// add swagger
-	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	if securityConfig.DocsEnabled {
+		router.GET(security.DocsPath+"*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, map[string]any{
 			"data": "success",
 		})
 	})
+	healthHandler := health.NewHandler(health.NewChecker(mono.DB(), migration.FS))
+	router.GET("/livez", healthHandler.Livez)
+	router.GET("/readyz", healthHandler.Readyz)
+	router.GET("/metrics", metricsRegistry.Handler())
+	rateLimitStore, err := ratelimit.StoreFromEnv()
+	if err != nil {
+		mono.Slog().Error("cannot use the rate limit store of REDIS_URL, limiting in memory", "error", err)
+		rateLimitStore = ratelimit.NewMemoryStore()
+	}
+	limiter := ratelimit.New(rateLimitStore)
+	// the public routes that create users and requests get tight limits per ip
+	loginLimit := limiter.Limit("login", ratelimit.Limit{Rate: 5, Per: time.Minute, Burst: 10}, ratelimit.ByIP)
+	registerLimit := limiter.Limit("register", ratelimit.Limit{Rate: 10, Per: time.Hour, Burst: 5}, ratelimit.ByIP)
+	submitLimit := limiter.Limit("submit", ratelimit.Limit{Rate: 30, Per: time.Hour, Burst: 10}, ratelimit.ByIP)
+	adminLimit := limiter.Limit("admin", ratelimit.Limit{Rate: 600, Per: time.Minute}, ratelimit.ByUser)
 	v1 := router.Group("/api/v1")
+	v1.Use(limiter.Limit("api", ratelimit.Limit{Rate: 300, Per: time.Minute}, ratelimit.ByAPIKey(ratelimit.APIKeyHeader, ratelimit.APIKeysFromEnv())))
+	referenceCache, err := cache.FromEnv()
+	if err != nil {
+		mono.Slog().Error("cannot use the cache of REDIS_URL, caching in memory", "error", err)
+		referenceCache = cache.NewLRU(cache.DefaultSize, cache.DefaultTTL)
+	}
+	unitOfWork := uow.New(mono.DB())
 	// Initialize repository
-	authRepo := authStorage.NewAuthenticationRepository(mono.DB())
-	userRepo := userStorage.NewAdminRepository(mono.DB())
+	authRepo := authStorage.NewAuthenticationRepository(mono.DB(), bus)
+	userRepo := userStorage.NewAdminRepository(mono.DB(), bus)
+	statsRepo := userStorage.NewStatsRepository(mono.DB())
+	exportRepo := userStorage.NewExportRepository(mono.DB())
+	importRepo := userStorage.NewImportRepository(mono.DB(), bus)
+	assignmentRepo := userStorage.NewAssignmentRepository(mono.DB())
+	reviewRepo := userStorage.NewReviewRepository(mono.DB())
+	commentRepo := userStorage.NewCommentRepository(mono.DB())
+	slaRepo := userStorage.NewSLARepository(mono.DB())
 	applicantRepo := userStorage.NewApplicantRepository(mono.DB())
 	applicantRequestRepo := userStorage.NewApplicantRequestRepository(mono.DB())
 	applicantIdentityRepo := appliIdentityStorage.NewUserIdentityRepository(mono.DB())
-	volunteerRepo := volunteerStorage.NewVolunteerRepository(mono.DB())
+	volunteerRepo := volunteerStorage.NewVolunteerRepository(mono.DB(), bus)
+	timeEntryRepo := volunteerStorage.NewTimeEntryRepository(mono.DB())
+	availabilityRepo := volunteerStorage.NewAvailabilityRepository(mono.DB())
+	shiftRepo := volunteerStorage.NewShiftRepository(mono.DB())
+	transferRepo := volunteerStorage.NewTransferRepository(mono.DB())
 	volunteerRequestRepo := userStorage.NewVolunteerRequestRepository(mono.DB())
 	roleRepo := roleStorage.NewRoleRepository(mono.DB())
 	deptRepo := deptStorage.NewDepartmentRepository(mono.DB())
 	countryRepo := countryStorage.NewCountryRepository(mono.DB())
+	skillRepo := skillStorage.NewSkillRepository(mono.DB())
+	notificationRepo := notificationStorage.NewNotificationRepository(mono.DB())
+	webhookRepo := webhookStorage.NewWebhookRepository(mono.DB())
+	auditRepo := auditStorage.NewAuditRepository()
 	// Initialize usecase
-	authUseCase := authUsecase.NewUserUsecase(authRepo, secretKey)
-	userUseCase := userUsecase.NewAdminUsecase(userRepo)
+	notificationUseCase := notificationUsecase.NewNotificationUsecase(notificationRepo, notificationUsecase.NewBroker())
+	webhookUseCase := webhookUsecase.NewWebhookUsecase(webhookRepo)
+	authUseCase := authUsecase.NewUserUsecase(authRepo, secretKey, metricsRegistry)
+	deptUseCase := deptUsecase.NewDepartmentUsecase(deptRepo, referenceCache)
+	attachmentStore := userStorage.NewLocalAttachmentStore(userStorage.GetAttachmentDir())
+	userUseCase := userUsecase.NewAdminUsecase(userRepo, deptUseCase, attachmentStore)
+	statsUseCase := userUsecase.NewStatsUsecase(statsRepo)
+	exportUseCase := userUsecase.NewExportUsecase(exportRepo)
+	importUseCase := userUsecase.NewImportUsecase(importRepo)
+	assignmentUseCase := userUsecase.NewAssignmentUsecase(assignmentRepo)
+	reviewUseCase := userUsecase.NewReviewUsecase(reviewRepo)
+	slaUseCase := userUsecase.NewSLAUsecase(slaRepo, notificationUseCase)
+	commentUseCase := userUsecase.NewCommentUsecase(commentRepo, attachmentStore, notificationUseCase)
 	applicantUseCase := userUsecase.NewApplicantUsecase(applicantRepo)
 	applicantRequestUseCase := userUsecase.NewApplicantRequestUsecase(applicantRequestRepo)
 	applicantIdenityUseCase := appliIdentityUsecase.NewUserIdentityUsecase(applicantIdentityRepo)
 	volunteerUseCase := volunteerUsecase.NewVolunteerUsecase(volunteerRepo)
+	timeEntryUseCase := volunteerUsecase.NewTimeEntryUsecase(timeEntryRepo, volunteerRepo, deptUseCase)
+	scheduleUseCase := volunteerUsecase.NewScheduleUsecase(availabilityRepo, shiftRepo, volunteerRepo, notificationUseCase, unitOfWork)
+	transferUseCase := volunteerUsecase.NewTransferUsecase(transferRepo, volunteerRepo, deptUseCase, notificationUseCase, unitOfWork)
 	volunteerRequestUseCase := userUsecase.NewVolunteerRequestUsecase(volunteerRequestRepo)
-	roleUseCase := roleUsecase.NewRoleUsecase(roleRepo)
-	deptUseCase := deptUsecase.NewDepartmentUsecase(deptRepo)
-	countryUseCase := countryUsecase.NewCountryUsecase(countryRepo)
+	roleUseCase := roleUsecase.NewRoleUsecase(roleRepo, referenceCache)
+	countryUseCase := countryUsecase.NewCountryUsecase(countryRepo, referenceCache)
+	skillUseCase := skillUsecase.NewSkillUsecase(skillRepo)
+	// Subscribe to domain events, sync subscribers run in the publishing transaction
+	bus.SubscribeAll(auditRepo.Record)
+	event.Subscribe(bus, volunteerRepo.OnRequestApproved)
+	// webhook deliveries are an outbox written with the change, Run sends them after commit
+	event.Subscribe(bus, webhookUseCase.OnRequestApproved)
+	event.Subscribe(bus, webhookUseCase.OnRequestRejected)
+	event.Subscribe(bus, webhookUseCase.OnVolunteerCreated)
+	event.Subscribe(bus, webhookUseCase.OnVolunteerStatusChanged)
+	event.Subscribe(bus, webhookUseCase.OnVolunteerDeleted)
+	event.SubscribeAsync(bus, metricsRegistry.OnRequestApproved)
+	event.SubscribeAsync(bus, metricsRegistry.OnRequestRejected)
+	event.SubscribeAsync(bus, notificationUseCase.OnRequestApproved)
+	event.SubscribeAsync(bus, notificationUseCase.OnRequestRejected)
+	schedulerUseCase, err := newScheduler(mono, webhookUseCase, slaUseCase)
+	if err != nil {
+		return err
+	}
+	if worker.InServer() {
+		registerJobs(workers, webhookUseCase, schedulerUseCase)
+	}
 	// Initialize handler
 	authHandler := authTransport.NewAuthenticationHandler(authUseCase)
 	userHandler := userTransport.NewAuthenticationHandler(userUseCase)
+	statsHandler := userTransport.NewStatsHandler(statsUseCase, userHandler)
+	exportHandler := userTransport.NewExportHandler(exportUseCase, userHandler)
+	importHandler := userTransport.NewImportHandler(importUseCase, userHandler)
+	assignmentHandler := userTransport.NewAssignmentHandler(assignmentUseCase, userHandler)
+	reviewHandler := userTransport.NewReviewHandler(reviewUseCase, userHandler)
+	commentHandler := userTransport.NewCommentHandler(commentUseCase, userHandler)
+	slaHandler := userTransport.NewSLAHandler(slaUseCase, userHandler)
 	applicantHandler := userTransport.NewApplicantHandler(applicantUseCase)
 	applicantRequestHandler := userTransport.NewApplicantRequestHandler(applicantRequestUseCase)
 	applicantIdentityHandler := appliIdentityTransport.NewUserIdentityHandler(applicantIdenityUseCase)
 	volunteerHandler := volunteerTransport.NewVolunteerHandler(volunteerUseCase)
+	timeEntryHandler := volunteerTransport.NewTimeEntryHandler(timeEntryUseCase)
+	scheduleHandler := volunteerTransport.NewScheduleHandler(scheduleUseCase)
+	transferHandler := volunteerTransport.NewTransferHandler(transferUseCase)
 	volunteerRequestHandler := userTransport.NewVolunteerRequestHandler(volunteerRequestUseCase)
 	roleHandler := roleTransport.NewRoleHandler(roleUseCase)
 	deptHandler := deptTransport.NewDepartmentHandler(deptUseCase)
 	countryHandler := countryTransport.NewCountryHandler(countryUseCase)
+	skillHandler := skillTransport.NewSkillHandler(skillUseCase)
+	notificationHandler := notificationTransport.NewNotificationHandler(notificationUseCase)
+	webhookHandler := webhookTransport.NewWebhookHandler(webhookUseCase)
+	schedulerHandler := schedulerTransport.NewSchedulerHandler(schedulerUseCase)
 	auth := v1.Group("/auth")
 	{
-		auth.POST("/login", authHandler.Login)
+		auth.POST("/login", loginLimit, authHandler.Login)
 
-		auth.POST("/register", authHandler.Register)
+		auth.POST("/register", registerLimit, authHandler.Register)
+
+		auth.POST("/accept-invite", registerLimit, authHandler.AcceptInvite)
 	}
 
 	admin := v1.Group("/admin")
-	admin.Use(middleware.AuthMiddleware(secretKey))
+	admin.Use(middleware.AuthMiddleware(secretKey), adminLimit)
 	{
 		admin.GET("/list-request", userHandler.GetListRequest)
 		admin.GET("/request/:id", userHandler.GetRequestById)
@@ -100,12 +230,43 @@ func RegisterHandlerV1(mono system.Service) {
 		admin.POST("/approve-request/:id", userHandler.ApproveRequest)
 		admin.POST("/reject-request/:id", userHandler.RejectRequest)
 		admin.POST("/add-reject-notes/:id", userHandler.AddRejectNotes)
+		admin.POST("/requests/bulk", userHandler.BulkDecide)
+		admin.GET("/requests/queue", assignmentHandler.GetQueue)
+		admin.POST("/requests/auto-assign", assignmentHandler.AutoAssign)
+		admin.POST("/requests/:id/claim", assignmentHandler.ClaimRequest)
+		admin.DELETE("/requests/:id/claim", assignmentHandler.ReleaseRequest)
+		admin.POST("/requests/:id/viewed", assignmentHandler.MarkViewed)
+		admin.POST("/requests/:id/reject", userHandler.RejectRequestWithReasons)
+		admin.GET("/requests/:id/review", reviewHandler.GetRequestReview)
+		admin.PUT("/requests/:id/checklist/:itemId", reviewHandler.CheckItem)
+		admin.GET("/requests/:id/comments", commentHandler.GetReviewerThread)
+		admin.POST("/requests/:id/comments", commentHandler.AddReviewerComment)
+		admin.POST("/requests/:id/comments/read", commentHandler.MarkReviewerThreadRead)
+		admin.GET("/requests/:id/attachments/:attachmentId", commentHandler.GetReviewerAttachment)
+		admin.GET("/rejection-reasons", reviewHandler.ListReasons)
+		admin.POST("/rejection-reasons", reviewHandler.CreateReason)
+		admin.PUT("/rejection-reasons/:id", reviewHandler.UpdateReason)
+		admin.GET("/checklist-items", reviewHandler.ListChecklistItems)
+		admin.POST("/checklist-items", reviewHandler.CreateChecklistItem)
+		admin.PUT("/checklist-items/:id", reviewHandler.UpdateChecklistItem)
+		admin.GET("/request-slas", slaHandler.ListSLAs)
+		admin.PUT("/request-slas/:type", slaHandler.UpdateSLA)
+		admin.DELETE("/request-slas/:type", slaHandler.DeleteSLA)
 		admin.DELETE("/delete-request/:id", userHandler.DeleteRequest)
+		admin.GET("/list-volunteer", userHandler.GetListVolunteer)
+		admin.GET("/stats", statsHandler.GetStats)
+		admin.GET("/export/requests", exportHandler.ExportRequests)
+		admin.GET("/export/volunteers", exportHandler.ExportVolunteers)
+		admin.GET("/export/applicants", exportHandler.ExportApplicants)
+		admin.POST("/import/volunteers", importHandler.ImportVolunteers)
+		admin.GET("/jobs", schedulerHandler.ListJobs)
+		admin.POST("/jobs/:name/run", schedulerHandler.TriggerJob)
+		admin.GET("/jobs/:name/runs", schedulerHandler.ListJobRuns)
 	}
 
 	applicant := v1.Group("/applicant")
 	{
-		applicant.POST("/", applicantHandler.CreateApplicant)
+		applicant.POST("/", submitLimit, applicantHandler.CreateApplicant)
 		applicant.PUT("/:id", applicantHandler.UpdateApplicant)
 		applicant.DELETE("/:id", applicantHandler.DeleteApplicant)
 		applicant.GET("/:id", applicantHandler.FindApplicantByID)
@@ -113,12 +274,17 @@ func RegisterHandlerV1(mono system.Service) {
 
 	appliRequest := v1.Group("/applicant-request")
 	{
-		appliRequest.POST("/", applicantRequestHandler.CreateApplicantRequest)
+		appliRequest.POST("/", submitLimit, applicantRequestHandler.CreateApplicantRequest)
+		appliRequest.GET("/mine", middleware.AuthMiddleware(secretKey), reviewHandler.GetMyRequests)
+		appliRequest.GET("/:id/comments", middleware.AuthMiddleware(secretKey), commentHandler.GetRequesterThread)
+		appliRequest.POST("/:id/comments", middleware.AuthMiddleware(secretKey), commentHandler.AddRequesterComment)
+		appliRequest.POST("/:id/comments/read", middleware.AuthMiddleware(secretKey), commentHandler.MarkRequesterThreadRead)
+		appliRequest.GET("/:id/attachments/:attachmentId", middleware.AuthMiddleware(secretKey), commentHandler.GetRequesterAttachment)
 	}
 
 	appliIdentity := v1.Group("applicant-identity")
 	{
-		appliIdentity.POST("/", applicantIdentityHandler.CreateUserIdentity)
+		appliIdentity.POST("/", submitLimit, applicantIdentityHandler.CreateUserIdentity)
 		appliIdentity.GET("/:id", applicantIdentityHandler.FindUserIdentity)
 		appliIdentity.PUT("/:id", applicantIdentityHandler.UpdateUserIdentity)
 	}
@@ -130,11 +296,58 @@ func RegisterHandlerV1(mono system.Service) {
 		volunteer.DELETE("/:id", volunteerHandler.DeleteVolunteer)
 		volunteer.GET("/:id", volunteerHandler.FindVolunteerByID)
 		volunteer.GET("/", volunteerHandler.GetAllVolunteers)
+		volunteer.GET("/:id/availability", scheduleHandler.GetAvailability)
+		volunteer.PUT("/:id/availability", scheduleHandler.UpdateAvailability)
+		volunteer.GET("/:id/blackouts", scheduleHandler.ListBlackouts)
+		volunteer.POST("/:id/blackouts", scheduleHandler.CreateBlackout)
+		volunteer.DELETE("/:id/blackouts/:blackoutId", scheduleHandler.DeleteBlackout)
+		volunteer.GET("/:id/skills", skillHandler.GetVolunteerSkills)
+		volunteer.PUT("/:id/skills", skillHandler.UpdateVolunteerSkills)
+		volunteer.POST("/:id/skills/:skillId/verify", middleware.AuthMiddleware(secretKey), skillHandler.VerifyVolunteerSkill)
+		volunteer.POST("/:id/transfers", middleware.AuthMiddleware(secretKey), transferHandler.RequestTransfer)
+		volunteer.GET("/:id/memberships", middleware.AuthMiddleware(secretKey), transferHandler.ListMemberships)
+	}
+
+	transfer := v1.Group("/transfers")
+	transfer.Use(middleware.AuthMiddleware(secretKey))
+	{
+		transfer.GET("/", transferHandler.ListTransfers)
+		transfer.GET("/:id", transferHandler.FindTransferByID)
+		transfer.POST("/:id/approve", transferHandler.ApproveTransfer)
+		transfer.POST("/:id/reject", transferHandler.RejectTransfer)
+		transfer.POST("/:id/cancel", transferHandler.CancelTransfer)
+	}
+
+	shift := v1.Group("/shifts")
+	{
+		shift.GET("/", scheduleHandler.ListShifts)
+		shift.GET("/:id", scheduleHandler.FindShiftByID)
+	}
+	shiftAdmin := v1.Group("/shifts")
+	shiftAdmin.Use(middleware.AuthMiddleware(secretKey))
+	{
+		shiftAdmin.POST("/", scheduleHandler.CreateShift)
+		shiftAdmin.DELETE("/:id", scheduleHandler.DeleteShift)
+		shiftAdmin.GET("/:id/suggestions", scheduleHandler.SuggestVolunteers)
+		shiftAdmin.POST("/:id/assignments", scheduleHandler.AssignVolunteer)
+		shiftAdmin.DELETE("/:id/assignments/:volunteerId", scheduleHandler.UnassignVolunteer)
+	}
+
+	volHours := v1.Group("/volunteer-hours")
+	volHours.Use(middleware.AuthMiddleware(secretKey))
+	{
+		volHours.POST("/", timeEntryHandler.CreateTimeEntry)
+		volHours.GET("/", timeEntryHandler.ListTimeEntries)
+		volHours.GET("/totals/volunteers", timeEntryHandler.TotalsByVolunteer)
+		volHours.GET("/totals/departments", timeEntryHandler.TotalsByDepartment)
+		volHours.GET("/export", timeEntryHandler.ExportTimeEntries)
+		volHours.POST("/:id/approve", timeEntryHandler.ApproveTimeEntry)
+		volHours.POST("/:id/reject", timeEntryHandler.RejectTimeEntry)
 	}
 
 	volRequest := v1.Group("/volunteer-request")
 	{
-		volRequest.POST("/", volunteerRequestHandler.CreateVolunteerRequest)
+		volRequest.POST("/", submitLimit, volunteerRequestHandler.CreateVolunteerRequest)
 	}
 
 	role := v1.Group("/role")
@@ -148,11 +361,15 @@ func RegisterHandlerV1(mono system.Service) {
 
 	dept := v1.Group("/departments")
 	{
-		dept.POST("/", deptHandler.CreateDepartment)
-		dept.PUT("/:id", deptHandler.UpdateDepartment)
-		dept.DELETE("/:id", deptHandler.DeleteDepartment)
+		dept.POST("/", middleware.AuthMiddleware(secretKey), deptHandler.CreateDepartment)
+		dept.PUT("/:id", middleware.AuthMiddleware(secretKey), deptHandler.UpdateDepartment)
+		dept.DELETE("/:id", middleware.AuthMiddleware(secretKey), deptHandler.DeleteDepartment)
 		dept.GET("/:id", deptHandler.GetDepartmentByID)
 		dept.GET("/", deptHandler.GetAllDepartments)
+		dept.GET("/tree", deptHandler.GetDepartmentTree)
+		dept.GET("/:id/managers", deptHandler.GetManagers)
+		dept.POST("/:id/managers", middleware.AuthMiddleware(secretKey), deptHandler.AssignManager)
+		dept.DELETE("/:id/managers/:userId", middleware.AuthMiddleware(secretKey), deptHandler.RemoveManager)
 	}
 
 	country := v1.Group("/countries")
@@ -163,4 +380,37 @@ func RegisterHandlerV1(mono system.Service) {
 		country.GET("/:id", countryHandler.GetCountryByID)
 		country.GET("/", countryHandler.GetAllCountries)
 	}
+
+	skill := v1.Group("/skills")
+	{
+		skill.POST("/", skillHandler.CreateSkill)
+		skill.PUT("/:id", skillHandler.UpdateSkill)
+		skill.DELETE("/:id", skillHandler.DeleteSkill)
+		skill.GET("/:id", skillHandler.GetSkillByID)
+		skill.GET("/", skillHandler.GetAllSkills)
+		skill.POST("/match", skillHandler.MatchVolunteers)
+	}
+
+	notification := v1.Group("/notifications")
+	{
+		notification.GET("/", middleware.AuthMiddleware(secretKey), notificationHandler.ListNotifications)
+		notification.GET("/unread-count", middleware.AuthMiddleware(secretKey), notificationHandler.CountUnread)
+		notification.POST("/read", middleware.AuthMiddleware(secretKey), notificationHandler.MarkRead)
+		notification.POST("/read-all", middleware.AuthMiddleware(secretKey), notificationHandler.MarkAllRead)
+		notification.GET("/stream", requests.Stream(), middleware.TokenFromQuery(), middleware.AuthMiddleware(secretKey), notificationHandler.Stream)
+	}
+
+	webhook := v1.Group("/webhooks")
+	webhook.Use(middleware.AuthMiddleware(secretKey))
+	{
+		webhook.GET("/events", webhookHandler.ListEvents)
+		webhook.POST("/", webhookHandler.CreateWebhook)
+		webhook.GET("/", webhookHandler.ListWebhooks)
+		webhook.GET("/:id", webhookHandler.GetWebhook)
+		webhook.PUT("/:id", webhookHandler.UpdateWebhook)
+		webhook.DELETE("/:id", webhookHandler.DeleteWebhook)
+		webhook.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+		webhook.POST("/:id/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+	}
+	return nil
 }
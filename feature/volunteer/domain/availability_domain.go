@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+)
+
+// VolunteerAvailability is a weekly recurring window in which a volunteer can serve.
+// StartMinute and EndMinute are minutes since midnight.
+type VolunteerAvailability struct {
+	ID          int          `gorm:"primaryKey"`
+	VolunteerID int          `gorm:"index;notnull"`
+	Weekday     time.Weekday `gorm:"notnull"`
+	StartMinute int          `gorm:"notnull"`
+	EndMinute   int          `gorm:"notnull"`
+	CreatedAt   time.Time    `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time    `gorm:"autoUpdateTime"`
+}
+
+// Covers reports whether the window contains [start, end) of the same day
+func (a *VolunteerAvailability) Covers(start, end time.Time) bool {
+	if a.Weekday != start.Weekday() {
+		return false
+	}
+	return a.StartMinute <= minuteOfDay(start) && minuteOfDay(end) <= a.EndMinute
+}
+
+// VolunteerBlackout is a single date on which a volunteer is not available regardless of the weekly windows
+type VolunteerBlackout struct {
+	ID          int       `gorm:"primaryKey"`
+	VolunteerID int       `gorm:"index;notnull"`
+	Date        time.Time `gorm:"type:date;notnull"`
+	Reason      string
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func minuteOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
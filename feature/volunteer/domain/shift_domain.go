@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+)
+
+// Shift is a slot a department needs covered by up to Capacity volunteers
+type Shift struct {
+	ID           int       `gorm:"primaryKey"`
+	DepartmentID int       `gorm:"index;notnull"`
+	Name         string    `gorm:"notnull"`
+	StartTime    time.Time `gorm:"notnull"`
+	EndTime      time.Time `gorm:"notnull"`
+	Capacity     int       `gorm:"notnull"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
+
+// Overlaps reports whether the shift intersects [start, end)
+func (s *Shift) Overlaps(start, end time.Time) bool {
+	return s.StartTime.Before(end) && start.Before(s.EndTime)
+}
+
+type ShiftAssignment struct {
+	ID          int       `gorm:"primaryKey"`
+	ShiftID     int       `gorm:"index;notnull"`
+	VolunteerID int       `gorm:"index;notnull"`
+	AssignedBy  *int      `gorm:"index"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
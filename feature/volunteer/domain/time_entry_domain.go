@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+)
+
+// Time entry status values
+const (
+	TimeEntryPending  = 0
+	TimeEntryApproved = 1
+	TimeEntryRejected = 2
+)
+
+// TimeEntry is one block of time a volunteer contributed, either for an event or ad-hoc (EventID nil)
+type TimeEntry struct {
+	ID           int       `gorm:"primaryKey"`
+	VolunteerID  int       `gorm:"index;notnull"`
+	EventID      *int      `gorm:"index"`
+	DepartmentID int       `gorm:"index;notnull"`
+	StartTime    time.Time `gorm:"notnull"`
+	EndTime      time.Time `gorm:"notnull"`
+	Description  string
+	Status       int  `gorm:"notnull;default:0"`
+	ApproverID   *int `gorm:"index"`
+	ApprovedAt   *time.Time
+	RejectNotes  string
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
+
+// Minutes returns the duration of the entry in whole minutes
+func (e *TimeEntry) Minutes() int {
+	return int(e.EndTime.Sub(e.StartTime).Minutes())
+}
+
+// HoursTotal is an aggregated sum of approved minutes grouped by volunteer or department
+type HoursTotal struct {
+	VolunteerID  int
+	DepartmentID int
+	Minutes      int
+	Entries      int
+}
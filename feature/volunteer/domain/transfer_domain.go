@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+)
+
+// Department transfer status values
+const (
+	TransferPending   = 0
+	TransferApproved  = 1
+	TransferRejected  = 2
+	TransferCancelled = 3
+)
+
+// DepartmentTransfer is a request to move a volunteer to another department,
+// it is applied once managers of both the source and the target department approved it
+type DepartmentTransfer struct {
+	ID               int `gorm:"primaryKey"`
+	VolunteerID      int `gorm:"index;notnull"`
+	FromDepartmentID int `gorm:"index;notnull"`
+	ToDepartmentID   int `gorm:"index;notnull"`
+	RequestedBy      int `gorm:"notnull"`
+	Reason           string
+	Status           int `gorm:"notnull;default:0"`
+	SourceApprovedBy *int
+	SourceApprovedAt *time.Time
+	TargetApprovedBy *int
+	TargetApprovedAt *time.Time
+	DecidedBy        *int
+	DecidedAt        *time.Time
+	RejectNotes      string
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+}
+
+// FullyApproved reports whether both departments signed off
+func (t *DepartmentTransfer) FullyApproved() bool {
+	return t.SourceApprovedBy != nil && t.TargetApprovedBy != nil
+}
+
+// DepartmentMembership is one period a volunteer belonged to a department, EndDate is nil for the current one
+type DepartmentMembership struct {
+	ID           int        `gorm:"primaryKey"`
+	VolunteerID  int        `gorm:"index;notnull"`
+	DepartmentID int        `gorm:"index;notnull"`
+	StartDate    time.Time  `gorm:"type:date;notnull"`
+	EndDate      *time.Time `gorm:"type:date"`
+	TransferID   *int
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
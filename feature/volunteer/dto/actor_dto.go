@@ -0,0 +1,22 @@
+package dto
+
+// Actor is the authenticated user acting on volunteer records,
+// ManagedDepartments holds the department subtree they manage
+type Actor struct {
+	UserID             int
+	Admin              bool
+	ManagedDepartments []int
+}
+
+// Manages reports whether the actor may decide for departmentID
+func (a Actor) Manages(departmentID int) bool {
+	if a.Admin {
+		return true
+	}
+	for _, id := range a.ManagedDepartments {
+		if id == departmentID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+// AvailabilityWindowDTO is a weekly window, weekday 0 is Sunday and times are "HH:MM"
+type AvailabilityWindowDTO struct {
+	Weekday   int    `json:"weekday"`
+	StartTime string `json:"start_time" binding:"required"`
+	EndTime   string `json:"end_time" binding:"required"`
+}
+
+type AvailabilityUpdateDTO struct {
+	Windows []AvailabilityWindowDTO `json:"windows"`
+}
+
+type BlackoutCreateDTO struct {
+	Date   string `json:"date" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+type BlackoutResponseDTO struct {
+	ID     int    `json:"id"`
+	Date   string `json:"date"`
+	Reason string `json:"reason"`
+}
+
+type ShiftCreateDTO struct {
+	DepartmentID int       `json:"department_id" binding:"required"`
+	Name         string    `json:"name" binding:"required"`
+	StartTime    time.Time `json:"start_time" binding:"required"`
+	EndTime      time.Time `json:"end_time" binding:"required"`
+	Capacity     int       `json:"capacity" binding:"required"`
+}
+
+type ShiftResponseDTO struct {
+	ID           int       `json:"id"`
+	DepartmentID int       `json:"department_id"`
+	Name         string    `json:"name"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	Capacity     int       `json:"capacity"`
+	Assigned     []int     `json:"assigned_volunteer_ids"`
+}
+
+type ShiftAssignDTO struct {
+	VolunteerID int `json:"volunteer_id" binding:"required"`
+}
+
+// ShiftFilter narrows shift listings, zero values are ignored
+type ShiftFilter struct {
+	DepartmentID int
+	From         *time.Time
+	To           *time.Time
+}
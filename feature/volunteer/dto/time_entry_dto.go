@@ -0,0 +1,51 @@
+package dto
+
+import "time"
+
+type TimeEntryCreateDTO struct {
+	VolunteerID  int       `json:"volunteer_id" binding:"required"`
+	EventID      *int      `json:"event_id"`
+	DepartmentID int       `json:"department_id"`
+	StartTime    time.Time `json:"start_time" binding:"required"`
+	EndTime      time.Time `json:"end_time" binding:"required"`
+	Description  string    `json:"description"`
+}
+
+type TimeEntryRejectDTO struct {
+	Notes string `json:"notes"`
+}
+
+type TimeEntryResponseDTO struct {
+	ID           int        `json:"id"`
+	VolunteerID  int        `json:"volunteer_id"`
+	EventID      *int       `json:"event_id"`
+	DepartmentID int        `json:"department_id"`
+	StartTime    time.Time  `json:"start_time"`
+	EndTime      time.Time  `json:"end_time"`
+	Minutes      int        `json:"minutes"`
+	Description  string     `json:"description"`
+	Status       int        `json:"status"`
+	ApproverID   *int       `json:"approver_id"`
+	ApprovedAt   *time.Time `json:"approved_at"`
+	RejectNotes  string     `json:"reject_notes"`
+}
+
+// TimeEntryFilter narrows time entry queries, zero values are ignored
+type TimeEntryFilter struct {
+	VolunteerID  int
+	DepartmentID int
+	Status       *int
+	From         *time.Time
+	To           *time.Time
+	// Scope limits the entries to the ones of the actor as a volunteer and of the departments
+	// they manage, nil or an admin sees every entry
+	Scope *Actor
+}
+
+type HoursTotalDTO struct {
+	VolunteerID  int     `json:"volunteer_id,omitempty"`
+	DepartmentID int     `json:"department_id,omitempty"`
+	Minutes      int     `json:"minutes"`
+	Hours        float64 `json:"hours"`
+	Entries      int     `json:"entries"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+type TransferCreateDTO struct {
+	ToDepartmentID int    `json:"to_department_id" binding:"required"`
+	Reason         string `json:"reason"`
+}
+
+type TransferRejectDTO struct {
+	Notes string `json:"notes"`
+}
+
+type TransferResponseDTO struct {
+	ID               int        `json:"id"`
+	VolunteerID      int        `json:"volunteer_id"`
+	FromDepartmentID int        `json:"from_department_id"`
+	ToDepartmentID   int        `json:"to_department_id"`
+	RequestedBy      int        `json:"requested_by"`
+	Reason           string     `json:"reason"`
+	Status           int        `json:"status"`
+	SourceApprovedBy *int       `json:"source_approved_by"`
+	SourceApprovedAt *time.Time `json:"source_approved_at"`
+	TargetApprovedBy *int       `json:"target_approved_by"`
+	TargetApprovedAt *time.Time `json:"target_approved_at"`
+	DecidedBy        *int       `json:"decided_by"`
+	DecidedAt        *time.Time `json:"decided_at"`
+	RejectNotes      string     `json:"reject_notes"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type TransferFilter struct {
+	VolunteerID  int
+	DepartmentID int
+	Status       *int
+	// Scope limits the transfers to the ones of the actor as a volunteer and of the departments
+	// they manage on either side, nil or an admin sees every transfer
+	Scope *Actor
+}
+
+type MembershipResponseDTO struct {
+	DepartmentID int     `json:"department_id"`
+	StartDate    string  `json:"start_date"`
+	EndDate      *string `json:"end_date"`
+	TransferID   *int    `json:"transfer_id"`
+}
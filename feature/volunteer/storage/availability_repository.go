@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"gorm.io/gorm"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+)
+
+// AvailabilityRepositoryInterface defines the methods that an AvailabilityRepository should implement
+type AvailabilityRepositoryInterface interface {
+	ReplaceAvailability(ctx context.Context, volunteerID int, windows []*domain.VolunteerAvailability) error
+	ListAvailability(ctx context.Context, volunteerIDs []int) ([]*domain.VolunteerAvailability, error)
+	CreateBlackout(ctx context.Context, blackout *domain.VolunteerBlackout) error
+	DeleteBlackout(ctx context.Context, volunteerID int, id int) error
+	ListBlackouts(ctx context.Context, volunteerID int) ([]*domain.VolunteerBlackout, error)
+	ListBlackoutsOn(ctx context.Context, volunteerIDs []int, date time.Time) ([]*domain.VolunteerBlackout, error)
+}
+
+type AvailabilityRepository struct {
+	db *gorm.DB
+}
+
+func NewAvailabilityRepository(db *gorm.DB) *AvailabilityRepository {
+	return &AvailabilityRepository{db: db}
+}
+
+// ReplaceAvailability swaps the whole weekly schedule of a volunteer in one transaction
+func (r *AvailabilityRepository) ReplaceAvailability(ctx context.Context, volunteerID int, windows []*domain.VolunteerAvailability) error {
+	return uow.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("volunteer_id = ?", volunteerID).Delete(&domain.VolunteerAvailability{}).Error; err != nil {
+			return err
+		}
+		if len(windows) == 0 {
+			return nil
+		}
+		return tx.Create(&windows).Error
+	})
+}
+
+func (r *AvailabilityRepository) ListAvailability(ctx context.Context, volunteerIDs []int) ([]*domain.VolunteerAvailability, error) {
+	var windows []*domain.VolunteerAvailability
+	err := uow.Conn(ctx, r.db).Where("volunteer_id IN ?", volunteerIDs).Order("weekday, start_minute").Find(&windows).Error
+	return windows, err
+}
+
+func (r *AvailabilityRepository) CreateBlackout(ctx context.Context, blackout *domain.VolunteerBlackout) error {
+	return uow.Conn(ctx, r.db).Create(blackout).Error
+}
+
+func (r *AvailabilityRepository) DeleteBlackout(ctx context.Context, volunteerID int, id int) error {
+	return uow.Conn(ctx, r.db).Where("volunteer_id = ?", volunteerID).Delete(&domain.VolunteerBlackout{}, id).Error
+}
+
+func (r *AvailabilityRepository) ListBlackouts(ctx context.Context, volunteerID int) ([]*domain.VolunteerBlackout, error) {
+	var blackouts []*domain.VolunteerBlackout
+	err := uow.Conn(ctx, r.db).Where("volunteer_id = ?", volunteerID).Order("date").Find(&blackouts).Error
+	return blackouts, err
+}
+
+func (r *AvailabilityRepository) ListBlackoutsOn(ctx context.Context, volunteerIDs []int, date time.Time) ([]*domain.VolunteerBlackout, error) {
+	var blackouts []*domain.VolunteerBlackout
+	err := uow.Conn(ctx, r.db).Where("volunteer_id IN ? AND date = ?", volunteerIDs, date.Format("2006-01-02")).Find(&blackouts).Error
+	return blackouts, err
+}
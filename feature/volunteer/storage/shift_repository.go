@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+)
+
+// ShiftRepositoryInterface defines the methods that a ShiftRepository should implement
+type ShiftRepositoryInterface interface {
+	CreateShift(ctx context.Context, shift *domain.Shift) error
+	FindShiftByID(ctx context.Context, id int) (*domain.Shift, error)
+	LockShift(ctx context.Context, id int) (*domain.Shift, error)
+	LockVolunteer(ctx context.Context, id int) (*domain.VolunteerDetails, error)
+	ListShifts(ctx context.Context, filter dto.ShiftFilter) ([]*domain.Shift, error)
+	DeleteShift(ctx context.Context, id int) error
+	CreateAssignment(ctx context.Context, assignment *domain.ShiftAssignment) error
+	DeleteAssignment(ctx context.Context, shiftID int, volunteerID int) error
+	ListAssignments(ctx context.Context, shiftIDs []int) ([]*domain.ShiftAssignment, error)
+	ListBusyVolunteers(ctx context.Context, volunteerIDs []int, start, end time.Time) ([]int, error)
+}
+
+type ShiftRepository struct {
+	db *gorm.DB
+}
+
+func NewShiftRepository(db *gorm.DB) *ShiftRepository {
+	return &ShiftRepository{db: db}
+}
+
+func (r *ShiftRepository) CreateShift(ctx context.Context, shift *domain.Shift) error {
+	return uow.Conn(ctx, r.db).Create(shift).Error
+}
+
+func (r *ShiftRepository) FindShiftByID(ctx context.Context, id int) (*domain.Shift, error) {
+	var shift domain.Shift
+	if err := uow.Conn(ctx, r.db).First(&shift, id).Error; err != nil {
+		return nil, err
+	}
+	return &shift, nil
+}
+
+// LockShift loads the shift and locks its row until the transaction carried by ctx ends,
+// concurrent assignments to the same shift wait for each other
+func (r *ShiftRepository) LockShift(ctx context.Context, id int) (*domain.Shift, error) {
+	var shift domain.Shift
+	if err := uow.Conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&shift, id).Error; err != nil {
+		return nil, err
+	}
+	return &shift, nil
+}
+
+// LockVolunteer loads the volunteer and locks its row until the transaction carried by ctx ends,
+// concurrent assignments of the volunteer to different shifts wait for each other
+func (r *ShiftRepository) LockVolunteer(ctx context.Context, id int) (*domain.VolunteerDetails, error) {
+	var volunteer domain.VolunteerDetails
+	if err := uow.Conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&volunteer, id).Error; err != nil {
+		return nil, err
+	}
+	return &volunteer, nil
+}
+
+func (r *ShiftRepository) ListShifts(ctx context.Context, filter dto.ShiftFilter) ([]*domain.Shift, error) {
+	query := uow.Conn(ctx, r.db).Model(&domain.Shift{})
+	if filter.DepartmentID != 0 {
+		query = query.Where("department_id = ?", filter.DepartmentID)
+	}
+	if filter.From != nil {
+		query = query.Where("end_time > ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("start_time < ?", *filter.To)
+	}
+	var shifts []*domain.Shift
+	err := query.Order("start_time").Find(&shifts).Error
+	return shifts, err
+}
+
+// DeleteShift removes the shift together with its assignments
+func (r *ShiftRepository) DeleteShift(ctx context.Context, id int) error {
+	return uow.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("shift_id = ?", id).Delete(&domain.ShiftAssignment{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Shift{}, id).Error
+	})
+}
+
+func (r *ShiftRepository) CreateAssignment(ctx context.Context, assignment *domain.ShiftAssignment) error {
+	return uow.Conn(ctx, r.db).Create(assignment).Error
+}
+
+func (r *ShiftRepository) DeleteAssignment(ctx context.Context, shiftID int, volunteerID int) error {
+	return uow.Conn(ctx, r.db).Where("shift_id = ? AND volunteer_id = ?", shiftID, volunteerID).Delete(&domain.ShiftAssignment{}).Error
+}
+
+func (r *ShiftRepository) ListAssignments(ctx context.Context, shiftIDs []int) ([]*domain.ShiftAssignment, error) {
+	var assignments []*domain.ShiftAssignment
+	err := uow.Conn(ctx, r.db).Where("shift_id IN ?", shiftIDs).Find(&assignments).Error
+	return assignments, err
+}
+
+// ListBusyVolunteers returns the volunteers already assigned to a shift overlapping [start, end)
+func (r *ShiftRepository) ListBusyVolunteers(ctx context.Context, volunteerIDs []int, start, end time.Time) ([]int, error) {
+	var ids []int
+	err := uow.Conn(ctx, r.db).Model(&domain.ShiftAssignment{}).
+		Joins("JOIN shifts ON shifts.id = shift_assignments.shift_id").
+		Where("shift_assignments.volunteer_id IN ?", volunteerIDs).
+		Where("shifts.start_time < ? AND shifts.end_time > ?", end, start).
+		Distinct().
+		Pluck("shift_assignments.volunteer_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+)
+
+var ErrTimeEntryNotPending = errors.New("time entry not found or already processed")
+
+// TimeEntryRepositoryInterface defines the methods that a TimeEntryRepository should implement
+type TimeEntryRepositoryInterface interface {
+	CreateTimeEntry(ctx context.Context, entry *domain.TimeEntry) error
+	FindTimeEntryByID(ctx context.Context, id int) (*domain.TimeEntry, error)
+	ListTimeEntries(ctx context.Context, filter dto.TimeEntryFilter) ([]*domain.TimeEntry, error)
+	StreamTimeEntries(ctx context.Context, filter dto.TimeEntryFilter, fn func(entry *domain.TimeEntry) error) error
+	ApproveTimeEntry(ctx context.Context, id int, approverID int) error
+	RejectTimeEntry(ctx context.Context, id int, approverID int, notes string) error
+	TotalsByVolunteer(ctx context.Context, filter dto.TimeEntryFilter) ([]*domain.HoursTotal, error)
+	TotalsByDepartment(ctx context.Context, filter dto.TimeEntryFilter) ([]*domain.HoursTotal, error)
+}
+
+type TimeEntryRepository struct {
+	db *gorm.DB
+}
+
+func NewTimeEntryRepository(db *gorm.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{db: db}
+}
+
+func (r *TimeEntryRepository) CreateTimeEntry(ctx context.Context, entry *domain.TimeEntry) error {
+	return uow.Conn(ctx, r.db).Create(entry).Error
+}
+
+func (r *TimeEntryRepository) FindTimeEntryByID(ctx context.Context, id int) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	if err := uow.Conn(ctx, r.db).First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *TimeEntryRepository) ListTimeEntries(ctx context.Context, filter dto.TimeEntryFilter) ([]*domain.TimeEntry, error) {
+	var entries []*domain.TimeEntry
+	if err := applyTimeEntryFilter(uow.Conn(ctx, r.db), filter).Order("start_time").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ApproveTimeEntry only moves pending entries, so a decided entry cannot be approved twice
+// StreamTimeEntries hands the entries matching filter to fn one at a time, reading them from the
+// query cursor instead of loading them all
+func (r *TimeEntryRepository) StreamTimeEntries(ctx context.Context, filter dto.TimeEntryFilter, fn func(entry *domain.TimeEntry) error) error {
+	db := uow.Conn(ctx, r.db)
+	rows, err := applyTimeEntryFilter(db, filter).Order("start_time").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var entry domain.TimeEntry
+		if err := db.ScanRows(rows, &entry); err != nil {
+			return err
+		}
+		if err := fn(&entry); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *TimeEntryRepository) ApproveTimeEntry(ctx context.Context, id int, approverID int) error {
+	now := time.Now()
+	return r.decide(ctx, id, map[string]interface{}{
+		"status":      domain.TimeEntryApproved,
+		"approver_id": approverID,
+		"approved_at": &now,
+	})
+}
+
+func (r *TimeEntryRepository) RejectTimeEntry(ctx context.Context, id int, approverID int, notes string) error {
+	return r.decide(ctx, id, map[string]interface{}{
+		"status":       domain.TimeEntryRejected,
+		"approver_id":  approverID,
+		"reject_notes": notes,
+	})
+}
+
+// TotalsByVolunteer sums approved minutes per volunteer
+func (r *TimeEntryRepository) TotalsByVolunteer(ctx context.Context, filter dto.TimeEntryFilter) ([]*domain.HoursTotal, error) {
+	var totals []*domain.HoursTotal
+	err := applyTimeEntryFilter(uow.Conn(ctx, r.db), approvedOnly(filter)).
+		Select("volunteer_id, SUM(TIMESTAMPDIFF(MINUTE, start_time, end_time)) AS minutes, COUNT(*) AS entries").
+		Group("volunteer_id").
+		Order("volunteer_id").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// TotalsByDepartment sums approved minutes per department
+func (r *TimeEntryRepository) TotalsByDepartment(ctx context.Context, filter dto.TimeEntryFilter) ([]*domain.HoursTotal, error) {
+	var totals []*domain.HoursTotal
+	err := applyTimeEntryFilter(uow.Conn(ctx, r.db), approvedOnly(filter)).
+		Select("department_id, SUM(TIMESTAMPDIFF(MINUTE, start_time, end_time)) AS minutes, COUNT(*) AS entries").
+		Group("department_id").
+		Order("department_id").
+		Scan(&totals).Error
+	return totals, err
+}
+
+func (r *TimeEntryRepository) decide(ctx context.Context, id int, values map[string]interface{}) error {
+	result := uow.Conn(ctx, r.db).Model(&domain.TimeEntry{}).
+		Where("id = ? AND status = ?", id, domain.TimeEntryPending).
+		Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTimeEntryNotPending
+	}
+	return nil
+}
+
+func approvedOnly(filter dto.TimeEntryFilter) dto.TimeEntryFilter {
+	status := domain.TimeEntryApproved
+	filter.Status = &status
+	return filter
+}
+
+func applyTimeEntryFilter(db *gorm.DB, filter dto.TimeEntryFilter) *gorm.DB {
+	query := db.Model(&domain.TimeEntry{})
+	if filter.VolunteerID != 0 {
+		query = query.Where("volunteer_id = ?", filter.VolunteerID)
+	}
+	if filter.DepartmentID != 0 {
+		query = query.Where("department_id = ?", filter.DepartmentID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("start_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("start_time < ?", *filter.To)
+	}
+	if filter.Scope != nil && !filter.Scope.Admin {
+		// the entries of the actor, of the volunteers of their departments and logged for their departments
+		volunteers := db.Session(&gorm.Session{NewDB: true}).Model(&domain.VolunteerDetails{}).Select("id").
+			Where("user_id = ? OR department_id IN ?", filter.Scope.UserID, filter.Scope.ManagedDepartments)
+		query = query.Where("volunteer_id IN (?) OR department_id IN ?", volunteers, filter.Scope.ManagedDepartments)
+	}
+	return query
+}
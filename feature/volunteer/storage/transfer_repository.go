@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+)
+
+var ErrTransferNotPending = errors.New("transfer is not pending")
+
+// TransferRepositoryInterface defines the methods that a TransferRepository should implement
+type TransferRepositoryInterface interface {
+	CreateTransfer(ctx context.Context, transfer *domain.DepartmentTransfer) error
+	FindTransferByID(ctx context.Context, id int) (*domain.DepartmentTransfer, error)
+	LockTransfer(ctx context.Context, id int) (*domain.DepartmentTransfer, error)
+	HasPendingTransfer(ctx context.Context, volunteerID int) (bool, error)
+	ListTransfers(ctx context.Context, filter dto.TransferFilter) ([]*domain.DepartmentTransfer, error)
+	UpdatePendingTransfer(ctx context.Context, transfer *domain.DepartmentTransfer) error
+	CompleteTransfer(ctx context.Context, transfer *domain.DepartmentTransfer, effective time.Time) error
+	ListMemberships(ctx context.Context, volunteerID int) ([]*domain.DepartmentMembership, error)
+	ListDepartmentManagerUserIDs(ctx context.Context, departmentIDs []int) ([]int, error)
+}
+
+type TransferRepository struct {
+	db *gorm.DB
+}
+
+func NewTransferRepository(db *gorm.DB) *TransferRepository {
+	return &TransferRepository{db: db}
+}
+
+func (r *TransferRepository) CreateTransfer(ctx context.Context, transfer *domain.DepartmentTransfer) error {
+	return uow.Conn(ctx, r.db).Create(transfer).Error
+}
+
+func (r *TransferRepository) FindTransferByID(ctx context.Context, id int) (*domain.DepartmentTransfer, error) {
+	var transfer domain.DepartmentTransfer
+	if err := uow.Conn(ctx, r.db).First(&transfer, id).Error; err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// LockTransfer loads the transfer and locks its row until the transaction carried by ctx ends,
+// concurrent approvals of the same transfer wait for each other
+func (r *TransferRepository) LockTransfer(ctx context.Context, id int) (*domain.DepartmentTransfer, error) {
+	var transfer domain.DepartmentTransfer
+	if err := uow.Conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&transfer, id).Error; err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (r *TransferRepository) HasPendingTransfer(ctx context.Context, volunteerID int) (bool, error) {
+	var count int64
+	err := uow.Conn(ctx, r.db).Model(&domain.DepartmentTransfer{}).
+		Where("volunteer_id = ? AND status = ?", volunteerID, domain.TransferPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *TransferRepository) ListTransfers(ctx context.Context, filter dto.TransferFilter) ([]*domain.DepartmentTransfer, error) {
+	db := uow.Conn(ctx, r.db)
+	query := db.Model(&domain.DepartmentTransfer{})
+	if filter.VolunteerID != 0 {
+		query = query.Where("volunteer_id = ?", filter.VolunteerID)
+	}
+	if filter.DepartmentID != 0 {
+		query = query.Where("from_department_id = ? OR to_department_id = ?", filter.DepartmentID, filter.DepartmentID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.Scope != nil && !filter.Scope.Admin {
+		// the transfers of the actor and those leaving or entering a department they manage
+		volunteers := db.Session(&gorm.Session{NewDB: true}).Model(&domain.VolunteerDetails{}).Select("id").
+			Where("user_id = ?", filter.Scope.UserID)
+		query = query.Where("volunteer_id IN (?) OR from_department_id IN ? OR to_department_id IN ?",
+			volunteers, filter.Scope.ManagedDepartments, filter.Scope.ManagedDepartments)
+	}
+	var transfers []*domain.DepartmentTransfer
+	err := query.Order("created_at DESC").Find(&transfers).Error
+	return transfers, err
+}
+
+// UpdatePendingTransfer saves the transfer only if it is still pending, so concurrent decisions cannot both win
+func (r *TransferRepository) UpdatePendingTransfer(ctx context.Context, transfer *domain.DepartmentTransfer) error {
+	return updatePendingTransfer(uow.Conn(ctx, r.db), transfer)
+}
+
+// CompleteTransfer marks the transfer approved, moves the volunteer and rolls the membership history over at effective
+func (r *TransferRepository) CompleteTransfer(ctx context.Context, transfer *domain.DepartmentTransfer, effective time.Time) error {
+	return uow.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := updatePendingTransfer(tx, transfer); err != nil {
+			return err
+		}
+		result := tx.Model(&domain.VolunteerDetails{}).
+			Where("id = ? AND department_id = ?", transfer.VolunteerID, transfer.FromDepartmentID).
+			Update("department_id", transfer.ToDepartmentID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("volunteer is no longer in the source department")
+		}
+		err := tx.Model(&domain.DepartmentMembership{}).
+			Where("volunteer_id = ? AND end_date IS NULL", transfer.VolunteerID).
+			Update("end_date", effective).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&domain.DepartmentMembership{
+			VolunteerID:  transfer.VolunteerID,
+			DepartmentID: transfer.ToDepartmentID,
+			StartDate:    effective,
+			TransferID:   &transfer.ID,
+		}).Error
+	})
+}
+
+func (r *TransferRepository) ListMemberships(ctx context.Context, volunteerID int) ([]*domain.DepartmentMembership, error) {
+	var memberships []*domain.DepartmentMembership
+	err := uow.Conn(ctx, r.db).Where("volunteer_id = ?", volunteerID).Order("start_date, id").Find(&memberships).Error
+	return memberships, err
+}
+
+// ListDepartmentManagerUserIDs returns the user accounts directly assigned to manage the departments
+func (r *TransferRepository) ListDepartmentManagerUserIDs(ctx context.Context, departmentIDs []int) ([]int, error) {
+	var userIDs []int
+	err := uow.Conn(ctx, r.db).Table("department_managers").
+		Where("department_id IN ?", departmentIDs).
+		Distinct().Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+func updatePendingTransfer(db *gorm.DB, transfer *domain.DepartmentTransfer) error {
+	result := db.Model(transfer).
+		Where("status = ?", domain.TransferPending).
+		Select("status", "source_approved_by", "source_approved_at", "target_approved_by", "target_approved_at",
+			"decided_by", "decided_at", "reject_notes").
+		Updates(transfer)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTransferNotPending
+	}
+	return nil
+}
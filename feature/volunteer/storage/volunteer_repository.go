@@ -1,51 +1,161 @@
 package storage
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"gorm.io/gorm"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/event"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
 )
 
 // VolunteerRepositoryInterface defines the methods that a VolunteerRepository should implement
 type VolunteerRepositoryInterface interface {
-	CreateVolunteer(volunteer *domain.VolunteerDetails) error
-	UpdateVolunteer(volunteer *domain.VolunteerDetails) error
-	DeleteVolunteer(id int) error
-	FindVolunteerByID(id int) (*domain.VolunteerDetails, error)
-	GetAllVolunteers() ([]*domain.VolunteerDetails, error)
+	CreateVolunteer(ctx context.Context, volunteer *domain.VolunteerDetails) error
+	UpdateVolunteer(ctx context.Context, volunteer *domain.VolunteerDetails) error
+	DeleteVolunteer(ctx context.Context, id int) error
+	FindVolunteerByID(ctx context.Context, id int) (*domain.VolunteerDetails, error)
+	GetAllVolunteers(ctx context.Context) ([]*domain.VolunteerDetails, error)
+	GetActiveVolunteersByDepartment(ctx context.Context, departmentID int) ([]*domain.VolunteerDetails, error)
 }
 
 type VolunteerRepository struct {
-	db *gorm.DB
+	db  *gorm.DB
+	bus *event.Bus
+}
+
+func NewVolunteerRepository(db *gorm.DB, bus *event.Bus) *VolunteerRepository {
+	return &VolunteerRepository{db: db, bus: bus}
 }
 
-func NewVolunteerRepository(db *gorm.DB) *VolunteerRepository {
-	return &VolunteerRepository{db: db}
+// CreateVolunteer inserts the volunteer and opens their first department membership
+func (r *VolunteerRepository) CreateVolunteer(ctx context.Context, volunteer *domain.VolunteerDetails) error {
+	return r.bus.InTx(ctx, func(tx *event.Tx) error {
+		return createVolunteer(tx, volunteer)
+	})
 }
 
-func (r *VolunteerRepository) CreateVolunteer(volunteer *domain.VolunteerDetails) error {
-	return r.db.Create(volunteer).Error
+// UpdateVolunteer saves the volunteer and publishes VolunteerStatusChanged when the status changed
+func (r *VolunteerRepository) UpdateVolunteer(ctx context.Context, volunteer *domain.VolunteerDetails) error {
+	return r.bus.InTx(ctx, func(tx *event.Tx) error {
+		var previous domain.VolunteerDetails
+		if err := tx.DB().Select("status").First(&previous, volunteer.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.DB().Save(volunteer).Error; err != nil {
+			return err
+		}
+		if previous.Status == volunteer.Status {
+			return nil
+		}
+		return tx.Publish(event.VolunteerStatusChanged{
+			VolunteerID:    volunteer.ID,
+			UserID:         volunteer.UserID,
+			DepartmentID:   volunteer.DepartmentID,
+			Status:         volunteer.Status,
+			PreviousStatus: previous.Status,
+		})
+	})
 }
 
-func (r *VolunteerRepository) UpdateVolunteer(volunteer *domain.VolunteerDetails) error {
-	return r.db.Save(volunteer).Error
+// volunteerTables hold the rows of a volunteer, deleted with it. The memberships go before the
+// transfers that opened them.
+var volunteerTables = []string{
+	"time_entries",
+	"volunteer_availabilities",
+	"volunteer_blackouts",
+	"shift_assignments",
+	"volunteer_skills",
+	"department_managers",
+	"department_memberships",
+	"department_transfers",
 }
 
-func (r *VolunteerRepository) DeleteVolunteer(id int) error {
-	return r.db.Delete(&domain.VolunteerDetails{}, id).Error
+// DeleteVolunteer removes the volunteer with their rows of volunteerTables and publishes
+// VolunteerDeleted
+func (r *VolunteerRepository) DeleteVolunteer(ctx context.Context, id int) error {
+	return r.bus.InTx(ctx, func(tx *event.Tx) error {
+		var volunteer domain.VolunteerDetails
+		if err := tx.DB().First(&volunteer, id).Error; err != nil {
+			return err
+		}
+		for _, table := range volunteerTables {
+			err := tx.DB().Table(table).Where("volunteer_id = ?", volunteer.ID).Delete(map[string]interface{}{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if err := tx.DB().Delete(&volunteer).Error; err != nil {
+			return err
+		}
+		return tx.Publish(event.VolunteerDeleted{
+			VolunteerID:  volunteer.ID,
+			UserID:       volunteer.UserID,
+			DepartmentID: volunteer.DepartmentID,
+			Status:       volunteer.Status,
+		})
+	})
 }
 
-func (r *VolunteerRepository) FindVolunteerByID(id int) (*domain.VolunteerDetails, error) {
+// OnRequestApproved makes the user of an approved verification request an active volunteer
+// of their department. It runs in the approval transaction, an error cancels the approval.
+func (r *VolunteerRepository) OnRequestApproved(tx *event.Tx, approved event.RequestApproved) error {
+	if approved.Type != "verification" {
+		return nil
+	}
+	if approved.DepartmentID == nil {
+		return errors.New("User has no department")
+	}
+	return createVolunteer(tx, &domain.VolunteerDetails{
+		UserID:       approved.UserID,
+		DepartmentID: *approved.DepartmentID,
+		Status:       1,
+	})
+}
+
+// createVolunteer inserts the volunteer with its first membership and publishes VolunteerCreated
+func createVolunteer(tx *event.Tx, volunteer *domain.VolunteerDetails) error {
+	if err := tx.DB().Create(volunteer).Error; err != nil {
+		return err
+	}
+	err := tx.DB().Create(&domain.DepartmentMembership{
+		VolunteerID:  volunteer.ID,
+		DepartmentID: volunteer.DepartmentID,
+		StartDate:    time.Now(),
+	}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Publish(event.VolunteerCreated{
+		VolunteerID:  volunteer.ID,
+		UserID:       volunteer.UserID,
+		DepartmentID: volunteer.DepartmentID,
+		Status:       volunteer.Status,
+	})
+}
+
+func (r *VolunteerRepository) FindVolunteerByID(ctx context.Context, id int) (*domain.VolunteerDetails, error) {
 	var volunteer *domain.VolunteerDetails
-	if err := r.db.First(&volunteer, id).Error; err != nil {
+	if err := uow.Conn(ctx, r.db).First(&volunteer, id).Error; err != nil {
 		return nil, err
 	}
 	return volunteer, nil
 }
 
-func (r *VolunteerRepository) GetAllVolunteers() ([]*domain.VolunteerDetails, error) {
+func (r *VolunteerRepository) GetAllVolunteers(ctx context.Context) ([]*domain.VolunteerDetails, error) {
+	var volunteers []*domain.VolunteerDetails
+	if err := uow.Conn(ctx, r.db).Find(&volunteers).Error; err != nil {
+		return nil, err
+	}
+	return volunteers, nil
+}
+
+func (r *VolunteerRepository) GetActiveVolunteersByDepartment(ctx context.Context, departmentID int) ([]*domain.VolunteerDetails, error) {
 	var volunteers []*domain.VolunteerDetails
-	if err := r.db.Find(&volunteers).Error; err != nil {
+	if err := uow.Conn(ctx, r.db).Where("department_id = ? AND status = ?", departmentID, 1).Find(&volunteers).Error; err != nil {
 		return nil, err
 	}
 	return volunteers, nil
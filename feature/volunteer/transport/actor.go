@@ -0,0 +1,24 @@
+package transport
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/gin-gonic/gin"
+)
+
+type actorResolver interface {
+	ResolveActor(ctx context.Context, userID int, roleID int) (*dto.Actor, error)
+}
+
+// resolveActor returns the authenticated user of the request with the departments they manage
+func resolveActor(c *gin.Context, resolver actorResolver) (*dto.Actor, error) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		return nil, errors.New("unauthorized")
+	}
+	roleId, _ := c.Get("roleId")
+	role, _ := roleId.(int)
+	return resolver.ResolveActor(c.Request.Context(), userId.(int), role)
+}
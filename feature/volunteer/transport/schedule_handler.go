@@ -0,0 +1,376 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleHandler struct {
+	ScheduleUsecaseH usecase.ScheduleUsecaseInterface
+}
+
+func NewScheduleHandler(scheduleUsecase usecase.ScheduleUsecaseInterface) *ScheduleHandler {
+	return &ScheduleHandler{ScheduleUsecaseH: scheduleUsecase}
+}
+
+// GetAvailability godoc
+// @Summary Get volunteer availability
+// @Description Get the weekly availability windows of a volunteer
+// @Produce json
+// @Tags volunteer
+// @Param id path int true "Volunteer ID"
+// @Success 200 {array} dto.AvailabilityWindowDTO
+// @Router /api/v1/volunteer/{id}/availability [get]
+func (h *ScheduleHandler) GetAvailability(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	windows, err := h.ScheduleUsecaseH.GetAvailability(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, windows)
+}
+
+// UpdateAvailability godoc
+// @Summary Replace volunteer availability
+// @Description Replace the weekly availability windows of a volunteer
+// @Accept json
+// @Produce json
+// @Tags volunteer
+// @Param id path int true "Volunteer ID"
+// @Param request body dto.AvailabilityUpdateDTO true "Availability windows"
+// @Success 200 {string} message "Availability updated successfully"
+// @Router /api/v1/volunteer/{id}/availability [put]
+func (h *ScheduleHandler) UpdateAvailability(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	var input dto.AvailabilityUpdateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.ScheduleUsecaseH.UpdateAvailability(c.Request.Context(), id, input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Availability updated successfully"})
+}
+
+// ListBlackouts godoc
+// @Summary List volunteer blackout dates
+// @Description List the dates a volunteer is unavailable
+// @Produce json
+// @Tags volunteer
+// @Param id path int true "Volunteer ID"
+// @Success 200 {array} dto.BlackoutResponseDTO
+// @Router /api/v1/volunteer/{id}/blackouts [get]
+func (h *ScheduleHandler) ListBlackouts(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	blackouts, err := h.ScheduleUsecaseH.ListBlackouts(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, blackouts)
+}
+
+// CreateBlackout godoc
+// @Summary Add volunteer blackout date
+// @Description Mark a date on which the volunteer is unavailable
+// @Accept json
+// @Produce json
+// @Tags volunteer
+// @Param id path int true "Volunteer ID"
+// @Param request body dto.BlackoutCreateDTO true "Blackout date"
+// @Success 201 {object} dto.BlackoutResponseDTO
+// @Router /api/v1/volunteer/{id}/blackouts [post]
+func (h *ScheduleHandler) CreateBlackout(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	var input dto.BlackoutCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	blackout, err := h.ScheduleUsecaseH.CreateBlackout(c.Request.Context(), id, input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, blackout)
+}
+
+// DeleteBlackout godoc
+// @Summary Remove volunteer blackout date
+// @Description Remove a blackout date of a volunteer
+// @Produce json
+// @Tags volunteer
+// @Param id path int true "Volunteer ID"
+// @Param blackoutId path int true "Blackout ID"
+// @Success 200 {string} message "Blackout deleted successfully"
+// @Router /api/v1/volunteer/{id}/blackouts/{blackoutId} [delete]
+func (h *ScheduleHandler) DeleteBlackout(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+	blackoutID, err := strconv.Atoi(c.Param("blackoutId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blackout ID"})
+		return
+	}
+
+	if err := h.ScheduleUsecaseH.DeleteBlackout(c.Request.Context(), id, blackoutID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Blackout deleted successfully"})
+}
+
+// CreateShift godoc
+// @Summary Create shift
+// @Description Define a shift for a department
+// @Accept json
+// @Produce json
+// @Tags shift
+// @Param request body dto.ShiftCreateDTO true "Create Shift Request"
+// @Success 201 {object} dto.ShiftResponseDTO
+// @Security bearerToken
+// @Router /api/v1/shifts/ [post]
+func (h *ScheduleHandler) CreateShift(c *gin.Context) {
+	if _, err := checkSupervisor(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	var input dto.ShiftCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shift, err := h.ScheduleUsecaseH.CreateShift(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, shift)
+}
+
+// ListShifts godoc
+// @Summary List shifts
+// @Description List shifts filtered by department and date range
+// @Produce json
+// @Tags shift
+// @Param department_id query int false "Department ID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date inclusive (YYYY-MM-DD)"
+// @Success 200 {array} dto.ShiftResponseDTO
+// @Router /api/v1/shifts/ [get]
+func (h *ScheduleHandler) ListShifts(c *gin.Context) {
+	var filter dto.ShiftFilter
+	var err error
+	if v := c.Query("department_id"); v != "" {
+		if filter.DepartmentID, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid department_id"})
+			return
+		}
+	}
+	if filter.From, filter.To, err = parseDateRange(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shifts, err := h.ScheduleUsecaseH.ListShifts(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shifts)
+}
+
+// FindShiftByID godoc
+// @Summary Find shift by ID
+// @Description Find shift by ID with its assigned volunteers
+// @Produce json
+// @Tags shift
+// @Param id path int true "Shift ID"
+// @Success 200 {object} dto.ShiftResponseDTO
+// @Router /api/v1/shifts/{id} [get]
+func (h *ScheduleHandler) FindShiftByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift ID"})
+		return
+	}
+
+	shift, err := h.ScheduleUsecaseH.FindShiftByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Shift not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, shift)
+}
+
+// DeleteShift godoc
+// @Summary Delete shift
+// @Description Delete a shift and its assignments
+// @Produce json
+// @Tags shift
+// @Param id path int true "Shift ID"
+// @Success 200 {string} message "Shift deleted successfully"
+// @Security bearerToken
+// @Router /api/v1/shifts/{id} [delete]
+func (h *ScheduleHandler) DeleteShift(c *gin.Context) {
+	if _, err := checkSupervisor(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift ID"})
+		return
+	}
+
+	if err := h.ScheduleUsecaseH.DeleteShift(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Shift deleted successfully"})
+}
+
+// SuggestVolunteers godoc
+// @Summary Suggest volunteers for a shift
+// @Description List department volunteers available for the shift and not booked elsewhere
+// @Produce json
+// @Tags shift
+// @Param id path int true "Shift ID"
+// @Success 200 {array} dto.VolunteerResponseDTO
+// @Security bearerToken
+// @Router /api/v1/shifts/{id}/suggestions [get]
+func (h *ScheduleHandler) SuggestVolunteers(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift ID"})
+		return
+	}
+
+	volunteers, err := h.ScheduleUsecaseH.SuggestVolunteers(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, volunteers)
+}
+
+// AssignVolunteer godoc
+// @Summary Assign volunteer to shift
+// @Description Assign a volunteer to a shift, failing on full shifts and double-booking
+// @Accept json
+// @Produce json
+// @Tags shift
+// @Param id path int true "Shift ID"
+// @Param request body dto.ShiftAssignDTO true "Assign Volunteer Request"
+// @Success 201 {string} message "Volunteer assigned successfully"
+// @Failure 409 {string} error "Shift full or double-booking"
+// @Security bearerToken
+// @Router /api/v1/shifts/{id}/assignments [post]
+func (h *ScheduleHandler) AssignVolunteer(c *gin.Context) {
+	userID, err := checkSupervisor(c)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift ID"})
+		return
+	}
+
+	var input dto.ShiftAssignDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.ScheduleUsecaseH.AssignVolunteer(c.Request.Context(), id, input, userID); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrShiftFull) || errors.Is(err, usecase.ErrShiftDoubleBooking) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Volunteer assigned successfully"})
+}
+
+// UnassignVolunteer godoc
+// @Summary Remove volunteer from shift
+// @Description Remove a volunteer assignment from a shift
+// @Produce json
+// @Tags shift
+// @Param id path int true "Shift ID"
+// @Param volunteerId path int true "Volunteer ID"
+// @Success 200 {string} message "Volunteer unassigned successfully"
+// @Security bearerToken
+// @Router /api/v1/shifts/{id}/assignments/{volunteerId} [delete]
+func (h *ScheduleHandler) UnassignVolunteer(c *gin.Context) {
+	if _, err := checkSupervisor(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift ID"})
+		return
+	}
+	volunteerID, err := strconv.Atoi(c.Param("volunteerId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	if err := h.ScheduleUsecaseH.UnassignVolunteer(c.Request.Context(), id, volunteerID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Volunteer unassigned successfully"})
+}
@@ -0,0 +1,317 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/export"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type TimeEntryHandler struct {
+	TimeEntryUsecaseH usecase.TimeEntryUsecaseInterface
+}
+
+func NewTimeEntryHandler(timeEntryUsecase usecase.TimeEntryUsecaseInterface) *TimeEntryHandler {
+	return &TimeEntryHandler{TimeEntryUsecaseH: timeEntryUsecase}
+}
+
+// CreateTimeEntry godoc
+// @Summary Log volunteer hours
+// @Description Log a time entry for a volunteer, pending supervisor approval
+// @Accept json
+// @Produce json
+// @Tags volunteer-hours
+// @Param request body dto.TimeEntryCreateDTO true "Create Time Entry Request"
+// @Success 201 {object} dto.TimeEntryResponseDTO
+// @Security bearerToken
+// @Router /api/v1/volunteer-hours/ [post]
+func (h *TimeEntryHandler) CreateTimeEntry(c *gin.Context) {
+	actor, err := resolveActor(c, h.TimeEntryUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	var input dto.TimeEntryCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.TimeEntryUsecaseH.CreateTimeEntry(c.Request.Context(), input, *actor)
+	if errors.Is(err, usecase.ErrTimeEntryForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// ListTimeEntries godoc
+// @Summary List time entries
+// @Description List time entries filtered by volunteer, department, status and date range. Volunteers see their own entries, managers the ones of their departments
+// @Produce json
+// @Tags volunteer-hours
+// @Param volunteer_id query int false "Volunteer ID"
+// @Param department_id query int false "Department ID"
+// @Param status query int false "0: pending, 1: approved, 2: rejected"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date inclusive (YYYY-MM-DD)"
+// @Success 200 {array} dto.TimeEntryResponseDTO
+// @Security bearerToken
+// @Router /api/v1/volunteer-hours/ [get]
+func (h *TimeEntryHandler) ListTimeEntries(c *gin.Context) {
+	actor, err := resolveActor(c, h.TimeEntryUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	filter, err := parseTimeEntryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.Scope = actor
+
+	entries, err := h.TimeEntryUsecaseH.ListTimeEntries(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// ApproveTimeEntry godoc
+// @Summary Approve time entry
+// @Description Approve a pending time entry
+// @Produce json
+// @Tags volunteer-hours
+// @Param id path int true "Time Entry ID"
+// @Success 200 {string} message "Time entry approved successfully"
+// @Security bearerToken
+// @Router /api/v1/volunteer-hours/{id}/approve [post]
+func (h *TimeEntryHandler) ApproveTimeEntry(c *gin.Context) {
+	approverID, err := checkSupervisor(c)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time entry ID"})
+		return
+	}
+
+	if err := h.TimeEntryUsecaseH.ApproveTimeEntry(c.Request.Context(), id, approverID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Time entry approved successfully"})
+}
+
+// RejectTimeEntry godoc
+// @Summary Reject time entry
+// @Description Reject a pending time entry with optional notes
+// @Accept json
+// @Produce json
+// @Tags volunteer-hours
+// @Param id path int true "Time Entry ID"
+// @Param request body dto.TimeEntryRejectDTO false "Reject Time Entry Request"
+// @Success 200 {string} message "Time entry rejected successfully"
+// @Security bearerToken
+// @Router /api/v1/volunteer-hours/{id}/reject [post]
+func (h *TimeEntryHandler) RejectTimeEntry(c *gin.Context) {
+	approverID, err := checkSupervisor(c)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time entry ID"})
+		return
+	}
+
+	var input dto.TimeEntryRejectDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := h.TimeEntryUsecaseH.RejectTimeEntry(c.Request.Context(), id, approverID, input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Time entry rejected successfully"})
+}
+
+// TotalsByVolunteer godoc
+// @Summary Approved hours per volunteer
+// @Description Sum of approved hours per volunteer over a date range
+// @Produce json
+// @Tags volunteer-hours
+// @Param department_id query int false "Department ID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date inclusive (YYYY-MM-DD)"
+// @Success 200 {array} dto.HoursTotalDTO
+// @Security bearerToken
+// @Router /api/v1/volunteer-hours/totals/volunteers [get]
+func (h *TimeEntryHandler) TotalsByVolunteer(c *gin.Context) {
+	actor, err := resolveActor(c, h.TimeEntryUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	filter, err := parseTimeEntryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.Scope = actor
+
+	totals, err := h.TimeEntryUsecaseH.TotalsByVolunteer(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
+// TotalsByDepartment godoc
+// @Summary Approved hours per department
+// @Description Sum of approved hours per department over a date range
+// @Produce json
+// @Tags volunteer-hours
+// @Param volunteer_id query int false "Volunteer ID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date inclusive (YYYY-MM-DD)"
+// @Success 200 {array} dto.HoursTotalDTO
+// @Security bearerToken
+// @Router /api/v1/volunteer-hours/totals/departments [get]
+func (h *TimeEntryHandler) TotalsByDepartment(c *gin.Context) {
+	actor, err := resolveActor(c, h.TimeEntryUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	filter, err := parseTimeEntryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.Scope = actor
+
+	totals, err := h.TimeEntryUsecaseH.TotalsByDepartment(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
+// ExportTimeEntries godoc
+// @Summary Export time entries
+// @Description Stream the time entries matching the filters as CSV or XLSX
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Tags volunteer-hours
+// @Param format query string false "csv (default) or xlsx"
+// @Param volunteer_id query int false "Volunteer ID"
+// @Param department_id query int false "Department ID"
+// @Param status query int false "0: pending, 1: approved, 2: rejected"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date inclusive (YYYY-MM-DD)"
+// @Success 200 {file} file
+// @Security bearerToken
+// @Router /api/v1/volunteer-hours/export [get]
+func (h *TimeEntryHandler) ExportTimeEntries(c *gin.Context) {
+	actor, err := resolveActor(c, h.TimeEntryUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	filter, err := parseTimeEntryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.Scope = actor
+
+	format := c.DefaultQuery("format", export.CSV)
+	export.Download(c, "volunteer-hours", format, func(w io.Writer) error {
+		return h.TimeEntryUsecaseH.ExportTimeEntries(c.Request.Context(), filter, format, w)
+	})
+}
+
+func parseTimeEntryFilter(c *gin.Context) (dto.TimeEntryFilter, error) {
+	var filter dto.TimeEntryFilter
+	var err error
+	if v := c.Query("volunteer_id"); v != "" {
+		if filter.VolunteerID, err = strconv.Atoi(v); err != nil {
+			return filter, errors.New("invalid volunteer_id")
+		}
+	}
+	if v := c.Query("department_id"); v != "" {
+		if filter.DepartmentID, err = strconv.Atoi(v); err != nil {
+			return filter, errors.New("invalid department_id")
+		}
+	}
+	if v := c.Query("status"); v != "" {
+		status, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, errors.New("invalid status")
+		}
+		filter.Status = &status
+	}
+	filter.From, filter.To, err = parseDateRange(c)
+	return filter, err
+}
+
+// parseDateRange reads the from/to query dates, to is inclusive so it is moved to the next midnight
+func parseDateRange(c *gin.Context) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, nil, errors.New("invalid from date")
+		}
+		from = &t
+	}
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, nil, errors.New("invalid to date")
+		}
+		t = t.AddDate(0, 0, 1)
+		to = &t
+	}
+	return from, to, nil
+}
+
+func checkSupervisor(c *gin.Context) (int, error) {
+	roleId, exists := c.Get("roleId")
+	if !exists || roleId.(int) != 1 {
+		return 0, errors.New("forbidden: only supervisors can perform this action")
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		return 0, errors.New("unauthorized")
+	}
+	return userId.(int), nil
+}
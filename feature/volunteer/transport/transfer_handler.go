@@ -0,0 +1,283 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TransferHandler struct {
+	TransferUsecaseH usecase.TransferUsecaseInterface
+}
+
+func NewTransferHandler(transferUsecase usecase.TransferUsecaseInterface) *TransferHandler {
+	return &TransferHandler{TransferUsecaseH: transferUsecase}
+}
+
+// RequestTransfer godoc
+// @Summary Request department transfer
+// @Description Request moving a volunteer to another department, by the volunteer or a coordinator of either department
+// @Accept json
+// @Produce json
+// @Tags transfer
+// @Param id path int true "Volunteer ID"
+// @Param request body dto.TransferCreateDTO true "Transfer Request"
+// @Success 201 {object} dto.TransferResponseDTO
+// @Failure 409 {string} error "Already in department or transfer pending"
+// @Security bearerToken
+// @Router /api/v1/volunteer/{id}/transfers [post]
+func (h *TransferHandler) RequestTransfer(c *gin.Context) {
+	actor, err := resolveActor(c, h.TransferUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	var input dto.TransferCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, err := h.TransferUsecaseH.RequestTransfer(c.Request.Context(), id, input, *actor)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, transfer)
+}
+
+// ListTransfers godoc
+// @Summary List department transfers
+// @Description List transfers filtered by volunteer, department (source or target) and status, limited to your own and those of the departments you manage
+// @Produce json
+// @Tags transfer
+// @Param volunteer_id query int false "Volunteer ID"
+// @Param department_id query int false "Department ID"
+// @Param status query int false "0: pending, 1: approved, 2: rejected, 3: cancelled"
+// @Success 200 {array} dto.TransferResponseDTO
+// @Security bearerToken
+// @Router /api/v1/transfers/ [get]
+func (h *TransferHandler) ListTransfers(c *gin.Context) {
+	actor, err := resolveActor(c, h.TransferUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	filter := dto.TransferFilter{Scope: actor}
+	if v := c.Query("volunteer_id"); v != "" {
+		if filter.VolunteerID, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid volunteer_id"})
+			return
+		}
+	}
+	if v := c.Query("department_id"); v != "" {
+		if filter.DepartmentID, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid department_id"})
+			return
+		}
+	}
+	if v := c.Query("status"); v != "" {
+		status, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+			return
+		}
+		filter.Status = &status
+	}
+
+	transfers, err := h.TransferUsecaseH.ListTransfers(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfers)
+}
+
+// FindTransferByID godoc
+// @Summary Get department transfer
+// @Description Get a department transfer with its approvals
+// @Produce json
+// @Tags transfer
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} dto.TransferResponseDTO
+// @Security bearerToken
+// @Router /api/v1/transfers/{id} [get]
+func (h *TransferHandler) FindTransferByID(c *gin.Context) {
+	actor, err := resolveActor(c, h.TransferUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	transfer, err := h.TransferUsecaseH.FindTransferByID(c.Request.Context(), id, *actor)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+// ApproveTransfer godoc
+// @Summary Approve department transfer
+// @Description Approve one side of a transfer for a department you manage, the volunteer moves once both departments were approved by two different users
+// @Produce json
+// @Tags transfer
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} dto.TransferResponseDTO
+// @Security bearerToken
+// @Router /api/v1/transfers/{id}/approve [post]
+func (h *TransferHandler) ApproveTransfer(c *gin.Context) {
+	actor, err := resolveActor(c, h.TransferUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	transfer, err := h.TransferUsecaseH.ApproveTransfer(c.Request.Context(), id, *actor)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+// RejectTransfer godoc
+// @Summary Reject department transfer
+// @Description Reject a pending transfer as a manager of the source or target department
+// @Accept json
+// @Produce json
+// @Tags transfer
+// @Param id path int true "Transfer ID"
+// @Param request body dto.TransferRejectDTO false "Reject Transfer Request"
+// @Success 200 {string} message "Transfer rejected successfully"
+// @Security bearerToken
+// @Router /api/v1/transfers/{id}/reject [post]
+func (h *TransferHandler) RejectTransfer(c *gin.Context) {
+	actor, err := resolveActor(c, h.TransferUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	var input dto.TransferRejectDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := h.TransferUsecaseH.RejectTransfer(c.Request.Context(), id, *actor, input); err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Transfer rejected successfully"})
+}
+
+// CancelTransfer godoc
+// @Summary Cancel department transfer
+// @Description Withdraw a pending transfer, by its requester or the volunteer
+// @Produce json
+// @Tags transfer
+// @Param id path int true "Transfer ID"
+// @Success 200 {string} message "Transfer cancelled successfully"
+// @Security bearerToken
+// @Router /api/v1/transfers/{id}/cancel [post]
+func (h *TransferHandler) CancelTransfer(c *gin.Context) {
+	actor, err := resolveActor(c, h.TransferUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	if err := h.TransferUsecaseH.CancelTransfer(c.Request.Context(), id, *actor); err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Transfer cancelled successfully"})
+}
+
+// ListMemberships godoc
+// @Summary Department membership history
+// @Description Departments the volunteer belonged to with their effective dates
+// @Produce json
+// @Tags transfer
+// @Param id path int true "Volunteer ID"
+// @Success 200 {array} dto.MembershipResponseDTO
+// @Security bearerToken
+// @Router /api/v1/volunteer/{id}/memberships [get]
+func (h *TransferHandler) ListMemberships(c *gin.Context) {
+	actor, err := resolveActor(c, h.TransferUsecaseH)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	memberships, err := h.TransferUsecaseH.ListMemberships(c.Request.Context(), id, *actor)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, memberships)
+}
+
+func transferErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrTransferForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrTransferSameDepartment),
+		errors.Is(err, usecase.ErrTransferAlreadyPending),
+		errors.Is(err, usecase.ErrTransferNothingToSign),
+		errors.Is(err, usecase.ErrTransferSameApprover),
+		errors.Is(err, storage.ErrTransferNotPending):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -1,12 +1,14 @@
 package transport
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type VolunteerHandler struct {
@@ -32,7 +34,7 @@ func (h *VolunteerHandler) CreateVolunteer(c *gin.Context) {
 		return
 	}
 
-	if err := h.VolUsecaseH.CreateVolunteer(input); err != nil {
+	if err := h.VolUsecaseH.CreateVolunteer(c.Request.Context(), input); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -62,7 +64,11 @@ func (h *VolunteerHandler) UpdateVolunteer(c *gin.Context) {
 		return
 	}
 
-	if err := h.VolUsecaseH.UpdateVolunteer(id, input); err != nil {
+	if err := h.VolUsecaseH.UpdateVolunteer(c.Request.Context(), id, input); err != nil {
+		if errors.Is(err, usecase.ErrDepartmentChangeNeedsTransfer) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -77,6 +83,7 @@ func (h *VolunteerHandler) UpdateVolunteer(c *gin.Context) {
 // @Tags volunteer
 // @Param id path int true "Volunteer ID"
 // @Success 200 {string} message "Volunteer deleted successfully"
+// @Failure 404 {string} error "Volunteer not found"
 // @Router /api/v1/volunteer/{id} [delete]
 func (h *VolunteerHandler) DeleteVolunteer(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -85,7 +92,11 @@ func (h *VolunteerHandler) DeleteVolunteer(c *gin.Context) {
 		return
 	}
 
-	if err = h.VolUsecaseH.DeleteVolunteer(id); err != nil {
+	if err = h.VolUsecaseH.DeleteVolunteer(c.Request.Context(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Volunteer not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -108,7 +119,7 @@ func (h *VolunteerHandler) FindVolunteerByID(c *gin.Context) {
 		return
 	}
 
-	volunteer, err := h.VolUsecaseH.FindVolunteerByID(id)
+	volunteer, err := h.VolUsecaseH.FindVolunteerByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Volunteer not found"})
 		return
@@ -125,7 +136,7 @@ func (h *VolunteerHandler) FindVolunteerByID(c *gin.Context) {
 // @Success 200 {object} domain.VolunteerDetails
 // @Router /api/v1/volunteer/ [get]
 func (h *VolunteerHandler) GetAllVolunteers(c *gin.Context) {
-	volunteers, err := h.VolUsecaseH.GetAllVolunteers()
+	volunteers, err := h.VolUsecaseH.GetAllVolunteers(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+)
+
+// DepartmentScopeResolver returns the departments a user manages, sub-departments included
+type DepartmentScopeResolver interface {
+	ManagedDepartmentIDs(ctx context.Context, userID int) ([]int, error)
+}
+
+// resolveActor loads the departments the user manages, admins (role 1) manage every department
+func resolveActor(ctx context.Context, scope DepartmentScopeResolver, userID int, roleID int) (*dto.Actor, error) {
+	if roleID == 1 {
+		return &dto.Actor{UserID: userID, Admin: true}, nil
+	}
+	departmentIDs, err := scope.ManagedDepartmentIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Actor{UserID: userID, ManagedDepartments: departmentIDs}, nil
+}
@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+)
+
+var (
+	ErrShiftFull          = errors.New("shift is already at capacity")
+	ErrShiftDoubleBooking = errors.New("volunteer is already assigned to an overlapping shift")
+)
+
+type ScheduleUsecaseInterface interface {
+	GetAvailability(ctx context.Context, volunteerID int) ([]dto.AvailabilityWindowDTO, error)
+	UpdateAvailability(ctx context.Context, volunteerID int, input dto.AvailabilityUpdateDTO) error
+	ListBlackouts(ctx context.Context, volunteerID int) ([]dto.BlackoutResponseDTO, error)
+	CreateBlackout(ctx context.Context, volunteerID int, input dto.BlackoutCreateDTO) (*dto.BlackoutResponseDTO, error)
+	DeleteBlackout(ctx context.Context, volunteerID int, id int) error
+	CreateShift(ctx context.Context, input dto.ShiftCreateDTO) (*dto.ShiftResponseDTO, error)
+	FindShiftByID(ctx context.Context, id int) (*dto.ShiftResponseDTO, error)
+	ListShifts(ctx context.Context, filter dto.ShiftFilter) ([]dto.ShiftResponseDTO, error)
+	DeleteShift(ctx context.Context, id int) error
+	SuggestVolunteers(ctx context.Context, shiftID int) ([]dto.VolunteerResponseDTO, error)
+	AssignVolunteer(ctx context.Context, shiftID int, input dto.ShiftAssignDTO, assignedBy int) error
+	UnassignVolunteer(ctx context.Context, shiftID int, volunteerID int) error
+}
+
+// ShiftNotifier tells a volunteer they were scheduled on a shift
+type ShiftNotifier interface {
+	NotifyShiftAssigned(ctx context.Context, userID int, shift *domain.Shift)
+}
+
+type ScheduleUsecase struct {
+	AvailabilityRepo storage.AvailabilityRepositoryInterface
+	ShiftRepo        storage.ShiftRepositoryInterface
+	VolunteerRepo    storage.VolunteerRepositoryInterface
+	Notifier         ShiftNotifier
+	UnitOfWork       uow.Runner
+}
+
+func NewScheduleUsecase(availabilityRepo storage.AvailabilityRepositoryInterface, shiftRepo storage.ShiftRepositoryInterface, volunteerRepo storage.VolunteerRepositoryInterface, notifier ShiftNotifier, unitOfWork uow.Runner) *ScheduleUsecase {
+	return &ScheduleUsecase{AvailabilityRepo: availabilityRepo, ShiftRepo: shiftRepo, VolunteerRepo: volunteerRepo, Notifier: notifier, UnitOfWork: unitOfWork}
+}
+
+func (u *ScheduleUsecase) GetAvailability(ctx context.Context, volunteerID int) ([]dto.AvailabilityWindowDTO, error) {
+	windows, err := u.AvailabilityRepo.ListAvailability(ctx, []int{volunteerID})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.AvailabilityWindowDTO, 0, len(windows))
+	for _, window := range windows {
+		response = append(response, dto.AvailabilityWindowDTO{
+			Weekday:   int(window.Weekday),
+			StartTime: formatMinuteOfDay(window.StartMinute),
+			EndTime:   formatMinuteOfDay(window.EndMinute),
+		})
+	}
+	return response, nil
+}
+
+// UpdateAvailability replaces the weekly windows of the volunteer with the given ones
+func (u *ScheduleUsecase) UpdateAvailability(ctx context.Context, volunteerID int, input dto.AvailabilityUpdateDTO) error {
+	if _, err := u.VolunteerRepo.FindVolunteerByID(ctx, volunteerID); err != nil {
+		return errors.New("volunteer not found")
+	}
+	windows := make([]*domain.VolunteerAvailability, 0, len(input.Windows))
+	for _, w := range input.Windows {
+		if w.Weekday < 0 || w.Weekday > 6 {
+			return fmt.Errorf("invalid weekday %d", w.Weekday)
+		}
+		start, err := parseMinuteOfDay(w.StartTime)
+		if err != nil {
+			return err
+		}
+		end, err := parseMinuteOfDay(w.EndTime)
+		if err != nil {
+			return err
+		}
+		if end <= start {
+			return fmt.Errorf("window %s-%s ends before it starts", w.StartTime, w.EndTime)
+		}
+		windows = append(windows, &domain.VolunteerAvailability{
+			VolunteerID: volunteerID,
+			Weekday:     time.Weekday(w.Weekday),
+			StartMinute: start,
+			EndMinute:   end,
+		})
+	}
+	return u.AvailabilityRepo.ReplaceAvailability(ctx, volunteerID, windows)
+}
+
+func (u *ScheduleUsecase) ListBlackouts(ctx context.Context, volunteerID int) ([]dto.BlackoutResponseDTO, error) {
+	blackouts, err := u.AvailabilityRepo.ListBlackouts(ctx, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.BlackoutResponseDTO, 0, len(blackouts))
+	for _, blackout := range blackouts {
+		response = append(response, toBlackoutResponse(blackout))
+	}
+	return response, nil
+}
+
+func (u *ScheduleUsecase) CreateBlackout(ctx context.Context, volunteerID int, input dto.BlackoutCreateDTO) (*dto.BlackoutResponseDTO, error) {
+	date, err := time.Parse("2006-01-02", input.Date)
+	if err != nil {
+		return nil, errors.New("invalid date")
+	}
+	if _, err := u.VolunteerRepo.FindVolunteerByID(ctx, volunteerID); err != nil {
+		return nil, errors.New("volunteer not found")
+	}
+	blackout := &domain.VolunteerBlackout{
+		VolunteerID: volunteerID,
+		Date:        date,
+		Reason:      input.Reason,
+	}
+	if err := u.AvailabilityRepo.CreateBlackout(ctx, blackout); err != nil {
+		return nil, err
+	}
+	response := toBlackoutResponse(blackout)
+	return &response, nil
+}
+
+func (u *ScheduleUsecase) DeleteBlackout(ctx context.Context, volunteerID int, id int) error {
+	return u.AvailabilityRepo.DeleteBlackout(ctx, volunteerID, id)
+}
+
+func (u *ScheduleUsecase) CreateShift(ctx context.Context, input dto.ShiftCreateDTO) (*dto.ShiftResponseDTO, error) {
+	if !input.EndTime.After(input.StartTime) {
+		return nil, errors.New("end time must be after start time")
+	}
+	if !sameDay(input.StartTime, input.EndTime) {
+		return nil, errors.New("a shift must start and end on the same day")
+	}
+	if input.Capacity < 1 {
+		return nil, errors.New("capacity must be at least 1")
+	}
+	shift := &domain.Shift{
+		DepartmentID: input.DepartmentID,
+		Name:         input.Name,
+		StartTime:    input.StartTime,
+		EndTime:      input.EndTime,
+		Capacity:     input.Capacity,
+	}
+	if err := u.ShiftRepo.CreateShift(ctx, shift); err != nil {
+		return nil, err
+	}
+	response := toShiftResponse(shift, nil)
+	return &response, nil
+}
+
+func (u *ScheduleUsecase) FindShiftByID(ctx context.Context, id int) (*dto.ShiftResponseDTO, error) {
+	shift, err := u.ShiftRepo.FindShiftByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := u.ShiftRepo.ListAssignments(ctx, []int{id})
+	if err != nil {
+		return nil, err
+	}
+	response := toShiftResponse(shift, assignments)
+	return &response, nil
+}
+
+func (u *ScheduleUsecase) ListShifts(ctx context.Context, filter dto.ShiftFilter) ([]dto.ShiftResponseDTO, error) {
+	shifts, err := u.ShiftRepo.ListShifts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(shifts) == 0 {
+		return []dto.ShiftResponseDTO{}, nil
+	}
+	ids := make([]int, 0, len(shifts))
+	for _, shift := range shifts {
+		ids = append(ids, shift.ID)
+	}
+	assignments, err := u.ShiftRepo.ListAssignments(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byShift := make(map[int][]*domain.ShiftAssignment)
+	for _, assignment := range assignments {
+		byShift[assignment.ShiftID] = append(byShift[assignment.ShiftID], assignment)
+	}
+	response := make([]dto.ShiftResponseDTO, 0, len(shifts))
+	for _, shift := range shifts {
+		response = append(response, toShiftResponse(shift, byShift[shift.ID]))
+	}
+	return response, nil
+}
+
+func (u *ScheduleUsecase) DeleteShift(ctx context.Context, id int) error {
+	return u.ShiftRepo.DeleteShift(ctx, id)
+}
+
+// SuggestVolunteers lists active volunteers of the shift's department whose weekly availability
+// covers the shift, who have no blackout on that day and who are not booked on an overlapping shift
+func (u *ScheduleUsecase) SuggestVolunteers(ctx context.Context, shiftID int) ([]dto.VolunteerResponseDTO, error) {
+	shift, err := u.ShiftRepo.FindShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	volunteers, err := u.VolunteerRepo.GetActiveVolunteersByDepartment(ctx, shift.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	response := []dto.VolunteerResponseDTO{}
+	if len(volunteers) == 0 {
+		return response, nil
+	}
+	ids := make([]int, 0, len(volunteers))
+	for _, volunteer := range volunteers {
+		ids = append(ids, volunteer.ID)
+	}
+	windows, err := u.AvailabilityRepo.ListAvailability(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	blackouts, err := u.AvailabilityRepo.ListBlackoutsOn(ctx, ids, shift.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	busy, err := u.ShiftRepo.ListBusyVolunteers(ctx, ids, shift.StartTime, shift.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	for _, volunteer := range matchVolunteers(shift, volunteers, windows, blackouts, busy) {
+		response = append(response, dto.VolunteerResponseDTO{
+			ID:           volunteer.ID,
+			UserID:       volunteer.UserID,
+			DepartmentID: volunteer.DepartmentID,
+			Status:       volunteer.Status,
+		})
+	}
+	return response, nil
+}
+
+// AssignVolunteer books the volunteer on the shift, rejecting full shifts and double-booking.
+// The checks and the insert run in one transaction on the locked shift and volunteer, so two
+// concurrent assignments can neither both take the last seat nor book the volunteer on two
+// overlapping shifts. The shift is always locked first.
+func (u *ScheduleUsecase) AssignVolunteer(ctx context.Context, shiftID int, input dto.ShiftAssignDTO, assignedBy int) error {
+	var shift *domain.Shift
+	var volunteer *domain.VolunteerDetails
+	err := u.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		shift, err = u.ShiftRepo.LockShift(ctx, shiftID)
+		if err != nil {
+			return errors.New("shift not found")
+		}
+		volunteer, err = u.ShiftRepo.LockVolunteer(ctx, input.VolunteerID)
+		if err != nil {
+			return errors.New("volunteer not found")
+		}
+		assignments, err := u.ShiftRepo.ListAssignments(ctx, []int{shiftID})
+		if err != nil {
+			return err
+		}
+		if len(assignments) >= shift.Capacity {
+			return ErrShiftFull
+		}
+		busy, err := u.ShiftRepo.ListBusyVolunteers(ctx, []int{input.VolunteerID}, shift.StartTime, shift.EndTime)
+		if err != nil {
+			return err
+		}
+		if len(busy) > 0 {
+			return ErrShiftDoubleBooking
+		}
+		return u.ShiftRepo.CreateAssignment(ctx, &domain.ShiftAssignment{
+			ShiftID:     shiftID,
+			VolunteerID: input.VolunteerID,
+			AssignedBy:  &assignedBy,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	u.Notifier.NotifyShiftAssigned(ctx, volunteer.UserID, shift)
+	return nil
+}
+
+func (u *ScheduleUsecase) UnassignVolunteer(ctx context.Context, shiftID int, volunteerID int) error {
+	return u.ShiftRepo.DeleteAssignment(ctx, shiftID, volunteerID)
+}
+
+func matchVolunteers(shift *domain.Shift, volunteers []*domain.VolunteerDetails, windows []*domain.VolunteerAvailability, blackouts []*domain.VolunteerBlackout, busy []int) []*domain.VolunteerDetails {
+	available := make(map[int]bool)
+	for _, window := range windows {
+		if window.Covers(shift.StartTime, shift.EndTime) {
+			available[window.VolunteerID] = true
+		}
+	}
+	for _, blackout := range blackouts {
+		delete(available, blackout.VolunteerID)
+	}
+	for _, id := range busy {
+		delete(available, id)
+	}
+	var matched []*domain.VolunteerDetails
+	for _, volunteer := range volunteers {
+		if available[volunteer.ID] {
+			matched = append(matched, volunteer)
+		}
+	}
+	return matched
+}
+
+func parseMinuteOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatMinuteOfDay(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func sameDay(a, b time.Time) bool {
+	b = b.In(a.Location())
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+func toBlackoutResponse(blackout *domain.VolunteerBlackout) dto.BlackoutResponseDTO {
+	return dto.BlackoutResponseDTO{
+		ID:     blackout.ID,
+		Date:   blackout.Date.Format("2006-01-02"),
+		Reason: blackout.Reason,
+	}
+}
+
+func toShiftResponse(shift *domain.Shift, assignments []*domain.ShiftAssignment) dto.ShiftResponseDTO {
+	assigned := make([]int, 0, len(assignments))
+	for _, assignment := range assignments {
+		assigned = append(assigned, assignment.VolunteerID)
+	}
+	return dto.ShiftResponseDTO{
+		ID:           shift.ID,
+		DepartmentID: shift.DepartmentID,
+		Name:         shift.Name,
+		StartTime:    shift.StartTime,
+		EndTime:      shift.EndTime,
+		Capacity:     shift.Capacity,
+		Assigned:     assigned,
+	}
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockShiftRepository struct {
+	mock.Mock
+}
+
+func (m *mockShiftRepository) CreateShift(ctx context.Context, shift *domain.Shift) error {
+	args := m.Called(shift)
+	return args.Error(0)
+}
+
+func (m *mockShiftRepository) FindShiftByID(ctx context.Context, id int) (*domain.Shift, error) {
+	args := m.Called(id)
+	shift, _ := args.Get(0).(*domain.Shift)
+	return shift, args.Error(1)
+}
+
+func (m *mockShiftRepository) LockShift(ctx context.Context, id int) (*domain.Shift, error) {
+	args := m.Called(id)
+	shift, _ := args.Get(0).(*domain.Shift)
+	return shift, args.Error(1)
+}
+
+func (m *mockShiftRepository) LockVolunteer(ctx context.Context, id int) (*domain.VolunteerDetails, error) {
+	args := m.Called(id)
+	volunteer, _ := args.Get(0).(*domain.VolunteerDetails)
+	return volunteer, args.Error(1)
+}
+
+func (m *mockShiftRepository) ListShifts(ctx context.Context, filter dto.ShiftFilter) ([]*domain.Shift, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*domain.Shift), args.Error(1)
+}
+
+func (m *mockShiftRepository) DeleteShift(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *mockShiftRepository) CreateAssignment(ctx context.Context, assignment *domain.ShiftAssignment) error {
+	args := m.Called(assignment)
+	return args.Error(0)
+}
+
+func (m *mockShiftRepository) DeleteAssignment(ctx context.Context, shiftID int, volunteerID int) error {
+	args := m.Called(shiftID, volunteerID)
+	return args.Error(0)
+}
+
+func (m *mockShiftRepository) ListAssignments(ctx context.Context, shiftIDs []int) ([]*domain.ShiftAssignment, error) {
+	args := m.Called(shiftIDs)
+	return args.Get(0).([]*domain.ShiftAssignment), args.Error(1)
+}
+
+func (m *mockShiftRepository) ListBusyVolunteers(ctx context.Context, volunteerIDs []int, start, end time.Time) ([]int, error) {
+	args := m.Called(volunteerIDs, start, end)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+// inlineRunner runs the unit of work without a database, counting the units it ran
+type inlineRunner struct {
+	runs int
+}
+
+func (r *inlineRunner) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	r.runs++
+	return fn(ctx)
+}
+
+type recordingShiftNotifier struct {
+	userIDs []int
+}
+
+func (n *recordingShiftNotifier) NotifyShiftAssigned(ctx context.Context, userID int, shift *domain.Shift) {
+	n.userIDs = append(n.userIDs, userID)
+}
+
+func TestMatchVolunteers(t *testing.T) {
+	// Monday 2024-06-03 09:00-12:00
+	shift := &domain.Shift{
+		ID:           1,
+		DepartmentID: 2,
+		StartTime:    time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC),
+		EndTime:      time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC),
+		Capacity:     3,
+	}
+	volunteers := []*domain.VolunteerDetails{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}
+	windows := []*domain.VolunteerAvailability{
+		{VolunteerID: 1, Weekday: time.Monday, StartMinute: 8 * 60, EndMinute: 13 * 60},
+		{VolunteerID: 2, Weekday: time.Monday, StartMinute: 10 * 60, EndMinute: 13 * 60},
+		{VolunteerID: 3, Weekday: time.Tuesday, StartMinute: 8 * 60, EndMinute: 13 * 60},
+		{VolunteerID: 4, Weekday: time.Monday, StartMinute: 9 * 60, EndMinute: 12 * 60},
+		{VolunteerID: 5, Weekday: time.Monday, StartMinute: 0, EndMinute: 24 * 60},
+	}
+	blackouts := []*domain.VolunteerBlackout{{VolunteerID: 4, Date: shift.StartTime}}
+	busy := []int{5}
+
+	matched := matchVolunteers(shift, volunteers, windows, blackouts, busy)
+
+	assert.Len(t, matched, 1)
+	assert.Equal(t, 1, matched[0].ID)
+}
+
+func TestUpdateAvailability_InvalidWindow(t *testing.T) {
+	volunteerRepo := new(MockVolunteerRepository)
+	usecase := NewScheduleUsecase(nil, nil, volunteerRepo, nil, nil)
+	volunteerRepo.On("FindVolunteerByID", 1).Return(&domain.VolunteerDetails{ID: 1}, nil)
+
+	err := usecase.UpdateAvailability(context.Background(), 1, dto.AvailabilityUpdateDTO{
+		Windows: []dto.AvailabilityWindowDTO{{Weekday: 1, StartTime: "17:00", EndTime: "09:00"}},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestCreateShift_SpansMidnight(t *testing.T) {
+	usecase := NewScheduleUsecase(nil, nil, nil, nil, nil)
+	start := time.Date(2024, 6, 3, 22, 0, 0, 0, time.UTC)
+
+	_, err := usecase.CreateShift(context.Background(), dto.ShiftCreateDTO{
+		DepartmentID: 1,
+		Name:         "Night",
+		StartTime:    start,
+		EndTime:      start.Add(4 * time.Hour),
+		Capacity:     1,
+	})
+
+	assert.EqualError(t, err, "a shift must start and end on the same day")
+}
+
+func TestAssignVolunteer(t *testing.T) {
+	shiftRepo := new(mockShiftRepository)
+	volunteerRepo := new(MockVolunteerRepository)
+	notifier := new(recordingShiftNotifier)
+	runner := new(inlineRunner)
+	usecase := NewScheduleUsecase(nil, shiftRepo, volunteerRepo, notifier, runner)
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	shift := &domain.Shift{ID: 1, StartTime: start, EndTime: start.Add(3 * time.Hour), Capacity: 2}
+	shiftRepo.On("LockShift", 1).Return(shift, nil)
+	shiftRepo.On("LockVolunteer", 5).Return(&domain.VolunteerDetails{ID: 5, UserID: 50}, nil)
+	shiftRepo.On("ListAssignments", []int{1}).Return([]*domain.ShiftAssignment{{ShiftID: 1, VolunteerID: 4}}, nil)
+	shiftRepo.On("ListBusyVolunteers", []int{5}, shift.StartTime, shift.EndTime).Return([]int{}, nil)
+	shiftRepo.On("CreateAssignment", mock.MatchedBy(func(assignment *domain.ShiftAssignment) bool {
+		return assignment.ShiftID == 1 && assignment.VolunteerID == 5 && *assignment.AssignedBy == 7
+	})).Return(nil)
+
+	err := usecase.AssignVolunteer(context.Background(), 1, dto.ShiftAssignDTO{VolunteerID: 5}, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, runner.runs)
+	assert.Equal(t, []int{50}, notifier.userIDs)
+	shiftRepo.AssertExpectations(t)
+}
+
+func TestAssignVolunteer_ShiftFull(t *testing.T) {
+	shiftRepo := new(mockShiftRepository)
+	volunteerRepo := new(MockVolunteerRepository)
+	notifier := new(recordingShiftNotifier)
+	usecase := NewScheduleUsecase(nil, shiftRepo, volunteerRepo, notifier, new(inlineRunner))
+	shiftRepo.On("LockShift", 1).Return(&domain.Shift{ID: 1, Capacity: 1}, nil)
+	shiftRepo.On("LockVolunteer", 5).Return(&domain.VolunteerDetails{ID: 5, UserID: 50}, nil)
+	shiftRepo.On("ListAssignments", []int{1}).Return([]*domain.ShiftAssignment{{ShiftID: 1, VolunteerID: 4}}, nil)
+
+	err := usecase.AssignVolunteer(context.Background(), 1, dto.ShiftAssignDTO{VolunteerID: 5}, 7)
+
+	assert.ErrorIs(t, err, ErrShiftFull)
+	assert.Empty(t, notifier.userIDs)
+	shiftRepo.AssertNotCalled(t, "CreateAssignment", mock.Anything)
+}
+
+func TestAssignVolunteer_DoubleBooking(t *testing.T) {
+	shiftRepo := new(mockShiftRepository)
+	notifier := new(recordingShiftNotifier)
+	usecase := NewScheduleUsecase(nil, shiftRepo, new(MockVolunteerRepository), notifier, new(inlineRunner))
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	shift := &domain.Shift{ID: 1, StartTime: start, EndTime: start.Add(3 * time.Hour), Capacity: 2}
+	var locked []string
+	shiftRepo.On("LockShift", 1).Return(shift, nil).Run(func(mock.Arguments) { locked = append(locked, "shift") })
+	shiftRepo.On("LockVolunteer", 5).Return(&domain.VolunteerDetails{ID: 5, UserID: 50}, nil).Run(func(mock.Arguments) { locked = append(locked, "volunteer") })
+	shiftRepo.On("ListAssignments", []int{1}).Return([]*domain.ShiftAssignment{}, nil)
+	shiftRepo.On("ListBusyVolunteers", []int{5}, shift.StartTime, shift.EndTime).Return([]int{5}, nil)
+
+	err := usecase.AssignVolunteer(context.Background(), 1, dto.ShiftAssignDTO{VolunteerID: 5}, 7)
+
+	assert.ErrorIs(t, err, ErrShiftDoubleBooking)
+	assert.Equal(t, []string{"shift", "volunteer"}, locked)
+	assert.Empty(t, notifier.userIDs)
+	shiftRepo.AssertNotCalled(t, "CreateAssignment", mock.Anything)
+}
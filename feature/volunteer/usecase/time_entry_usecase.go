@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/export"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+)
+
+// ErrTimeEntryForbidden is returned when logging hours for a volunteer who is neither the actor nor in a
+// department they manage
+var ErrTimeEntryForbidden = errors.New("forbidden: you cannot log hours for this volunteer")
+
+type TimeEntryUsecaseInterface interface {
+	ResolveActor(ctx context.Context, userID int, roleID int) (*dto.Actor, error)
+	CreateTimeEntry(ctx context.Context, input dto.TimeEntryCreateDTO, actor dto.Actor) (*dto.TimeEntryResponseDTO, error)
+	ListTimeEntries(ctx context.Context, filter dto.TimeEntryFilter) ([]dto.TimeEntryResponseDTO, error)
+	ApproveTimeEntry(ctx context.Context, id int, approverID int) error
+	RejectTimeEntry(ctx context.Context, id int, approverID int, input dto.TimeEntryRejectDTO) error
+	TotalsByVolunteer(ctx context.Context, filter dto.TimeEntryFilter) ([]dto.HoursTotalDTO, error)
+	TotalsByDepartment(ctx context.Context, filter dto.TimeEntryFilter) ([]dto.HoursTotalDTO, error)
+	ExportTimeEntries(ctx context.Context, filter dto.TimeEntryFilter, format string, w io.Writer) error
+}
+
+type TimeEntryUsecase struct {
+	TimeEntryRepo storage.TimeEntryRepositoryInterface
+	VolunteerRepo storage.VolunteerRepositoryInterface
+	DeptScope     DepartmentScopeResolver
+}
+
+func NewTimeEntryUsecase(timeEntryRepo storage.TimeEntryRepositoryInterface, volunteerRepo storage.VolunteerRepositoryInterface, deptScope DepartmentScopeResolver) *TimeEntryUsecase {
+	return &TimeEntryUsecase{TimeEntryRepo: timeEntryRepo, VolunteerRepo: volunteerRepo, DeptScope: deptScope}
+}
+
+func (u *TimeEntryUsecase) ResolveActor(ctx context.Context, userID int, roleID int) (*dto.Actor, error) {
+	return resolveActor(ctx, u.DeptScope, userID, roleID)
+}
+
+// CreateTimeEntry logs a pending entry, the department defaults to the volunteer's current department.
+// Volunteers log their own hours, managers the hours of their departments.
+func (u *TimeEntryUsecase) CreateTimeEntry(ctx context.Context, input dto.TimeEntryCreateDTO, actor dto.Actor) (*dto.TimeEntryResponseDTO, error) {
+	if !input.EndTime.After(input.StartTime) {
+		return nil, errors.New("end time must be after start time")
+	}
+	if input.EndTime.After(time.Now()) {
+		return nil, errors.New("cannot log hours in the future")
+	}
+	volunteer, err := u.VolunteerRepo.FindVolunteerByID(ctx, input.VolunteerID)
+	if err != nil {
+		return nil, errors.New("volunteer not found")
+	}
+	departmentID := input.DepartmentID
+	if departmentID == 0 {
+		departmentID = volunteer.DepartmentID
+	}
+	if volunteer.UserID != actor.UserID && !actor.Manages(volunteer.DepartmentID) && !actor.Manages(departmentID) {
+		return nil, ErrTimeEntryForbidden
+	}
+	entry := &domain.TimeEntry{
+		VolunteerID:  volunteer.ID,
+		EventID:      input.EventID,
+		DepartmentID: departmentID,
+		StartTime:    input.StartTime,
+		EndTime:      input.EndTime,
+		Description:  input.Description,
+		Status:       domain.TimeEntryPending,
+	}
+	if err := u.TimeEntryRepo.CreateTimeEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+	response := toTimeEntryResponse(entry)
+	return &response, nil
+}
+
+func (u *TimeEntryUsecase) ListTimeEntries(ctx context.Context, filter dto.TimeEntryFilter) ([]dto.TimeEntryResponseDTO, error) {
+	entries, err := u.TimeEntryRepo.ListTimeEntries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.TimeEntryResponseDTO, 0, len(entries))
+	for _, entry := range entries {
+		response = append(response, toTimeEntryResponse(entry))
+	}
+	return response, nil
+}
+
+func (u *TimeEntryUsecase) ApproveTimeEntry(ctx context.Context, id int, approverID int) error {
+	return u.TimeEntryRepo.ApproveTimeEntry(ctx, id, approverID)
+}
+
+func (u *TimeEntryUsecase) RejectTimeEntry(ctx context.Context, id int, approverID int, input dto.TimeEntryRejectDTO) error {
+	return u.TimeEntryRepo.RejectTimeEntry(ctx, id, approverID, input.Notes)
+}
+
+func (u *TimeEntryUsecase) TotalsByVolunteer(ctx context.Context, filter dto.TimeEntryFilter) ([]dto.HoursTotalDTO, error) {
+	totals, err := u.TimeEntryRepo.TotalsByVolunteer(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return toHoursTotals(totals), nil
+}
+
+func (u *TimeEntryUsecase) TotalsByDepartment(ctx context.Context, filter dto.TimeEntryFilter) ([]dto.HoursTotalDTO, error) {
+	totals, err := u.TimeEntryRepo.TotalsByDepartment(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return toHoursTotals(totals), nil
+}
+
+// ExportTimeEntries streams the entries matching filter in format, csv or xlsx
+func (u *TimeEntryUsecase) ExportTimeEntries(ctx context.Context, filter dto.TimeEntryFilter, format string, w io.Writer) error {
+	header := []string{"id", "volunteer_id", "event_id", "department_id", "start_time", "end_time", "minutes", "status", "approver_id", "description"}
+	return export.Write(format, w, header, func(write func([]string) error) error {
+		return u.TimeEntryRepo.StreamTimeEntries(ctx, filter, func(entry *domain.TimeEntry) error {
+			return write(timeEntryRow(entry))
+		})
+	})
+}
+
+func timeEntryRow(entry *domain.TimeEntry) []string {
+	eventID := ""
+	if entry.EventID != nil {
+		eventID = strconv.Itoa(*entry.EventID)
+	}
+	approverID := ""
+	if entry.ApproverID != nil {
+		approverID = strconv.Itoa(*entry.ApproverID)
+	}
+	return []string{
+		strconv.Itoa(entry.ID),
+		strconv.Itoa(entry.VolunteerID),
+		eventID,
+		strconv.Itoa(entry.DepartmentID),
+		entry.StartTime.Format(time.RFC3339),
+		entry.EndTime.Format(time.RFC3339),
+		strconv.Itoa(entry.Minutes()),
+		timeEntryStatusName(entry.Status),
+		approverID,
+		entry.Description,
+	}
+}
+
+func timeEntryStatusName(status int) string {
+	switch status {
+	case domain.TimeEntryApproved:
+		return "approved"
+	case domain.TimeEntryRejected:
+		return "rejected"
+	default:
+		return "pending"
+	}
+}
+
+func toTimeEntryResponse(entry *domain.TimeEntry) dto.TimeEntryResponseDTO {
+	return dto.TimeEntryResponseDTO{
+		ID:           entry.ID,
+		VolunteerID:  entry.VolunteerID,
+		EventID:      entry.EventID,
+		DepartmentID: entry.DepartmentID,
+		StartTime:    entry.StartTime,
+		EndTime:      entry.EndTime,
+		Minutes:      entry.Minutes(),
+		Description:  entry.Description,
+		Status:       entry.Status,
+		ApproverID:   entry.ApproverID,
+		ApprovedAt:   entry.ApprovedAt,
+		RejectNotes:  entry.RejectNotes,
+	}
+}
+
+func toHoursTotals(totals []*domain.HoursTotal) []dto.HoursTotalDTO {
+	response := make([]dto.HoursTotalDTO, 0, len(totals))
+	for _, total := range totals {
+		response = append(response, dto.HoursTotalDTO{
+			VolunteerID:  total.VolunteerID,
+			DepartmentID: total.DepartmentID,
+			Minutes:      total.Minutes,
+			Hours:        float64(total.Minutes) / 60,
+			Entries:      total.Entries,
+		})
+	}
+	return response
+}
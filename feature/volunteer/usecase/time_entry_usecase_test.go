@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/export"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockTimeEntryRepository struct {
+	mock.Mock
+}
+
+func (m *mockTimeEntryRepository) CreateTimeEntry(ctx context.Context, entry *domain.TimeEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *mockTimeEntryRepository) FindTimeEntryByID(ctx context.Context, id int) (*domain.TimeEntry, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.TimeEntry), args.Error(1)
+}
+
+func (m *mockTimeEntryRepository) ListTimeEntries(ctx context.Context, filter dto.TimeEntryFilter) ([]*domain.TimeEntry, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*domain.TimeEntry), args.Error(1)
+}
+
+func (m *mockTimeEntryRepository) StreamTimeEntries(ctx context.Context, filter dto.TimeEntryFilter, fn func(entry *domain.TimeEntry) error) error {
+	args := m.Called(filter)
+	for _, entry := range args.Get(0).([]*domain.TimeEntry) {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *mockTimeEntryRepository) ApproveTimeEntry(ctx context.Context, id int, approverID int) error {
+	args := m.Called(id, approverID)
+	return args.Error(0)
+}
+
+func (m *mockTimeEntryRepository) RejectTimeEntry(ctx context.Context, id int, approverID int, notes string) error {
+	args := m.Called(id, approverID, notes)
+	return args.Error(0)
+}
+
+func (m *mockTimeEntryRepository) TotalsByVolunteer(ctx context.Context, filter dto.TimeEntryFilter) ([]*domain.HoursTotal, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*domain.HoursTotal), args.Error(1)
+}
+
+func (m *mockTimeEntryRepository) TotalsByDepartment(ctx context.Context, filter dto.TimeEntryFilter) ([]*domain.HoursTotal, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*domain.HoursTotal), args.Error(1)
+}
+
+func TestCreateTimeEntry_DefaultsDepartment(t *testing.T) {
+	entryRepo := new(mockTimeEntryRepository)
+	volunteerRepo := new(MockVolunteerRepository)
+	usecase := NewTimeEntryUsecase(entryRepo, volunteerRepo, nil)
+
+	start := time.Now().Add(-3 * time.Hour)
+	input := dto.TimeEntryCreateDTO{
+		VolunteerID: 1,
+		StartTime:   start,
+		EndTime:     start.Add(90 * time.Minute),
+	}
+
+	volunteerRepo.On("FindVolunteerByID", 1).Return(&domain.VolunteerDetails{ID: 1, UserID: 10, DepartmentID: 7}, nil)
+	entryRepo.On("CreateTimeEntry", mock.MatchedBy(func(entry *domain.TimeEntry) bool {
+		return entry.DepartmentID == 7 && entry.Status == domain.TimeEntryPending
+	})).Return(nil)
+
+	result, err := usecase.CreateTimeEntry(context.Background(), input, dto.Actor{UserID: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 90, result.Minutes)
+	entryRepo.AssertExpectations(t)
+	volunteerRepo.AssertExpectations(t)
+}
+
+func TestCreateTimeEntry_InvalidRange(t *testing.T) {
+	entryRepo := new(mockTimeEntryRepository)
+	volunteerRepo := new(MockVolunteerRepository)
+	usecase := NewTimeEntryUsecase(entryRepo, volunteerRepo, nil)
+
+	start := time.Now().Add(-time.Hour)
+	input := dto.TimeEntryCreateDTO{
+		VolunteerID: 1,
+		StartTime:   start,
+		EndTime:     start.Add(-time.Minute),
+	}
+
+	result, err := usecase.CreateTimeEntry(context.Background(), input, dto.Actor{UserID: 1, Admin: true})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	entryRepo.AssertNotCalled(t, "CreateTimeEntry", mock.Anything)
+}
+
+func TestCreateTimeEntry_VolunteerNotFound(t *testing.T) {
+	entryRepo := new(mockTimeEntryRepository)
+	volunteerRepo := new(MockVolunteerRepository)
+	usecase := NewTimeEntryUsecase(entryRepo, volunteerRepo, nil)
+
+	start := time.Now().Add(-time.Hour)
+	input := dto.TimeEntryCreateDTO{
+		VolunteerID: 9,
+		StartTime:   start,
+		EndTime:     start.Add(time.Minute),
+	}
+	volunteerRepo.On("FindVolunteerByID", 9).Return(nil, errors.New("record not found"))
+
+	_, err := usecase.CreateTimeEntry(context.Background(), input, dto.Actor{UserID: 1, Admin: true})
+
+	assert.EqualError(t, err, "volunteer not found")
+}
+
+func TestCreateTimeEntry_Forbidden(t *testing.T) {
+	entryRepo := new(mockTimeEntryRepository)
+	volunteerRepo := new(MockVolunteerRepository)
+	usecase := NewTimeEntryUsecase(entryRepo, volunteerRepo, nil)
+
+	start := time.Now().Add(-time.Hour)
+	input := dto.TimeEntryCreateDTO{
+		VolunteerID: 1,
+		StartTime:   start,
+		EndTime:     start.Add(time.Minute),
+	}
+	volunteerRepo.On("FindVolunteerByID", 1).Return(&domain.VolunteerDetails{ID: 1, UserID: 10, DepartmentID: 7}, nil)
+
+	_, err := usecase.CreateTimeEntry(context.Background(), input, dto.Actor{UserID: 11, ManagedDepartments: []int{8}})
+	assert.ErrorIs(t, err, ErrTimeEntryForbidden)
+
+	entryRepo.On("CreateTimeEntry", mock.Anything).Return(nil)
+	_, err = usecase.CreateTimeEntry(context.Background(), input, dto.Actor{UserID: 11, ManagedDepartments: []int{7}})
+	assert.NoError(t, err)
+}
+
+func TestExportTimeEntries(t *testing.T) {
+	entryRepo := new(mockTimeEntryRepository)
+	usecase := NewTimeEntryUsecase(entryRepo, new(MockVolunteerRepository), nil)
+
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	approver := 3
+	entries := []*domain.TimeEntry{{
+		ID:           1,
+		VolunteerID:  2,
+		DepartmentID: 4,
+		StartTime:    start,
+		EndTime:      start.Add(2 * time.Hour),
+		Status:       domain.TimeEntryApproved,
+		ApproverID:   &approver,
+		Description:  "sorting, packing",
+	}}
+	entryRepo.On("StreamTimeEntries", dto.TimeEntryFilter{}).Return(entries, nil)
+
+	var buf bytes.Buffer
+	err := usecase.ExportTimeEntries(context.Background(), dto.TimeEntryFilter{}, export.CSV, &buf)
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, `1,2,,4,2024-06-01T09:00:00Z,2024-06-01T11:00:00Z,120,approved,3,"sorting, packing"`, lines[1])
+}
+
+func TestExportTimeEntries_EscapesFormulas(t *testing.T) {
+	entryRepo := new(mockTimeEntryRepository)
+	usecase := NewTimeEntryUsecase(entryRepo, new(MockVolunteerRepository), nil)
+
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	entries := []*domain.TimeEntry{{ID: 1, VolunteerID: 2, DepartmentID: 4, StartTime: start, EndTime: start.Add(time.Hour), Description: "=HYPERLINK(\"http://evil\")"}}
+	entryRepo.On("StreamTimeEntries", dto.TimeEntryFilter{}).Return(entries, nil)
+
+	var buf bytes.Buffer
+	err := usecase.ExportTimeEntries(context.Background(), dto.TimeEntryFilter{}, export.CSV, &buf)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `"'=HYPERLINK(""http://evil"")"`)
+}
@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+)
+
+var (
+	ErrTransferForbidden      = errors.New("forbidden: you cannot act on this transfer")
+	ErrTransferSameDepartment = errors.New("volunteer is already in this department")
+	ErrTransferAlreadyPending = errors.New("volunteer already has a pending transfer")
+	ErrTransferNothingToSign  = errors.New("transfer was already approved on your side")
+	ErrTransferSameApprover   = errors.New("the other department must be approved by another manager")
+)
+
+type TransferUsecaseInterface interface {
+	ResolveActor(ctx context.Context, userID int, roleID int) (*dto.Actor, error)
+	RequestTransfer(ctx context.Context, volunteerID int, input dto.TransferCreateDTO, actor dto.Actor) (*dto.TransferResponseDTO, error)
+	FindTransferByID(ctx context.Context, id int, actor dto.Actor) (*dto.TransferResponseDTO, error)
+	ListTransfers(ctx context.Context, filter dto.TransferFilter) ([]dto.TransferResponseDTO, error)
+	ApproveTransfer(ctx context.Context, id int, actor dto.Actor) (*dto.TransferResponseDTO, error)
+	RejectTransfer(ctx context.Context, id int, actor dto.Actor, input dto.TransferRejectDTO) error
+	CancelTransfer(ctx context.Context, id int, actor dto.Actor) error
+	ListMemberships(ctx context.Context, volunteerID int, actor dto.Actor) ([]dto.MembershipResponseDTO, error)
+}
+
+// TransferNotifier informs the parties of a transfer about its progress
+type TransferNotifier interface {
+	NotifyTransfer(ctx context.Context, userIDs []int, transfer *domain.DepartmentTransfer, message string)
+}
+
+type TransferUsecase struct {
+	TransferRepo  storage.TransferRepositoryInterface
+	VolunteerRepo storage.VolunteerRepositoryInterface
+	DeptScope     DepartmentScopeResolver
+	Notifier      TransferNotifier
+	UnitOfWork    uow.Runner
+}
+
+func NewTransferUsecase(transferRepo storage.TransferRepositoryInterface, volunteerRepo storage.VolunteerRepositoryInterface, deptScope DepartmentScopeResolver, notifier TransferNotifier, unitOfWork uow.Runner) *TransferUsecase {
+	return &TransferUsecase{TransferRepo: transferRepo, VolunteerRepo: volunteerRepo, DeptScope: deptScope, Notifier: notifier, UnitOfWork: unitOfWork}
+}
+
+func (u *TransferUsecase) ResolveActor(ctx context.Context, userID int, roleID int) (*dto.Actor, error) {
+	return resolveActor(ctx, u.DeptScope, userID, roleID)
+}
+
+// RequestTransfer opens a transfer, the volunteer themself or a coordinator of either department may request it
+func (u *TransferUsecase) RequestTransfer(ctx context.Context, volunteerID int, input dto.TransferCreateDTO, actor dto.Actor) (*dto.TransferResponseDTO, error) {
+	volunteer, err := u.VolunteerRepo.FindVolunteerByID(ctx, volunteerID)
+	if err != nil {
+		return nil, errors.New("volunteer not found")
+	}
+	if volunteer.UserID != actor.UserID && !actor.Manages(volunteer.DepartmentID) && !actor.Manages(input.ToDepartmentID) {
+		return nil, ErrTransferForbidden
+	}
+	if volunteer.DepartmentID == input.ToDepartmentID {
+		return nil, ErrTransferSameDepartment
+	}
+	pending, err := u.TransferRepo.HasPendingTransfer(ctx, volunteer.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrTransferAlreadyPending
+	}
+	transfer := &domain.DepartmentTransfer{
+		VolunteerID:      volunteer.ID,
+		FromDepartmentID: volunteer.DepartmentID,
+		ToDepartmentID:   input.ToDepartmentID,
+		RequestedBy:      actor.UserID,
+		Reason:           input.Reason,
+		Status:           domain.TransferPending,
+	}
+	if err := u.TransferRepo.CreateTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+	u.notify(ctx, transfer, volunteer, fmt.Sprintf("transfer from department %d to department %d requested", transfer.FromDepartmentID, transfer.ToDepartmentID))
+	response := toTransferResponse(transfer)
+	return &response, nil
+}
+
+// FindTransferByID returns the transfer to its volunteer, the managers of either department and admins
+func (u *TransferUsecase) FindTransferByID(ctx context.Context, id int, actor dto.Actor) (*dto.TransferResponseDTO, error) {
+	transfer, err := u.TransferRepo.FindTransferByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Manages(transfer.FromDepartmentID) && !actor.Manages(transfer.ToDepartmentID) {
+		volunteer, err := u.VolunteerRepo.FindVolunteerByID(ctx, transfer.VolunteerID)
+		if err != nil {
+			return nil, err
+		}
+		if volunteer.UserID != actor.UserID {
+			return nil, ErrTransferForbidden
+		}
+	}
+	response := toTransferResponse(transfer)
+	return &response, nil
+}
+
+func (u *TransferUsecase) ListTransfers(ctx context.Context, filter dto.TransferFilter) ([]dto.TransferResponseDTO, error) {
+	transfers, err := u.TransferRepo.ListTransfers(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.TransferResponseDTO, 0, len(transfers))
+	for _, transfer := range transfers {
+		response = append(response, toTransferResponse(transfer))
+	}
+	return response, nil
+}
+
+// ApproveTransfer signs off one side of the transfer, the source first when the actor manages
+// both. The move happens once both sides approved, by two different users. The transfer is
+// locked while it is signed, so two managers approving at once cannot overwrite each other.
+func (u *TransferUsecase) ApproveTransfer(ctx context.Context, id int, actor dto.Actor) (*dto.TransferResponseDTO, error) {
+	var transfer *domain.DepartmentTransfer
+	var message string
+	err := u.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		transfer, err = u.TransferRepo.LockTransfer(ctx, id)
+		if err != nil {
+			return err
+		}
+		if transfer.Status != domain.TransferPending {
+			return storage.ErrTransferNotPending
+		}
+		if !actor.Manages(transfer.FromDepartmentID) && !actor.Manages(transfer.ToDepartmentID) {
+			return ErrTransferForbidden
+		}
+		signedSource := transfer.SourceApprovedBy != nil && *transfer.SourceApprovedBy == actor.UserID
+		signedTarget := transfer.TargetApprovedBy != nil && *transfer.TargetApprovedBy == actor.UserID
+		now := time.Now()
+		switch {
+		case transfer.SourceApprovedBy == nil && actor.Manages(transfer.FromDepartmentID) && !signedTarget:
+			transfer.SourceApprovedBy = &actor.UserID
+			transfer.SourceApprovedAt = &now
+		case transfer.TargetApprovedBy == nil && actor.Manages(transfer.ToDepartmentID) && !signedSource:
+			transfer.TargetApprovedBy = &actor.UserID
+			transfer.TargetApprovedAt = &now
+		case signedSource || signedTarget:
+			return ErrTransferSameApprover
+		default:
+			return ErrTransferNothingToSign
+		}
+
+		if !transfer.FullyApproved() {
+			message = "transfer approved by one department, waiting for the other"
+			return u.TransferRepo.UpdatePendingTransfer(ctx, transfer)
+		}
+		transfer.Status = domain.TransferApproved
+		transfer.DecidedBy = &actor.UserID
+		transfer.DecidedAt = &now
+		message = fmt.Sprintf("transfer approved, volunteer moved to department %d", transfer.ToDepartmentID)
+		return u.TransferRepo.CompleteTransfer(ctx, transfer, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.notify(ctx, transfer, nil, message)
+	response := toTransferResponse(transfer)
+	return &response, nil
+}
+
+// RejectTransfer closes the transfer, a manager of either department may reject it
+func (u *TransferUsecase) RejectTransfer(ctx context.Context, id int, actor dto.Actor, input dto.TransferRejectDTO) error {
+	transfer, err := u.TransferRepo.FindTransferByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if transfer.Status != domain.TransferPending {
+		return storage.ErrTransferNotPending
+	}
+	if !actor.Manages(transfer.FromDepartmentID) && !actor.Manages(transfer.ToDepartmentID) {
+		return ErrTransferForbidden
+	}
+	now := time.Now()
+	transfer.Status = domain.TransferRejected
+	transfer.DecidedBy = &actor.UserID
+	transfer.DecidedAt = &now
+	transfer.RejectNotes = input.Notes
+	if err := u.TransferRepo.UpdatePendingTransfer(ctx, transfer); err != nil {
+		return err
+	}
+	u.notify(ctx, transfer, nil, "transfer rejected")
+	return nil
+}
+
+// CancelTransfer withdraws a pending transfer, only its requester or the volunteer may cancel it
+func (u *TransferUsecase) CancelTransfer(ctx context.Context, id int, actor dto.Actor) error {
+	transfer, err := u.TransferRepo.FindTransferByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if transfer.Status != domain.TransferPending {
+		return storage.ErrTransferNotPending
+	}
+	volunteer, err := u.VolunteerRepo.FindVolunteerByID(ctx, transfer.VolunteerID)
+	if err != nil {
+		return err
+	}
+	if transfer.RequestedBy != actor.UserID && volunteer.UserID != actor.UserID {
+		return ErrTransferForbidden
+	}
+	now := time.Now()
+	transfer.Status = domain.TransferCancelled
+	transfer.DecidedBy = &actor.UserID
+	transfer.DecidedAt = &now
+	if err := u.TransferRepo.UpdatePendingTransfer(ctx, transfer); err != nil {
+		return err
+	}
+	u.notify(ctx, transfer, volunteer, "transfer cancelled")
+	return nil
+}
+
+// ListMemberships returns the department history to the volunteer, the managers of a department
+// they belong or belonged to and admins
+func (u *TransferUsecase) ListMemberships(ctx context.Context, volunteerID int, actor dto.Actor) ([]dto.MembershipResponseDTO, error) {
+	volunteer, err := u.VolunteerRepo.FindVolunteerByID(ctx, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := u.TransferRepo.ListMemberships(ctx, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	allowed := volunteer.UserID == actor.UserID || actor.Manages(volunteer.DepartmentID)
+	for _, membership := range memberships {
+		allowed = allowed || actor.Manages(membership.DepartmentID)
+	}
+	if !allowed {
+		return nil, ErrTransferForbidden
+	}
+	response := make([]dto.MembershipResponseDTO, 0, len(memberships))
+	for _, membership := range memberships {
+		item := dto.MembershipResponseDTO{
+			DepartmentID: membership.DepartmentID,
+			StartDate:    membership.StartDate.Format("2006-01-02"),
+			TransferID:   membership.TransferID,
+		}
+		if membership.EndDate != nil {
+			end := membership.EndDate.Format("2006-01-02")
+			item.EndDate = &end
+		}
+		response = append(response, item)
+	}
+	return response, nil
+}
+
+// notify sends message to the volunteer, the requester and the managers of both departments,
+// notification failures never fail the transfer itself
+func (u *TransferUsecase) notify(ctx context.Context, transfer *domain.DepartmentTransfer, volunteer *domain.VolunteerDetails, message string) {
+	if volunteer == nil {
+		found, err := u.VolunteerRepo.FindVolunteerByID(ctx, transfer.VolunteerID)
+		if err != nil {
+			log.Printf("transfer %d: cannot load volunteer for notification: %v", transfer.ID, err)
+			return
+		}
+		volunteer = found
+	}
+	managers, err := u.TransferRepo.ListDepartmentManagerUserIDs(ctx, []int{transfer.FromDepartmentID, transfer.ToDepartmentID})
+	if err != nil {
+		log.Printf("transfer %d: cannot load department managers for notification: %v", transfer.ID, err)
+	}
+	u.Notifier.NotifyTransfer(context.WithoutCancel(ctx), transferParties(volunteer.UserID, transfer.RequestedBy, managers), transfer, message)
+}
+
+func transferParties(volunteerUserID int, requestedBy int, managers []int) []int {
+	seen := make(map[int]bool)
+	var parties []int
+	for _, id := range append([]int{volunteerUserID, requestedBy}, managers...) {
+		if !seen[id] {
+			seen[id] = true
+			parties = append(parties, id)
+		}
+	}
+	return parties
+}
+
+func toTransferResponse(transfer *domain.DepartmentTransfer) dto.TransferResponseDTO {
+	return dto.TransferResponseDTO{
+		ID:               transfer.ID,
+		VolunteerID:      transfer.VolunteerID,
+		FromDepartmentID: transfer.FromDepartmentID,
+		ToDepartmentID:   transfer.ToDepartmentID,
+		RequestedBy:      transfer.RequestedBy,
+		Reason:           transfer.Reason,
+		Status:           transfer.Status,
+		SourceApprovedBy: transfer.SourceApprovedBy,
+		SourceApprovedAt: transfer.SourceApprovedAt,
+		TargetApprovedBy: transfer.TargetApprovedBy,
+		TargetApprovedAt: transfer.TargetApprovedAt,
+		DecidedBy:        transfer.DecidedBy,
+		DecidedAt:        transfer.DecidedAt,
+		RejectNotes:      transfer.RejectNotes,
+		CreatedAt:        transfer.CreatedAt,
+	}
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockTransferRepository struct {
+	mock.Mock
+}
+
+func (m *mockTransferRepository) CreateTransfer(ctx context.Context, transfer *domain.DepartmentTransfer) error {
+	args := m.Called(transfer)
+	return args.Error(0)
+}
+
+func (m *mockTransferRepository) FindTransferByID(ctx context.Context, id int) (*domain.DepartmentTransfer, error) {
+	args := m.Called(id)
+	transfer, _ := args.Get(0).(*domain.DepartmentTransfer)
+	return transfer, args.Error(1)
+}
+
+func (m *mockTransferRepository) LockTransfer(ctx context.Context, id int) (*domain.DepartmentTransfer, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.DepartmentTransfer), args.Error(1)
+}
+
+func (m *mockTransferRepository) HasPendingTransfer(ctx context.Context, volunteerID int) (bool, error) {
+	args := m.Called(volunteerID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockTransferRepository) ListTransfers(ctx context.Context, filter dto.TransferFilter) ([]*domain.DepartmentTransfer, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*domain.DepartmentTransfer), args.Error(1)
+}
+
+func (m *mockTransferRepository) UpdatePendingTransfer(ctx context.Context, transfer *domain.DepartmentTransfer) error {
+	args := m.Called(transfer)
+	return args.Error(0)
+}
+
+func (m *mockTransferRepository) CompleteTransfer(ctx context.Context, transfer *domain.DepartmentTransfer, effective time.Time) error {
+	args := m.Called(transfer, effective)
+	return args.Error(0)
+}
+
+func (m *mockTransferRepository) ListMemberships(ctx context.Context, volunteerID int) ([]*domain.DepartmentMembership, error) {
+	args := m.Called(volunteerID)
+	return args.Get(0).([]*domain.DepartmentMembership), args.Error(1)
+}
+
+func (m *mockTransferRepository) ListDepartmentManagerUserIDs(ctx context.Context, departmentIDs []int) ([]int, error) {
+	args := m.Called(departmentIDs)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+type recordingNotifier struct {
+	userIDs  [][]int
+	messages []string
+}
+
+func (n *recordingNotifier) NotifyTransfer(ctx context.Context, userIDs []int, transfer *domain.DepartmentTransfer, message string) {
+	n.userIDs = append(n.userIDs, userIDs)
+	n.messages = append(n.messages, message)
+}
+
+func newTransferFixture() (*TransferUsecase, *mockTransferRepository, *MockVolunteerRepository, *recordingNotifier) {
+	transferRepo := new(mockTransferRepository)
+	volunteerRepo := new(MockVolunteerRepository)
+	notifier := new(recordingNotifier)
+	return NewTransferUsecase(transferRepo, volunteerRepo, nil, notifier, new(inlineRunner)), transferRepo, volunteerRepo, notifier
+}
+
+func TestRequestTransfer_ByVolunteer(t *testing.T) {
+	usecase, transferRepo, volunteerRepo, notifier := newTransferFixture()
+	volunteerRepo.On("FindVolunteerByID", 1).Return(&domain.VolunteerDetails{ID: 1, UserID: 10, DepartmentID: 2}, nil)
+	transferRepo.On("HasPendingTransfer", 1).Return(false, nil)
+	transferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *domain.DepartmentTransfer) bool {
+		return transfer.FromDepartmentID == 2 && transfer.ToDepartmentID == 3 && transfer.RequestedBy == 10
+	})).Return(nil)
+	transferRepo.On("ListDepartmentManagerUserIDs", []int{2, 3}).Return([]int{20, 30, 10}, nil)
+
+	result, err := usecase.RequestTransfer(context.Background(), 1, dto.TransferCreateDTO{ToDepartmentID: 3}, dto.Actor{UserID: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TransferPending, result.Status)
+	assert.Equal(t, [][]int{{10, 20, 30}}, notifier.userIDs)
+	transferRepo.AssertExpectations(t)
+}
+
+func TestRequestTransfer_Forbidden(t *testing.T) {
+	usecase, transferRepo, volunteerRepo, _ := newTransferFixture()
+	volunteerRepo.On("FindVolunteerByID", 1).Return(&domain.VolunteerDetails{ID: 1, UserID: 10, DepartmentID: 2}, nil)
+
+	_, err := usecase.RequestTransfer(context.Background(), 1, dto.TransferCreateDTO{ToDepartmentID: 3}, dto.Actor{UserID: 99, ManagedDepartments: []int{7}})
+
+	assert.ErrorIs(t, err, ErrTransferForbidden)
+	transferRepo.AssertNotCalled(t, "CreateTransfer", mock.Anything)
+}
+
+func TestRequestTransfer_SameDepartment(t *testing.T) {
+	usecase, _, volunteerRepo, _ := newTransferFixture()
+	volunteerRepo.On("FindVolunteerByID", 1).Return(&domain.VolunteerDetails{ID: 1, UserID: 10, DepartmentID: 2}, nil)
+
+	_, err := usecase.RequestTransfer(context.Background(), 1, dto.TransferCreateDTO{ToDepartmentID: 2}, dto.Actor{UserID: 10})
+
+	assert.ErrorIs(t, err, ErrTransferSameDepartment)
+}
+
+func TestApproveTransfer_NeedsBothDepartments(t *testing.T) {
+	usecase, transferRepo, volunteerRepo, notifier := newTransferFixture()
+	transfer := &domain.DepartmentTransfer{ID: 5, VolunteerID: 1, FromDepartmentID: 2, ToDepartmentID: 3, RequestedBy: 10}
+	transferRepo.On("LockTransfer", 5).Return(transfer, nil)
+	transferRepo.On("UpdatePendingTransfer", transfer).Return(nil).Once()
+	transferRepo.On("CompleteTransfer", transfer, mock.Anything).Return(nil).Once()
+	transferRepo.On("ListDepartmentManagerUserIDs", []int{2, 3}).Return([]int{20, 30}, nil)
+	volunteerRepo.On("FindVolunteerByID", 1).Return(&domain.VolunteerDetails{ID: 1, UserID: 10, DepartmentID: 2}, nil)
+
+	result, err := usecase.ApproveTransfer(context.Background(), 5, dto.Actor{UserID: 20, ManagedDepartments: []int{2}})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TransferPending, result.Status)
+	transferRepo.AssertNotCalled(t, "CompleteTransfer", mock.Anything, mock.Anything)
+
+	_, err = usecase.ApproveTransfer(context.Background(), 5, dto.Actor{UserID: 21, ManagedDepartments: []int{2}})
+	assert.ErrorIs(t, err, ErrTransferNothingToSign)
+
+	result, err = usecase.ApproveTransfer(context.Background(), 5, dto.Actor{UserID: 30, ManagedDepartments: []int{3}})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TransferApproved, result.Status)
+	assert.Equal(t, 20, *result.SourceApprovedBy)
+	assert.Equal(t, 30, *result.TargetApprovedBy)
+	assert.Len(t, notifier.messages, 2)
+	transferRepo.AssertExpectations(t)
+}
+
+func TestApproveTransfer_AdminSignsOneSide(t *testing.T) {
+	usecase, transferRepo, volunteerRepo, _ := newTransferFixture()
+	transfer := &domain.DepartmentTransfer{ID: 5, VolunteerID: 1, FromDepartmentID: 2, ToDepartmentID: 3, RequestedBy: 10}
+	transferRepo.On("LockTransfer", 5).Return(transfer, nil)
+	transferRepo.On("UpdatePendingTransfer", transfer).Return(nil).Once()
+	transferRepo.On("CompleteTransfer", transfer, mock.Anything).Return(nil).Once()
+	transferRepo.On("ListDepartmentManagerUserIDs", []int{2, 3}).Return([]int{}, nil)
+	volunteerRepo.On("FindVolunteerByID", 1).Return(&domain.VolunteerDetails{ID: 1, UserID: 10, DepartmentID: 2}, nil)
+	admin := dto.Actor{UserID: 1, Admin: true}
+
+	result, err := usecase.ApproveTransfer(context.Background(), 5, admin)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TransferPending, result.Status)
+	assert.Equal(t, 1, *result.SourceApprovedBy)
+	assert.Nil(t, result.TargetApprovedBy)
+
+	_, err = usecase.ApproveTransfer(context.Background(), 5, admin)
+	assert.ErrorIs(t, err, ErrTransferSameApprover)
+	transferRepo.AssertNotCalled(t, "CompleteTransfer", mock.Anything, mock.Anything)
+
+	result, err = usecase.ApproveTransfer(context.Background(), 5, dto.Actor{UserID: 2, Admin: true})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TransferApproved, result.Status)
+	assert.Equal(t, 2, *result.TargetApprovedBy)
+	transferRepo.AssertExpectations(t)
+}
+
+func TestApproveTransfer_AncestorManagerCannotSignBothSides(t *testing.T) {
+	usecase, transferRepo, _, _ := newTransferFixture()
+	approvedBy := 20
+	transfer := &domain.DepartmentTransfer{ID: 5, VolunteerID: 1, FromDepartmentID: 2, ToDepartmentID: 3, RequestedBy: 10, SourceApprovedBy: &approvedBy}
+	transferRepo.On("LockTransfer", 5).Return(transfer, nil)
+
+	_, err := usecase.ApproveTransfer(context.Background(), 5, dto.Actor{UserID: 20, ManagedDepartments: []int{1, 2, 3}})
+
+	assert.ErrorIs(t, err, ErrTransferSameApprover)
+	assert.Nil(t, transfer.TargetApprovedBy)
+	transferRepo.AssertNotCalled(t, "UpdatePendingTransfer", mock.Anything)
+}
+
+func TestCancelTransfer_OnlyRequesterOrVolunteer(t *testing.T) {
+	usecase, transferRepo, volunteerRepo, _ := newTransferFixture()
+	transfer := &domain.DepartmentTransfer{ID: 5, VolunteerID: 1, FromDepartmentID: 2, ToDepartmentID: 3, RequestedBy: 20}
+	transferRepo.On("FindTransferByID", 5).Return(transfer, nil)
+	volunteerRepo.On("FindVolunteerByID", 1).Return(&domain.VolunteerDetails{ID: 1, UserID: 10, DepartmentID: 2}, nil)
+
+	err := usecase.CancelTransfer(context.Background(), 5, dto.Actor{UserID: 30, ManagedDepartments: []int{3}})
+
+	assert.ErrorIs(t, err, ErrTransferForbidden)
+	transferRepo.AssertNotCalled(t, "UpdatePendingTransfer", mock.Anything)
+}
+
+func TestFindTransferByID_OnlyPartiesAndManagers(t *testing.T) {
+	usecase, transferRepo, volunteerRepo, _ := newTransferFixture()
+	transfer := &domain.DepartmentTransfer{ID: 5, VolunteerID: 1, FromDepartmentID: 2, ToDepartmentID: 3, RequestedBy: 20}
+	transferRepo.On("FindTransferByID", 5).Return(transfer, nil)
+	volunteerRepo.On("FindVolunteerByID", 1).Return(&domain.VolunteerDetails{ID: 1, UserID: 10, DepartmentID: 2}, nil)
+
+	_, err := usecase.FindTransferByID(context.Background(), 5, dto.Actor{UserID: 10})
+	assert.NoError(t, err)
+	_, err = usecase.FindTransferByID(context.Background(), 5, dto.Actor{UserID: 30, ManagedDepartments: []int{3}})
+	assert.NoError(t, err)
+	_, err = usecase.FindTransferByID(context.Background(), 5, dto.Actor{UserID: 40, ManagedDepartments: []int{4}})
+	assert.ErrorIs(t, err, ErrTransferForbidden)
+}
+
+func TestListMemberships_OnlyVolunteerAndManagers(t *testing.T) {
+	usecase, transferRepo, volunteerRepo, _ := newTransferFixture()
+	volunteerRepo.On("FindVolunteerByID", 1).Return(&domain.VolunteerDetails{ID: 1, UserID: 10, DepartmentID: 3}, nil)
+	transferRepo.On("ListMemberships", 1).Return([]*domain.DepartmentMembership{
+		{VolunteerID: 1, DepartmentID: 2, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{VolunteerID: 1, DepartmentID: 3, StartDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+
+	memberships, err := usecase.ListMemberships(context.Background(), 1, dto.Actor{UserID: 10})
+	assert.NoError(t, err)
+	assert.Len(t, memberships, 2)
+	_, err = usecase.ListMemberships(context.Background(), 1, dto.Actor{UserID: 20, ManagedDepartments: []int{2}})
+	assert.NoError(t, err)
+	_, err = usecase.ListMemberships(context.Background(), 1, dto.Actor{UserID: 40, ManagedDepartments: []int{4}})
+	assert.ErrorIs(t, err, ErrTransferForbidden)
+}
@@ -1,17 +1,22 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
 )
 
+var ErrDepartmentChangeNeedsTransfer = errors.New("department changes must go through a transfer request")
+
 type VolunteerUsecaseInterface interface {
-	CreateVolunteer(input dto.VolunteerCreateDTO) error
-	UpdateVolunteer(id int, input dto.VolunteerUpdateDTO) error
-	DeleteVolunteer(id int) error
-	FindVolunteerByID(id int) (*dto.VolunteerResponseDTO, error)
-	GetAllVolunteers() ([]dto.VolunteerResponseDTO, error)
+	CreateVolunteer(ctx context.Context, input dto.VolunteerCreateDTO) error
+	UpdateVolunteer(ctx context.Context, id int, input dto.VolunteerUpdateDTO) error
+	DeleteVolunteer(ctx context.Context, id int) error
+	FindVolunteerByID(ctx context.Context, id int) (*dto.VolunteerResponseDTO, error)
+	GetAllVolunteers(ctx context.Context) ([]dto.VolunteerResponseDTO, error)
 }
 
 type VolunteerUsecase struct {
@@ -22,32 +27,35 @@ func NewVolunteerUsecase(volunteerRepo storage.VolunteerRepositoryInterface) *Vo
 	return &VolunteerUsecase{VolunteerRepo: volunteerRepo}
 }
 
-func (u *VolunteerUsecase) CreateVolunteer(input dto.VolunteerCreateDTO) error {
+func (u *VolunteerUsecase) CreateVolunteer(ctx context.Context, input dto.VolunteerCreateDTO) error {
 	volunteer := &domain.VolunteerDetails{
 		UserID:       input.UserID,
 		DepartmentID: input.DepartmentID,
 		Status:       input.Status,
 	}
-	return u.VolunteerRepo.CreateVolunteer(volunteer)
+	return u.VolunteerRepo.CreateVolunteer(ctx, volunteer)
 }
 
-func (u *VolunteerUsecase) UpdateVolunteer(id int, input dto.VolunteerUpdateDTO) error {
-	volunteer, err := u.VolunteerRepo.FindVolunteerByID(id)
+// UpdateVolunteer updates the volunteer status, moving to another department requires a transfer
+func (u *VolunteerUsecase) UpdateVolunteer(ctx context.Context, id int, input dto.VolunteerUpdateDTO) error {
+	volunteer, err := u.VolunteerRepo.FindVolunteerByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	volunteer.DepartmentID = input.DepartmentID
+	if input.DepartmentID != 0 && input.DepartmentID != volunteer.DepartmentID {
+		return ErrDepartmentChangeNeedsTransfer
+	}
 	volunteer.Status = input.Status
 
-	return u.VolunteerRepo.UpdateVolunteer(volunteer)
+	return u.VolunteerRepo.UpdateVolunteer(ctx, volunteer)
 }
 
-func (u *VolunteerUsecase) DeleteVolunteer(id int) error {
-	return u.VolunteerRepo.DeleteVolunteer(id)
+func (u *VolunteerUsecase) DeleteVolunteer(ctx context.Context, id int) error {
+	return u.VolunteerRepo.DeleteVolunteer(ctx, id)
 }
 
-func (u *VolunteerUsecase) FindVolunteerByID(id int) (*dto.VolunteerResponseDTO, error) {
-	volunteer, err := u.VolunteerRepo.FindVolunteerByID(id)
+func (u *VolunteerUsecase) FindVolunteerByID(ctx context.Context, id int) (*dto.VolunteerResponseDTO, error) {
+	volunteer, err := u.VolunteerRepo.FindVolunteerByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -60,8 +68,8 @@ func (u *VolunteerUsecase) FindVolunteerByID(id int) (*dto.VolunteerResponseDTO,
 	return response, nil
 }
 
-func (u *VolunteerUsecase) GetAllVolunteers() ([]dto.VolunteerResponseDTO, error) {
-	volunteers, err := u.VolunteerRepo.GetAllVolunteers()
+func (u *VolunteerUsecase) GetAllVolunteers(ctx context.Context) ([]dto.VolunteerResponseDTO, error) {
+	volunteers, err := u.VolunteerRepo.GetAllVolunteers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -15,24 +16,35 @@ type MockVolunteerRepository struct {
 	mock.Mock
 }
 
-func (m *MockVolunteerRepository) CreateVolunteer(volunteer *domain.Volunteer) error {
+func (m *MockVolunteerRepository) CreateVolunteer(ctx context.Context, volunteer *domain.VolunteerDetails) error {
 	args := m.Called(volunteer)
 	return args.Error(0)
 }
 
-func (m *MockVolunteerRepository) UpdateVolunteer(volunteer *domain.Volunteer) error {
+func (m *MockVolunteerRepository) UpdateVolunteer(ctx context.Context, volunteer *domain.VolunteerDetails) error {
 	args := m.Called(volunteer)
 	return args.Error(0)
 }
 
-func (m *MockVolunteerRepository) DeleteVolunteer(id int) error {
+func (m *MockVolunteerRepository) DeleteVolunteer(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockVolunteerRepository) FindVolunteerByID(id int) (*domain.Volunteer, error) {
+func (m *MockVolunteerRepository) FindVolunteerByID(ctx context.Context, id int) (*domain.VolunteerDetails, error) {
 	args := m.Called(id)
-	return args.Get(0).(*domain.Volunteer), args.Error(1)
+	volunteer, _ := args.Get(0).(*domain.VolunteerDetails)
+	return volunteer, args.Error(1)
+}
+
+func (m *MockVolunteerRepository) GetAllVolunteers(ctx context.Context) ([]*domain.VolunteerDetails, error) {
+	args := m.Called()
+	return args.Get(0).([]*domain.VolunteerDetails), args.Error(1)
+}
+
+func (m *MockVolunteerRepository) GetActiveVolunteersByDepartment(ctx context.Context, departmentID int) ([]*domain.VolunteerDetails, error) {
+	args := m.Called(departmentID)
+	return args.Get(0).([]*domain.VolunteerDetails), args.Error(1)
 }
 
 func TestCreateVolunteer(t *testing.T) {
@@ -47,7 +59,7 @@ func TestCreateVolunteer(t *testing.T) {
 
 	mockRepo.On("CreateVolunteer", mock.Anything).Return(nil)
 
-	err := usecase.CreateVolunteer(input)
+	err := usecase.CreateVolunteer(context.Background(), input)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
@@ -58,10 +70,10 @@ func TestUpdateVolunteer(t *testing.T) {
 	usecase := NewVolunteerUsecase(mockRepo)
 
 	input := dto.VolunteerUpdateDTO{
-		DepartmentID: 4,
+		DepartmentID: 2,
 		Status:       1,
 	}
-	volunteer := &domain.Volunteer{
+	volunteer := &domain.VolunteerDetails{
 		ID:           1,
 		UserID:       1,
 		DepartmentID: 2,
@@ -71,19 +83,39 @@ func TestUpdateVolunteer(t *testing.T) {
 	mockRepo.On("FindVolunteerByID", 1).Return(volunteer, nil)
 	mockRepo.On("UpdateVolunteer", volunteer).Return(nil)
 
-	err := usecase.UpdateVolunteer(1, input)
+	err := usecase.UpdateVolunteer(context.Background(), 1, input)
 
 	assert.NoError(t, err)
+	assert.Equal(t, 1, volunteer.Status)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUpdateVolunteer_DepartmentChange(t *testing.T) {
+	mockRepo := new(MockVolunteerRepository)
+	usecase := NewVolunteerUsecase(mockRepo)
+
+	volunteer := &domain.VolunteerDetails{
+		ID:           1,
+		UserID:       1,
+		DepartmentID: 2,
+		Status:       0,
+	}
+
+	mockRepo.On("FindVolunteerByID", 1).Return(volunteer, nil)
+
+	err := usecase.UpdateVolunteer(context.Background(), 1, dto.VolunteerUpdateDTO{DepartmentID: 4, Status: 1})
+
+	assert.ErrorIs(t, err, ErrDepartmentChangeNeedsTransfer)
+	mockRepo.AssertNotCalled(t, "UpdateVolunteer", mock.Anything)
+}
+
 func TestDeleteVolunteer(t *testing.T) {
 	mockRepo := new(MockVolunteerRepository)
 	usecase := NewVolunteerUsecase(mockRepo)
 
 	mockRepo.On("DeleteVolunteer", 1).Return(nil)
 
-	err := usecase.DeleteVolunteer(1)
+	err := usecase.DeleteVolunteer(context.Background(), 1)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
@@ -93,7 +125,7 @@ func TestFindVolunteerByID(t *testing.T) {
 	mockRepo := new(MockVolunteerRepository)
 	usecase := NewVolunteerUsecase(mockRepo)
 
-	volunteer := &domain.Volunteer{
+	volunteer := &domain.VolunteerDetails{
 		ID:           1,
 		UserID:       1,
 		DepartmentID: 2,
@@ -102,7 +134,7 @@ func TestFindVolunteerByID(t *testing.T) {
 
 	mockRepo.On("FindVolunteerByID", 1).Return(volunteer, nil)
 
-	result, err := usecase.FindVolunteerByID(1)
+	result, err := usecase.FindVolunteerByID(context.Background(), 1)
 
 	assert.NoError(t, err)
 	assert.Equal(t, &dto.VolunteerResponseDTO{
@@ -120,7 +152,7 @@ func TestFindVolunteerByID_NotFound(t *testing.T) {
 
 	mockRepo.On("FindVolunteerByID", 1).Return(nil, errors.New("record not found"))
 
-	result, err := usecase.FindVolunteerByID(1)
+	result, err := usecase.FindVolunteerByID(context.Background(), 1)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Events a webhook can subscribe to
+const (
+	EventRequestApproved      = "request.approved"
+	EventRequestRejected      = "request.rejected"
+	EventVolunteerCreated     = "volunteer.created"
+	EventVolunteerActivated   = "volunteer.activated"
+	EventVolunteerDeactivated = "volunteer.deactivated"
+	EventVolunteerDeleted     = "volunteer.deleted"
+)
+
+// Events lists every event in the order they are documented
+var Events = []string{
+	EventRequestApproved,
+	EventRequestRejected,
+	EventVolunteerCreated,
+	EventVolunteerActivated,
+	EventVolunteerDeactivated,
+	EventVolunteerDeleted,
+}
+
+// Delivery statuses, a pending delivery is retried until it succeeds or runs out of attempts
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookSubscription posts the events listed in Events to URL, signed with Secret
+type WebhookSubscription struct {
+	ID          int    `gorm:"primaryKey"`
+	URL         string `gorm:"not null"`
+	Secret      string `gorm:"not null"`
+	Events      string `gorm:"not null"`
+	Description string
+	Active      bool `gorm:"not null"`
+	CreatedBy   *int
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// EventList splits the comma separated Events column
+func (s *WebhookSubscription) EventList() []string {
+	if s.Events == "" {
+		return []string{}
+	}
+	return strings.Split(s.Events, ",")
+}
+
+// Subscribes reports whether the subscription wants event
+func (s *WebhookSubscription) Subscribes(event string) bool {
+	for _, e := range s.EventList() {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event sent to one subscription. A redelivery is a new row with the same EventID
+type WebhookDelivery struct {
+	ID             int    `gorm:"primaryKey"`
+	SubscriptionID int    `gorm:"index"`
+	EventID        string `gorm:"not null"`
+	Event          string `gorm:"not null"`
+	Payload        string `gorm:"not null"`
+	Status         string `gorm:"not null"`
+	Attempts       int    `gorm:"not null"`
+	NextAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string
+	LastResponse   *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,96 @@
+package dto
+
+import "time"
+
+// WebhookCreateDTO registers a webhook, a secret is generated when none is given
+type WebhookCreateDTO struct {
+	URL         string   `json:"url" binding:"required,url,max=500"`
+	Secret      string   `json:"secret" binding:"omitempty,min=16,max=255"`
+	Events      []string `json:"events" binding:"required,min=1,dive,required"`
+	Description string   `json:"description" binding:"max=255"`
+	Active      *bool    `json:"active"`
+}
+
+// WebhookUpdateDTO changes the given fields only, setting Secret rotates it
+type WebhookUpdateDTO struct {
+	URL         *string  `json:"url" binding:"omitempty,url,max=500"`
+	Secret      *string  `json:"secret" binding:"omitempty,min=16,max=255"`
+	Events      []string `json:"events" binding:"omitempty,min=1,dive,required"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Active      *bool    `json:"active"`
+}
+
+// WebhookResponseDTO is a subscription as listed, the secret is never returned after creation
+type WebhookResponseDTO struct {
+	ID          int       `json:"id"`
+	URL         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Description string    `json:"description"`
+	Active      bool      `json:"active"`
+	SecretHint  string    `json:"secret_hint"`
+	CreatedBy   *int      `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookSecretDTO is returned when a subscription is created, it is the only time the secret is shown
+type WebhookSecretDTO struct {
+	WebhookResponseDTO
+	Secret string `json:"secret"`
+}
+
+// DeliveryResponseDTO is one delivery with the outcome of its last attempt
+type DeliveryResponseDTO struct {
+	ID             int        `json:"id"`
+	SubscriptionID int        `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	Event          string     `json:"event"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	LastStatusCode *int       `json:"last_status_code"`
+	LastError      *string    `json:"last_error"`
+	LastResponse   *string    `json:"last_response"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// DeliveryFilter pages through the deliveries of a subscription newest first
+type DeliveryFilter struct {
+	Status string
+	Before int
+	Limit  int
+}
+
+// DeliveryListDTO is one page of deliveries, NextBefore is empty on the last page
+type DeliveryListDTO struct {
+	Deliveries []DeliveryResponseDTO `json:"deliveries"`
+	NextBefore *int                  `json:"next_before"`
+}
+
+// EventPayload is the JSON body posted to the webhook URL
+type EventPayload struct {
+	ID        string    `json:"id"`
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// RequestEventData is the data of request.* events
+type RequestEventData struct {
+	RequestID  int        `json:"request_id"`
+	UserID     int        `json:"user_id"`
+	Type       string     `json:"type"`
+	Status     int        `json:"status"`
+	VerifierID *int       `json:"verifier_id"`
+	DecidedAt  *time.Time `json:"decided_at"`
+}
+
+// VolunteerEventData is the data of volunteer.* events
+type VolunteerEventData struct {
+	VolunteerID  int `json:"volunteer_id"`
+	UserID       int `json:"user_id"`
+	DepartmentID int `json:"department_id"`
+	Status       int `json:"status"`
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/dto"
+	"gorm.io/gorm"
+)
+
+type WebhookRepositoryInterface interface {
+	CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id int) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	ListActiveSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id int) error
+	CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id int) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID int, filter dto.DeliveryFilter) ([]domain.WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	SaveAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error
+	PurgeDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WebhookRepository stores webhook subscriptions and their delivery log.
+type WebhookRepository struct {
+	DB *gorm.DB
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository.
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{DB: db}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	return uow.Conn(ctx, r.DB).Create(subscription).Error
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id int) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	if err := uow.Conn(ctx, r.DB).First(&subscription, id).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subscriptions []domain.WebhookSubscription
+	err := uow.Conn(ctx, r.DB).Order("id").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// ListActiveSubscriptions returns the enabled subscriptions, the caller filters them by event.
+func (r *WebhookRepository) ListActiveSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subscriptions []domain.WebhookSubscription
+	err := uow.Conn(ctx, r.DB).Where("active = ?", true).Order("id").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	return uow.Conn(ctx, r.DB).Save(subscription).Error
+}
+
+// DeleteSubscription removes the subscription, its deliveries go with it.
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id int) error {
+	return uow.Conn(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.WebhookSubscription{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	return uow.Conn(ctx, r.DB).Create(&deliveries).Error
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id int) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	if err := uow.Conn(ctx, r.DB).First(&delivery, id).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListDeliveries returns the deliveries of the subscription newest first.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionID int, filter dto.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	db := uow.Conn(ctx, r.DB).Where("subscription_id = ?", subscriptionID)
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+	if filter.Before > 0 {
+		db = db.Where("id < ?", filter.Before)
+	}
+	err := db.Order("id DESC").Limit(filter.Limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimDueDeliveries picks pending deliveries whose next attempt is due and pushes their next
+// attempt lease into the future, so another instance polling at the same time skips them.
+// A delivery whose attempt crashed midway becomes due again once the lease runs out.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	var due []domain.WebhookDelivery
+	err := uow.Conn(ctx, r.DB).Where("status = ? AND next_attempt_at <= ?", domain.DeliveryPending, now).
+		Order("next_attempt_at, id").Limit(limit).Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+	claimed := make([]domain.WebhookDelivery, 0, len(due))
+	leaseUntil := now.Add(lease)
+	for _, delivery := range due {
+		result := uow.Conn(ctx, r.DB).Model(&domain.WebhookDelivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at <= ?", delivery.ID, domain.DeliveryPending, now).
+			Update("next_attempt_at", leaseUntil)
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 1 {
+			delivery.NextAttemptAt = &leaseUntil
+			claimed = append(claimed, delivery)
+		}
+	}
+	return claimed, nil
+}
+
+// SaveAttempt stores the outcome of an attempt.
+func (r *WebhookRepository) SaveAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return uow.Conn(ctx, r.DB).Model(delivery).Select(
+		"status", "attempts", "next_attempt_at", "last_status_code", "last_error", "last_response", "delivered_at",
+	).Updates(delivery).Error
+}
+
+// PurgeDeliveries deletes the succeeded and failed deliveries created before before.
+func (r *WebhookRepository) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	result := uow.Conn(ctx, r.DB).Where("created_at < ? AND status <> ?", before, domain.DeliveryPending).Delete(&domain.WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,274 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// WebhookHandler handles the HTTP requests for webhook subscriptions, admins only.
+type WebhookHandler struct {
+	usecase usecase.WebhookUsecaseInterface
+}
+
+// NewWebhookHandler creates a new instance of WebhookHandler.
+func NewWebhookHandler(usecase usecase.WebhookUsecaseInterface) *WebhookHandler {
+	return &WebhookHandler{usecase: usecase}
+}
+
+// ListEvents godoc
+// @Summary List webhook events
+// @Description Events a webhook can subscribe to
+// @Produce json
+// @Tags webhook
+// @Security bearerToken
+// @Success 200 {array} string
+// @Router /api/v1/webhooks/events [get]
+func (h *WebhookHandler) ListEvents(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, domain.Events)
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook
+// @Description Subscribe a URL to events. The secret signing the deliveries is generated when omitted and only returned by this call
+// @Accept json
+// @Produce json
+// @Tags webhook
+// @Param webhook body dto.WebhookCreateDTO true "Webhook data"
+// @Security bearerToken
+// @Success 201 {object} dto.WebhookSecretDTO
+// @Router /api/v1/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	var input dto.WebhookCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	webhook, err := h.usecase.CreateSubscription(c.Request.Context(), input, c.GetInt("userId"))
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// ListWebhooks godoc
+// @Summary List webhooks
+// @Produce json
+// @Tags webhook
+// @Security bearerToken
+// @Success 200 {array} dto.WebhookResponseDTO
+// @Router /api/v1/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	webhooks, err := h.usecase.ListSubscriptions(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook
+// @Produce json
+// @Tags webhook
+// @Param id path int true "Webhook ID"
+// @Security bearerToken
+// @Success 200 {object} dto.WebhookResponseDTO
+// @Router /api/v1/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+	webhook, err := h.usecase.GetSubscription(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook
+// @Description Change the URL, events, description or active flag, setting secret rotates it
+// @Accept json
+// @Produce json
+// @Tags webhook
+// @Param id path int true "Webhook ID"
+// @Param webhook body dto.WebhookUpdateDTO true "Fields to change"
+// @Security bearerToken
+// @Success 200 {object} dto.WebhookResponseDTO
+// @Router /api/v1/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+	var input dto.WebhookUpdateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	webhook, err := h.usecase.UpdateSubscription(c.Request.Context(), id, input)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Delete a webhook and its delivery log
+// @Produce json
+// @Tags webhook
+// @Param id path int true "Webhook ID"
+// @Security bearerToken
+// @Success 200 {string} message "Webhook deleted successfully"
+// @Router /api/v1/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+	if err := h.usecase.DeleteSubscription(c.Request.Context(), id); err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// ListDeliveries godoc
+// @Summary List webhook deliveries
+// @Description Delivery log of a webhook newest first, with the outcome of the last attempt
+// @Produce json
+// @Tags webhook
+// @Param id path int true "Webhook ID"
+// @Param status query string false "pending, succeeded or failed"
+// @Param before query int false "Continue after this delivery id (next_before of the previous page)"
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Security bearerToken
+// @Success 200 {object} dto.DeliveryListDTO
+// @Router /api/v1/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+	filter := dto.DeliveryFilter{Status: c.Query("status")}
+	switch filter.Status {
+	case "", domain.DeliveryPending, domain.DeliverySucceeded, domain.DeliveryFailed:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+	if v := c.Query("before"); v != "" {
+		if filter.Before, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid before"})
+			return
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		if filter.Limit, err = strconv.Atoi(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+	list, err := h.usecase.ListDeliveries(c.Request.Context(), id, filter)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook delivery
+// @Description Send the payload of a delivery again, as a new delivery with the same event id
+// @Produce json
+// @Tags webhook
+// @Param id path int true "Webhook ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Security bearerToken
+// @Success 202 {object} dto.DeliveryResponseDTO
+// @Router /api/v1/webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	if err := checkAdminRole(c); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+	deliveryID, err := strconv.Atoi(c.Param("deliveryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+		return
+	}
+	delivery, err := h.usecase.Redeliver(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+func checkAdminRole(c *gin.Context) error {
+	roleId, exists := c.Get("roleId")
+	if !exists || roleId.(int) != 1 {
+		return errors.New("forbidden: only admins can perform this action")
+	}
+	return nil
+}
+
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrUnknownEvent), errors.Is(err, usecase.ErrInvalidURL):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/tracing"
+)
+
+// ErrForbiddenAddress is returned when a webhook url resolves to an address of the internal network
+var ErrForbiddenAddress = errors.New("webhook url resolves to a private address")
+
+// reservedPrefixes are the ranges outside IsPrivate, IsLoopback and IsLinkLocalUnicast that
+// still reach internal or special purpose hosts
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+// newDeliveryClient returns the client deliveries are sent with. Receivers are chosen by whoever
+// creates a webhook and their answer ends up in the delivery log, so the client only connects to
+// public addresses, checked on the resolved address when dialing so that no DNS answer can point
+// it inside, and does not follow redirects.
+func newDeliveryClient() *http.Client {
+	dialer := &net.Dialer{Timeout: deliveryTimeout, Control: refuseInternal}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be dialed instead of the receiver and let it through
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   deliveryTimeout,
+		Transport: tracing.Transport(transport),
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// refuseInternal is the dialer Control, address is the resolved ip and port about to be connected
+func refuseInternal(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !publicAddress(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+	}
+	return nil
+}
+
+// publicAddress reports whether ip is a unicast address of the internet
+func publicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
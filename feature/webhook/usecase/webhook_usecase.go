@@ -0,0 +1,582 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/event"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/tracing"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/uow"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/storage"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxDeliveryAttempts is how many times a delivery is tried before it is marked failed
+	MaxDeliveryAttempts = 10
+	// retryBaseDelay doubles after every failed attempt up to retryMaxDelay
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = 6 * time.Hour
+	// deliveryTimeout bounds one attempt, deliveryLease must stay above it
+	deliveryTimeout = 10 * time.Second
+	deliveryLease   = time.Minute
+	pollInterval    = 10 * time.Second
+	dispatchBatch   = 50
+	// DeliveryRetention is how long the delivery log is kept by the webhook-deliveries-purge job
+	DeliveryRetention = 30 * 24 * time.Hour
+	// responseExcerptLength is how much of the receiver's answer is kept in the delivery log
+	responseExcerptLength = 1000
+
+	defaultDeliveryLimit = 20
+	maxDeliveryLimit     = 100
+)
+
+var (
+	ErrUnknownEvent = errors.New("unknown webhook event")
+	ErrInvalidURL   = errors.New("webhook url must be an absolute http or https url")
+)
+
+type WebhookUsecaseInterface interface {
+	CreateSubscription(ctx context.Context, input dto.WebhookCreateDTO, userID int) (*dto.WebhookSecretDTO, error)
+	ListSubscriptions(ctx context.Context) ([]dto.WebhookResponseDTO, error)
+	GetSubscription(ctx context.Context, id int) (*dto.WebhookResponseDTO, error)
+	UpdateSubscription(ctx context.Context, id int, input dto.WebhookUpdateDTO) (*dto.WebhookResponseDTO, error)
+	DeleteSubscription(ctx context.Context, id int) error
+	ListDeliveries(ctx context.Context, subscriptionID int, filter dto.DeliveryFilter) (*dto.DeliveryListDTO, error)
+	Redeliver(ctx context.Context, subscriptionID int, deliveryID int) (*dto.DeliveryResponseDTO, error)
+	Publish(ctx context.Context, event string, data any) error
+}
+
+// WebhookUsecase manages webhook subscriptions and delivers events to them.
+// Publish only records deliveries, Run sends them and retries failed ones with exponential backoff.
+// It subscribes to the request and volunteer events of the event bus.
+type WebhookUsecase struct {
+	Repo   storage.WebhookRepositoryInterface
+	Client *http.Client
+	wake   chan struct{}
+}
+
+// NewWebhookUsecase creates a new instance of WebhookUsecase.
+func NewWebhookUsecase(repo storage.WebhookRepositoryInterface) *WebhookUsecase {
+	return &WebhookUsecase{
+		Repo:   repo,
+		Client: newDeliveryClient(),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+func (u *WebhookUsecase) CreateSubscription(ctx context.Context, input dto.WebhookCreateDTO, userID int) (*dto.WebhookSecretDTO, error) {
+	if err := validateURL(input.URL); err != nil {
+		return nil, err
+	}
+	events, err := normalizeEvents(input.Events)
+	if err != nil {
+		return nil, err
+	}
+	secret := input.Secret
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+	subscription := &domain.WebhookSubscription{
+		URL:         input.URL,
+		Secret:      secret,
+		Events:      strings.Join(events, ","),
+		Description: strings.TrimSpace(input.Description),
+		Active:      input.Active == nil || *input.Active,
+		CreatedBy:   &userID,
+	}
+	if err := u.Repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return &dto.WebhookSecretDTO{WebhookResponseDTO: subscriptionResponse(subscription), Secret: secret}, nil
+}
+
+func (u *WebhookUsecase) ListSubscriptions(ctx context.Context) ([]dto.WebhookResponseDTO, error) {
+	subscriptions, err := u.Repo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.WebhookResponseDTO, 0, len(subscriptions))
+	for i := range subscriptions {
+		response = append(response, subscriptionResponse(&subscriptions[i]))
+	}
+	return response, nil
+}
+
+func (u *WebhookUsecase) GetSubscription(ctx context.Context, id int) (*dto.WebhookResponseDTO, error) {
+	subscription, err := u.Repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	response := subscriptionResponse(subscription)
+	return &response, nil
+}
+
+func (u *WebhookUsecase) UpdateSubscription(ctx context.Context, id int, input dto.WebhookUpdateDTO) (*dto.WebhookResponseDTO, error) {
+	subscription, err := u.Repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if input.URL != nil {
+		if err := validateURL(*input.URL); err != nil {
+			return nil, err
+		}
+		subscription.URL = *input.URL
+	}
+	if input.Events != nil {
+		events, err := normalizeEvents(input.Events)
+		if err != nil {
+			return nil, err
+		}
+		subscription.Events = strings.Join(events, ",")
+	}
+	if input.Secret != nil {
+		subscription.Secret = *input.Secret
+	}
+	if input.Description != nil {
+		subscription.Description = strings.TrimSpace(*input.Description)
+	}
+	if input.Active != nil {
+		subscription.Active = *input.Active
+	}
+	if err := u.Repo.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	response := subscriptionResponse(subscription)
+	return &response, nil
+}
+
+func (u *WebhookUsecase) DeleteSubscription(ctx context.Context, id int) error {
+	return u.Repo.DeleteSubscription(ctx, id)
+}
+
+func (u *WebhookUsecase) ListDeliveries(ctx context.Context, subscriptionID int, filter dto.DeliveryFilter) (*dto.DeliveryListDTO, error) {
+	if _, err := u.Repo.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDeliveryLimit
+	}
+	if filter.Limit > maxDeliveryLimit {
+		filter.Limit = maxDeliveryLimit
+	}
+	deliveries, err := u.Repo.ListDeliveries(ctx, subscriptionID, filter)
+	if err != nil {
+		return nil, err
+	}
+	list := &dto.DeliveryListDTO{Deliveries: make([]dto.DeliveryResponseDTO, 0, len(deliveries))}
+	for i := range deliveries {
+		list.Deliveries = append(list.Deliveries, deliveryResponse(&deliveries[i]))
+	}
+	if len(deliveries) == filter.Limit {
+		last := deliveries[len(deliveries)-1].ID
+		list.NextBefore = &last
+	}
+	return list, nil
+}
+
+// Redeliver sends the payload of a past delivery again as a new delivery with the same event id,
+// so receivers that dedupe on the id handle it once.
+func (u *WebhookUsecase) Redeliver(ctx context.Context, subscriptionID int, deliveryID int) (*dto.DeliveryResponseDTO, error) {
+	original, err := u.Repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.SubscriptionID != subscriptionID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	now := time.Now()
+	deliveries := []domain.WebhookDelivery{{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		Event:          original.Event,
+		Payload:        original.Payload,
+		Status:         domain.DeliveryPending,
+		NextAttemptAt:  &now,
+	}}
+	if err := u.Repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return nil, err
+	}
+	u.Wake()
+	response := deliveryResponse(&deliveries[0])
+	return &response, nil
+}
+
+// Publish records a delivery of event for every active subscription that wants it. The deliveries
+// join the transaction carried by ctx, and Run is woken once it committed.
+func (u *WebhookUsecase) Publish(ctx context.Context, event string, data any) error {
+	subscriptions, err := u.Repo.ListActiveSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	var targets []int
+	for _, subscription := range subscriptions {
+		if subscription.Subscribes(event) {
+			targets = append(targets, subscription.ID)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	eventID, err := generateEventID()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	payload, err := json.Marshal(dto.EventPayload{ID: eventID, Event: event, CreatedAt: now, Data: data})
+	if err != nil {
+		return err
+	}
+	deliveries := make([]domain.WebhookDelivery, 0, len(targets))
+	for _, subscriptionID := range targets {
+		deliveries = append(deliveries, domain.WebhookDelivery{
+			SubscriptionID: subscriptionID,
+			EventID:        eventID,
+			Event:          event,
+			Payload:        string(payload),
+			Status:         domain.DeliveryPending,
+			NextAttemptAt:  &now,
+		})
+	}
+	if err := u.Repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+	uow.AfterCommit(ctx, u.Wake)
+	return nil
+}
+
+// Wake makes Run look for due deliveries now instead of at its next poll.
+func (u *WebhookUsecase) Wake() {
+	select {
+	case u.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers due deliveries until ctx is done. Every instance may run it,
+// deliveries are claimed so each attempt is made by one instance only.
+func (u *WebhookUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		u.DispatchDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-u.wake:
+		}
+	}
+}
+
+// DispatchDue makes one attempt for every delivery that is due, batch after batch.
+func (u *WebhookUsecase) DispatchDue(ctx context.Context) {
+	subscriptions := map[int]*domain.WebhookSubscription{}
+	for ctx.Err() == nil {
+		deliveries, err := u.Repo.ClaimDueDeliveries(ctx, time.Now(), deliveryLease, dispatchBatch)
+		if err != nil {
+			log.Printf("cannot claim webhook deliveries: %v", err)
+			return
+		}
+		for i := range deliveries {
+			u.attempt(ctx, &deliveries[i], subscriptions)
+		}
+		if len(deliveries) < dispatchBatch {
+			return
+		}
+	}
+}
+
+// attempt sends one delivery and schedules the next attempt when it fails
+func (u *WebhookUsecase) attempt(ctx context.Context, delivery *domain.WebhookDelivery, subscriptions map[int]*domain.WebhookSubscription) {
+	ctx, span := tracing.Tracer("webhook").Start(ctx, "webhook.deliver", trace.WithAttributes(
+		attribute.Int("webhook.subscription_id", delivery.SubscriptionID),
+		attribute.Int("webhook.delivery_id", delivery.ID),
+		attribute.String("webhook.event", delivery.Event),
+		attribute.Int("webhook.attempt", delivery.Attempts+1),
+	))
+	defer span.End()
+
+	subscription, ok := subscriptions[delivery.SubscriptionID]
+	if !ok {
+		var err error
+		if subscription, err = u.Repo.GetSubscription(ctx, delivery.SubscriptionID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("cannot load webhook subscription %d: %v", delivery.SubscriptionID, err)
+			return
+		}
+		subscriptions[delivery.SubscriptionID] = subscription
+	}
+
+	var statusCode int
+	var response string
+	var err error
+	switch {
+	case subscription == nil:
+		err = errors.New("subscription was deleted")
+	case !subscription.Active:
+		err = errors.New("subscription is disabled")
+	default:
+		statusCode, response, err = u.send(ctx, subscription, delivery)
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastStatusCode = nil
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+	delivery.LastResponse = excerpt(response)
+	delivery.LastError = nil
+	switch {
+	case err == nil:
+		delivery.Status = domain.DeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	case subscription == nil || !subscription.Active || delivery.Attempts >= MaxDeliveryAttempts:
+		delivery.Status = domain.DeliveryFailed
+		delivery.LastError = excerpt(err.Error())
+		delivery.NextAttemptAt = nil
+	default:
+		next := now.Add(retryDelay(delivery.Attempts))
+		delivery.LastError = excerpt(err.Error())
+		delivery.NextAttemptAt = &next
+	}
+	if err := u.Repo.SaveAttempt(ctx, delivery); err != nil {
+		log.Printf("cannot save webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// PurgeDeliveries deletes the finished deliveries older than DeliveryRetention, it is the
+// webhook-deliveries-purge job. Pending deliveries are kept whatever their age.
+func (u *WebhookUsecase) PurgeDeliveries(ctx context.Context) error {
+	purged, err := u.Repo.PurgeDeliveries(ctx, time.Now().Add(-DeliveryRetention))
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("purged %d webhook deliveries", purged)
+	}
+	return nil
+}
+
+// send posts the payload, any status outside 2xx is an error
+func (u *WebhookUsecase) send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, string, error) {
+	timestamp := time.Now().Unix()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "onboarding-and-volunteer-service-webhooks")
+	request.Header.Set("X-Webhook-Event", delivery.Event)
+	request.Header.Set("X-Webhook-Id", delivery.EventID)
+	request.Header.Set("X-Webhook-Delivery", strconv.Itoa(delivery.ID))
+	request.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	request.Header.Set("X-Webhook-Signature", Sign(subscription.Secret, timestamp, []byte(delivery.Payload)))
+
+	response, err := u.Client.Do(request)
+	if err != nil {
+		return 0, "", err
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(response.Body, responseExcerptLength))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, string(body), fmt.Errorf("receiver answered %s", response.Status)
+	}
+	return response.StatusCode, string(body), nil
+}
+
+// Sign returns the X-Webhook-Signature header of a payload: the hex HMAC-SHA256 of
+// "<timestamp>.<payload>" keyed with the subscription secret, prefixed with "sha256=".
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// OnRequestApproved publishes request.approved. The On subscribers run in the transaction of the
+// event, so its deliveries are stored if and only if the change behind it committed.
+func (u *WebhookUsecase) OnRequestApproved(tx *event.Tx, e event.RequestApproved) error {
+	return u.Publish(tx.Context(), domain.EventRequestApproved, dto.RequestEventData{
+		RequestID:  e.RequestID,
+		UserID:     e.UserID,
+		Type:       e.Type,
+		Status:     1,
+		VerifierID: &e.VerifierID,
+		DecidedAt:  &e.DecidedAt,
+	})
+}
+
+// OnRequestRejected publishes request.rejected.
+func (u *WebhookUsecase) OnRequestRejected(tx *event.Tx, e event.RequestRejected) error {
+	return u.Publish(tx.Context(), domain.EventRequestRejected, dto.RequestEventData{
+		RequestID:  e.RequestID,
+		UserID:     e.UserID,
+		Type:       e.Type,
+		Status:     2,
+		VerifierID: &e.VerifierID,
+		DecidedAt:  &e.DecidedAt,
+	})
+}
+
+// OnVolunteerCreated publishes volunteer.created.
+func (u *WebhookUsecase) OnVolunteerCreated(tx *event.Tx, e event.VolunteerCreated) error {
+	return u.Publish(tx.Context(), domain.EventVolunteerCreated, dto.VolunteerEventData{
+		VolunteerID:  e.VolunteerID,
+		UserID:       e.UserID,
+		DepartmentID: e.DepartmentID,
+		Status:       e.Status,
+	})
+}
+
+// OnVolunteerStatusChanged publishes volunteer.activated or volunteer.deactivated.
+func (u *WebhookUsecase) OnVolunteerStatusChanged(tx *event.Tx, e event.VolunteerStatusChanged) error {
+	name := domain.EventVolunteerDeactivated
+	if e.Status == 1 {
+		name = domain.EventVolunteerActivated
+	}
+	return u.Publish(tx.Context(), name, dto.VolunteerEventData{
+		VolunteerID:  e.VolunteerID,
+		UserID:       e.UserID,
+		DepartmentID: e.DepartmentID,
+		Status:       e.Status,
+	})
+}
+
+// OnVolunteerDeleted publishes volunteer.deleted.
+func (u *WebhookUsecase) OnVolunteerDeleted(tx *event.Tx, e event.VolunteerDeleted) error {
+	return u.Publish(tx.Context(), domain.EventVolunteerDeleted, dto.VolunteerEventData{
+		VolunteerID:  e.VolunteerID,
+		UserID:       e.UserID,
+		DepartmentID: e.DepartmentID,
+		Status:       e.Status,
+	})
+}
+
+// retryDelay is the wait after the given number of failed attempts
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+func validateURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidURL
+	}
+	return nil
+}
+
+// normalizeEvents checks every event is known and drops duplicates
+func normalizeEvents(events []string) ([]string, error) {
+	known := make(map[string]bool, len(domain.Events))
+	for _, event := range domain.Events {
+		known[event] = true
+	}
+	seen := make(map[string]bool, len(events))
+	normalized := make([]string, 0, len(events))
+	for _, event := range events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !known[event] {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownEvent, event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			normalized = append(normalized, event)
+		}
+	}
+	return normalized, nil
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
+
+func generateEventID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return "evt_" + hex.EncodeToString(id), nil
+}
+
+func excerpt(s string) *string {
+	if s == "" {
+		return nil
+	}
+	if runes := []rune(s); len(runes) > responseExcerptLength {
+		s = string(runes[:responseExcerptLength])
+	}
+	return &s
+}
+
+func subscriptionResponse(subscription *domain.WebhookSubscription) dto.WebhookResponseDTO {
+	hint := ""
+	if len(subscription.Secret) > 4 {
+		hint = "..." + subscription.Secret[len(subscription.Secret)-4:]
+	}
+	return dto.WebhookResponseDTO{
+		ID:          subscription.ID,
+		URL:         subscription.URL,
+		Events:      subscription.EventList(),
+		Description: subscription.Description,
+		Active:      subscription.Active,
+		SecretHint:  hint,
+		CreatedBy:   subscription.CreatedBy,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}
+}
+
+func deliveryResponse(delivery *domain.WebhookDelivery) dto.DeliveryResponseDTO {
+	return dto.DeliveryResponseDTO{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		Event:          delivery.Event,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		LastResponse:   delivery.LastResponse,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
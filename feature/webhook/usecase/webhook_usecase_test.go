@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/event"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/dto"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type memoryWebhookRepository struct {
+	subscriptions []domain.WebhookSubscription
+	deliveries    []domain.WebhookDelivery
+}
+
+func (r *memoryWebhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	subscription.ID = len(r.subscriptions) + 1
+	r.subscriptions = append(r.subscriptions, *subscription)
+	return nil
+}
+
+func (r *memoryWebhookRepository) GetSubscription(ctx context.Context, id int) (*domain.WebhookSubscription, error) {
+	for i := range r.subscriptions {
+		if r.subscriptions[i].ID == id {
+			subscription := r.subscriptions[i]
+			return &subscription, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryWebhookRepository) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return r.subscriptions, nil
+}
+
+func (r *memoryWebhookRepository) ListActiveSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var active []domain.WebhookSubscription
+	for _, subscription := range r.subscriptions {
+		if subscription.Active {
+			active = append(active, subscription)
+		}
+	}
+	return active, nil
+}
+
+func (r *memoryWebhookRepository) UpdateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	r.subscriptions[subscription.ID-1] = *subscription
+	return nil
+}
+
+func (r *memoryWebhookRepository) DeleteSubscription(ctx context.Context, id int) error { return nil }
+
+func (r *memoryWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	for i := range deliveries {
+		deliveries[i].ID = len(r.deliveries) + 1
+		r.deliveries = append(r.deliveries, deliveries[i])
+	}
+	return nil
+}
+
+func (r *memoryWebhookRepository) GetDelivery(ctx context.Context, id int) (*domain.WebhookDelivery, error) {
+	if id < 1 || id > len(r.deliveries) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	delivery := r.deliveries[id-1]
+	return &delivery, nil
+}
+
+func (r *memoryWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID int, filter dto.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (r *memoryWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	var claimed []domain.WebhookDelivery
+	leaseUntil := now.Add(lease)
+	for i := range r.deliveries {
+		d := &r.deliveries[i]
+		if d.Status == domain.DeliveryPending && !d.NextAttemptAt.After(now) && len(claimed) < limit {
+			d.NextAttemptAt = &leaseUntil
+			claimed = append(claimed, *d)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *memoryWebhookRepository) SaveAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.deliveries[delivery.ID-1] = *delivery
+	return nil
+}
+
+func (r *memoryWebhookRepository) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// setupBus returns a bus on a mocked database, the memory repository ignores the transaction
+func setupBus(t *testing.T) (*event.Bus, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to setup mock db: %v", err)
+	}
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return event.NewBus(gormDB), mock
+}
+
+func TestPublishSignsAndDeliversToSubscribers(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := &memoryWebhookRepository{}
+	u := NewWebhookUsecase(repo)
+	u.Client = server.Client()
+	created, err := u.CreateSubscription(context.Background(), dto.WebhookCreateDTO{URL: server.URL, Events: []string{" Request.Approved ", "request.approved"}}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.EventRequestApproved}, created.Events)
+	_, err = u.CreateSubscription(context.Background(), dto.WebhookCreateDTO{URL: server.URL, Events: []string{domain.EventVolunteerDeleted}}, 1)
+	assert.NoError(t, err)
+
+	bus, mock := setupBus(t)
+	event.Subscribe(bus, u.OnRequestApproved)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	err = bus.InTx(context.Background(), func(tx *event.Tx) error {
+		return tx.Publish(event.RequestApproved{RequestID: 3, UserID: 4, Type: "verification", VerifierID: 9, DecidedAt: time.Now()})
+	})
+	assert.NoError(t, err)
+	assert.Len(t, repo.deliveries, 1)
+	u.DispatchDue(context.Background())
+
+	delivery := repo.deliveries[0]
+	assert.Equal(t, domain.DeliverySucceeded, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, *delivery.LastStatusCode)
+	if assert.NotNil(t, received) {
+		timestamp, _ := strconv.ParseInt(received.Header.Get("X-Webhook-Timestamp"), 10, 64)
+		assert.Equal(t, Sign(created.Secret, timestamp, body), received.Header.Get("X-Webhook-Signature"))
+		assert.Equal(t, domain.EventRequestApproved, received.Header.Get("X-Webhook-Event"))
+		assert.Equal(t, delivery.EventID, received.Header.Get("X-Webhook-Id"))
+	}
+}
+
+func TestFailedDeliveryIsRetriedThenFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	repo := &memoryWebhookRepository{}
+	u := NewWebhookUsecase(repo)
+	u.Client = server.Client()
+	_, err := u.CreateSubscription(context.Background(), dto.WebhookCreateDTO{URL: server.URL, Events: []string{domain.EventRequestRejected}}, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, u.Publish(context.Background(), domain.EventRequestRejected, dto.RequestEventData{RequestID: 1}))
+
+	before := time.Now()
+	u.DispatchDue(context.Background())
+	delivery := repo.deliveries[0]
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	assert.Equal(t, "down\n", *delivery.LastResponse)
+	assert.WithinDuration(t, before.Add(retryBaseDelay), *delivery.NextAttemptAt, 5*time.Second)
+
+	for i := 1; i < MaxDeliveryAttempts; i++ {
+		past := time.Now().Add(-time.Second)
+		repo.deliveries[0].NextAttemptAt = &past
+		u.DispatchDue(context.Background())
+	}
+	delivery = repo.deliveries[0]
+	assert.Equal(t, domain.DeliveryFailed, delivery.Status)
+	assert.Equal(t, MaxDeliveryAttempts, delivery.Attempts)
+	assert.Nil(t, delivery.NextAttemptAt)
+
+	redelivered, err := u.Redeliver(context.Background(), 1, delivery.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, delivery.EventID, redelivered.EventID)
+	assert.Equal(t, domain.DeliveryPending, redelivered.Status)
+	_, err = u.Redeliver(context.Background(), 2, delivery.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestCreateSubscriptionRejectsUnknownEvent(t *testing.T) {
+	u := NewWebhookUsecase(&memoryWebhookRepository{})
+	_, err := u.CreateSubscription(context.Background(), dto.WebhookCreateDTO{URL: "https://hr.example.org/hook", Events: []string{"user.deleted"}}, 1)
+	assert.ErrorIs(t, err, ErrUnknownEvent)
+	_, err = u.CreateSubscription(context.Background(), dto.WebhookCreateDTO{URL: "ftp://hr.example.org", Events: []string{domain.EventVolunteerCreated}}, 1)
+	assert.ErrorIs(t, err, ErrInvalidURL)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryDelay(1))
+	assert.Equal(t, 2*time.Minute, retryDelay(3))
+	assert.Equal(t, retryMaxDelay, retryDelay(20))
+}
+
+func TestDeliveryRefusesInternalAddresses(t *testing.T) {
+	reached := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.Write([]byte("internal secret"))
+	}))
+	defer server.Close()
+
+	repo := &memoryWebhookRepository{}
+	u := NewWebhookUsecase(repo)
+	_, err := u.CreateSubscription(context.Background(), dto.WebhookCreateDTO{URL: server.URL, Events: []string{domain.EventRequestRejected}}, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, u.Publish(context.Background(), domain.EventRequestRejected, dto.RequestEventData{RequestID: 1}))
+	u.DispatchDue(context.Background())
+
+	delivery := repo.deliveries[0]
+	assert.False(t, reached)
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	assert.Nil(t, delivery.LastResponse)
+	if assert.NotNil(t, delivery.LastError) {
+		assert.Contains(t, *delivery.LastError, ErrForbiddenAddress.Error())
+	}
+}
+
+func TestDeliveryDoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal" {
+			w.Write([]byte("internal secret"))
+			return
+		}
+		http.Redirect(w, r, "/internal", http.StatusFound)
+	}))
+	defer server.Close()
+
+	client := newDeliveryClient()
+	client.Transport = server.Client().Transport
+	response, err := client.Post(server.URL, "application/json", nil)
+	assert.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusFound, response.StatusCode)
+}
+
+func TestPublicAddress(t *testing.T) {
+	for address, public := range map[string]bool{
+		"93.184.216.34":          true,
+		"2606:2800:220:1::1":     true,
+		"127.0.0.1":              false,
+		"::1":                    false,
+		"10.1.2.3":               false,
+		"172.16.0.1":             false,
+		"192.168.1.1":            false,
+		"169.254.169.254":        false,
+		"fe80::1":                false,
+		"fd00::1":                false,
+		"100.64.0.1":             false,
+		"0.0.0.0":                false,
+		"::ffff:169.254.169.254": false,
+		"224.0.0.1":              false,
+	} {
+		assert.Equal(t, public, publicAddress(netip.MustParseAddr(address)), address)
+	}
+}
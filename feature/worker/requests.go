@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const drainPollInterval = 50 * time.Millisecond
+
+// Requests counts the HTTP requests being served so the shutdown can wait for them to finish.
+type Requests struct {
+	inFlight atomic.Int64
+	draining context.Context
+	drain    context.CancelFunc
+}
+
+// NewRequests creates a Requests with no request in flight.
+func NewRequests() *Requests {
+	draining, drain := context.WithCancel(context.Background())
+	return &Requests{draining: draining, drain: drain}
+}
+
+// Middleware counts the requests, it goes first on the router.
+func (r *Requests) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r.inFlight.Add(1)
+		defer r.inFlight.Add(-1)
+		c.Next()
+	}
+}
+
+// Stream is for the routes holding the connection open, like the notification stream. The
+// request context is done as soon as the shutdown starts so they do not hold the drain back.
+func (r *Requests) Stream() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+		stop := context.AfterFunc(r.draining, cancel)
+		defer stop()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// InFlight returns the number of requests being served.
+func (r *Requests) InFlight() int64 {
+	return r.inFlight.Load()
+}
+
+// Drain ends the streams and waits until no request is in flight or ctx is done.
+func (r *Requests) Drain(ctx context.Context) error {
+	r.drain()
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for r.inFlight.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d requests still in flight: %w", r.inFlight.Load(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// Worker drains the requests when the process shuts down.
+func (r *Requests) Worker() Worker {
+	return Worker{Name: "http", Stop: r.Drain}
+}
@@ -0,0 +1,156 @@
+// Package worker runs the background parts of the process and shuts them down in order.
+//
+// A Manager is added to the system Waiter next to the web server. When the waiter context is
+// done, on SIGINT or SIGTERM, every worker gets SHUTDOWN_TIMEOUT (30s by default) to finish:
+// the Stop hooks run, in reverse order of registration, while the Start loops return.
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultShutdownTimeout is how long the workers get to stop when SHUTDOWN_TIMEOUT is not set.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// Worker is a named part of the process. Start runs until its context is done, Stop releases
+// what the worker holds once the process shuts down. Either can be nil.
+type Worker struct {
+	Name  string
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+}
+
+// Loop makes a Worker of a loop that runs until its context is done, like WebhookUsecase.Run.
+func Loop(name string, run func(ctx context.Context)) Worker {
+	return Worker{Name: name, Start: func(ctx context.Context) error {
+		run(ctx)
+		return nil
+	}}
+}
+
+// Manager starts the workers and stops them when the process shuts down.
+type Manager struct {
+	timeout time.Duration
+	mu      sync.Mutex
+	workers []Worker
+}
+
+// NewManager creates a Manager giving the workers timeout to stop.
+func NewManager(timeout time.Duration) *Manager {
+	return &Manager{timeout: timeout}
+}
+
+// ShutdownTimeoutFromEnv reads SHUTDOWN_TIMEOUT, DefaultShutdownTimeout when it is not set.
+func ShutdownTimeoutFromEnv() (time.Duration, error) {
+	value := os.Getenv("SHUTDOWN_TIMEOUT")
+	if value == "" {
+		return DefaultShutdownTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid SHUTDOWN_TIMEOUT %q, use a duration such as 30s", value)
+	}
+	return timeout, nil
+}
+
+// InServer tells whether the server command runs the background jobs too. WORKERS_IN_SERVER=false
+// leaves them to the worker command.
+func InServer() bool {
+	inServer, err := strconv.ParseBool(os.Getenv("WORKERS_IN_SERVER"))
+	return err != nil || inServer
+}
+
+// Add registers workers, they are started by Run.
+func (m *Manager) Add(workers ...Worker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.workers = append(m.workers, workers...)
+}
+
+// Run starts the workers and blocks until ctx is done or a worker fails, then stops them all.
+// It fits system.Waiter.Add. The error joins the failures of the workers and of their Stop hooks.
+func (m *Manager) Run(ctx context.Context) error {
+	m.mu.Lock()
+	workers := append([]Worker(nil), m.workers...)
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	failures := make(chan error, len(workers))
+	var running sync.WaitGroup
+	for _, w := range workers {
+		if w.Start == nil {
+			continue
+		}
+		running.Add(1)
+		go func(w Worker) {
+			defer running.Done()
+			slog.Info("worker started", "worker", w.Name)
+			if err := w.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				slog.Error("worker failed", "worker", w.Name, "error", err)
+				failures <- fmt.Errorf("worker %s: %w", w.Name, err)
+				cancel()
+				return
+			}
+			slog.Info("worker stopped", "worker", w.Name)
+		}(w)
+	}
+	<-ctx.Done()
+
+	slog.Info("shutting down workers", "timeout", m.timeout.String())
+	stopCtx, stopCancel := context.WithTimeout(context.WithoutCancel(ctx), m.timeout)
+	defer stopCancel()
+	var errs []error
+	for i := len(workers) - 1; i >= 0; i-- {
+		if workers[i].Stop == nil {
+			continue
+		}
+		if err := workers[i].Stop(stopCtx); err != nil {
+			slog.Error("worker did not stop cleanly", "worker", workers[i].Name, "error", err)
+			errs = append(errs, fmt.Errorf("stopping %s: %w", workers[i].Name, err))
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		running.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-stopCtx.Done():
+		errs = append(errs, fmt.Errorf("workers still running after %s", m.timeout))
+	}
+	for {
+		select {
+		case err := <-failures:
+			errs = append(errs, err)
+		default:
+			return errors.Join(errs...)
+		}
+	}
+}
+
+// CancelOnSignal returns a wait function calling cancel on SIGINT or SIGTERM, so the waiter
+// context is done and everything added to the waiter shuts down.
+func CancelOnSignal(cancel context.CancelFunc) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		signals, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		<-signals.Done()
+		if ctx.Err() == nil {
+			slog.Info("shutdown signal received")
+			cancel()
+		}
+		return nil
+	}
+}
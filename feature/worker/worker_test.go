@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_Run(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+	stopHook := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			record("stop " + name)
+			return nil
+		}
+	}
+
+	manager := NewManager(time.Second)
+	started := make(chan struct{})
+	manager.Add(
+		Worker{Name: "first", Stop: stopHook("first")},
+		Loop("loop", func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+			record("loop returned")
+		}),
+		Worker{Name: "last", Stop: stopHook("last")},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- manager.Run(ctx) }()
+	<-started
+	cancel()
+
+	assert.NoError(t, <-done)
+	assert.ElementsMatch(t, []string{"stop last", "stop first", "loop returned"}, events)
+	// the stop hooks run in reverse order, the loop returns meanwhile
+	assert.Equal(t, []string{"stop last", "stop first"}, without(events, "loop returned"))
+}
+
+func without(events []string, event string) []string {
+	var others []string
+	for _, e := range events {
+		if e != event {
+			others = append(others, e)
+		}
+	}
+	return others
+}
+
+func TestManager_Run_WorkerFails(t *testing.T) {
+	manager := NewManager(time.Second)
+	stopped := false
+	manager.Add(
+		Worker{Name: "broken", Start: func(context.Context) error { return errors.New("no database") }},
+		Worker{Name: "other", Stop: func(context.Context) error {
+			stopped = true
+			return nil
+		}},
+	)
+
+	err := manager.Run(context.Background())
+
+	assert.EqualError(t, err, "worker broken: no database")
+	assert.True(t, stopped)
+}
+
+func TestManager_Run_Timeout(t *testing.T) {
+	manager := NewManager(50 * time.Millisecond)
+	manager.Add(Worker{Name: "stuck", Start: func(context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.EqualError(t, manager.Run(ctx), "workers still running after 50ms")
+}
+
+func TestRequests_Drain(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	requests := NewRequests()
+	router := gin.New()
+	router.Use(requests.Middleware())
+	entered := make(chan struct{}, 2)
+	release := make(chan struct{})
+	router.GET("/slow", func(c *gin.Context) {
+		entered <- struct{}{}
+		<-release
+		c.Status(http.StatusNoContent)
+	})
+	router.GET("/stream", requests.Stream(), func(c *gin.Context) {
+		entered <- struct{}{}
+		<-c.Request.Context().Done()
+	})
+
+	for _, path := range []string{"/slow", "/stream"} {
+		go router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		<-entered
+	}
+	assert.Equal(t, int64(2), requests.InFlight())
+
+	// the stream ends at once, the slow request keeps the drain waiting until it times out
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.EqualError(t, requests.Drain(ctx), "1 requests still in flight: context deadline exceeded")
+
+	close(release)
+	assert.NoError(t, requests.Drain(context.Background()))
+	assert.Equal(t, int64(0), requests.InFlight())
+}
+
+func TestShutdownTimeoutFromEnv(t *testing.T) {
+	timeout, err := ShutdownTimeoutFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultShutdownTimeout, timeout)
+
+	t.Setenv("SHUTDOWN_TIMEOUT", "5s")
+	timeout, err = ShutdownTimeoutFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, timeout)
+
+	t.Setenv("SHUTDOWN_TIMEOUT", "-1s")
+	_, err = ShutdownTimeoutFromEnv()
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package feature
+
+import (
+	notificationStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/storage"
+	notificationUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/notification/usecase"
+	schedulerStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/scheduler/storage"
+	schedulerUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/scheduler/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/tracing"
+	userStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+	userUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	webhookStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/storage"
+	webhookUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/webhook/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/worker"
+)
+
+// RegisterWorkersV1 adds the background jobs to workers without serving the API, for the worker
+// command. Run the server with WORKERS_IN_SERVER=false next to it.
+func RegisterWorkersV1(mono Service, workers *worker.Manager) error {
+	if err := tracing.InstrumentDB(mono.DB()); err != nil {
+		mono.Slog().Error("cannot trace database queries", "error", err)
+	}
+	webhookUseCase := webhookUsecase.NewWebhookUsecase(webhookStorage.NewWebhookRepository(mono.DB()))
+	// the notifications of the jobs are stored, the streams served by the server do not push them live
+	notificationUseCase := notificationUsecase.NewNotificationUsecase(notificationStorage.NewNotificationRepository(mono.DB()), notificationUsecase.NewBroker())
+	slaUseCase := userUsecase.NewSLAUsecase(userStorage.NewSLARepository(mono.DB()), notificationUseCase)
+	scheduler, err := newScheduler(mono, webhookUseCase, slaUseCase)
+	if err != nil {
+		return err
+	}
+	registerJobs(workers, webhookUseCase, scheduler)
+	return nil
+}
+
+// newScheduler registers the recurring jobs. The server lists and triggers them, the scheduler
+// worker runs them on schedule.
+func newScheduler(mono Service, webhookUseCase *webhookUsecase.WebhookUsecase, slaUseCase *userUsecase.SLAUsecase) (*schedulerUsecase.SchedulerUsecase, error) {
+	scheduler := schedulerUsecase.NewSchedulerUsecase(schedulerStorage.NewSchedulerRepository(mono.DB()))
+	jobs := []schedulerUsecase.Job{
+		{
+			Name:        "request-sla",
+			Description: "Reminds the reviewers of pending requests close to their deadline and escalates the breached ones to the department managers",
+			Schedule:    "*/15 * * * *",
+			Run:         slaUseCase.CheckSLAs,
+		},
+		{
+			Name:        "webhook-deliveries-purge",
+			Description: "Deletes the finished webhook deliveries older than 30 days",
+			Schedule:    "15 3 * * *",
+			Run:         webhookUseCase.PurgeDeliveries,
+		},
+		{
+			Name:        "job-runs-purge",
+			Description: "Deletes the job runs older than 30 days",
+			Schedule:    "45 3 * * *",
+			Run:         scheduler.PurgeRuns,
+		},
+	}
+	for _, job := range jobs {
+		if err := scheduler.Register(job); err != nil {
+			return nil, err
+		}
+	}
+	return scheduler, nil
+}
+
+// registerJobs adds the background jobs, run by the server or by the worker command
+func registerJobs(workers *worker.Manager, webhookUseCase *webhookUsecase.WebhookUsecase, scheduler *schedulerUsecase.SchedulerUsecase) {
+	workers.Add(
+		worker.Loop("webhook-dispatcher", webhookUseCase.Run),
+		worker.Loop("scheduler", scheduler.Run),
+	)
+}
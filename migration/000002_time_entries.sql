@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS `time_entries` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `volunteer_id` INT NOT NULL,
+    `event_id` INT DEFAULT NULL COMMENT 'NULL: ad-hoc entry',
+    `department_id` INT NOT NULL,
+    `start_time` DATETIME NOT NULL,
+    `end_time` DATETIME NOT NULL,
+    `description` VARCHAR(255) DEFAULT NULL,
+    `status` TINYINT NOT NULL DEFAULT 0 COMMENT '0: pending\n1: approved\n2: rejected',
+    `approver_id` INT DEFAULT NULL,
+    `approved_at` DATETIME DEFAULT NULL,
+    `reject_notes` VARCHAR(255) DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    KEY `fk_time_entries_volunteers_idx` (`volunteer_id`),
+    KEY `fk_time_entries_depts_idx` (`department_id`),
+    KEY `fk_time_entries_approvers_idx` (`approver_id`),
+    KEY `time_entries_start_time_idx` (`start_time`),
+    CONSTRAINT `fk_time_entries_volunteers` FOREIGN KEY (`volunteer_id`) REFERENCES `volunteer_details` (`id`),
+    CONSTRAINT `fk_time_entries_depts` FOREIGN KEY (`department_id`) REFERENCES `departments` (`id`),
+    CONSTRAINT `fk_time_entries_approvers` FOREIGN KEY (`approver_id`) REFERENCES `users` (`id`)
+);
@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS `volunteer_availabilities` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `volunteer_id` INT NOT NULL,
+    `weekday` TINYINT NOT NULL COMMENT '0: sunday\n6: saturday',
+    `start_minute` SMALLINT NOT NULL COMMENT 'minutes since midnight',
+    `end_minute` SMALLINT NOT NULL COMMENT 'minutes since midnight',
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    KEY `fk_volunteer_availabilities_volunteers_idx` (`volunteer_id`),
+    CONSTRAINT `fk_volunteer_availabilities_volunteers` FOREIGN KEY (`volunteer_id`) REFERENCES `volunteer_details` (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `volunteer_blackouts` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `volunteer_id` INT NOT NULL,
+    `date` DATE NOT NULL,
+    `reason` VARCHAR(255) DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    KEY `volunteer_blackouts_volunteer_date_idx` (`volunteer_id`, `date`),
+    CONSTRAINT `fk_volunteer_blackouts_volunteers` FOREIGN KEY (`volunteer_id`) REFERENCES `volunteer_details` (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `shifts` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `department_id` INT NOT NULL,
+    `name` VARCHAR(100) NOT NULL,
+    `start_time` DATETIME NOT NULL,
+    `end_time` DATETIME NOT NULL,
+    `capacity` INT NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    KEY `fk_shifts_depts_idx` (`department_id`),
+    KEY `shifts_start_time_idx` (`start_time`),
+    CONSTRAINT `fk_shifts_depts` FOREIGN KEY (`department_id`) REFERENCES `departments` (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `shift_assignments` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `shift_id` INT NOT NULL,
+    `volunteer_id` INT NOT NULL,
+    `assigned_by` INT DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY `shift_assignments_shift_volunteer_uq` (`shift_id`, `volunteer_id`),
+    KEY `fk_shift_assignments_volunteers_idx` (`volunteer_id`),
+    CONSTRAINT `fk_shift_assignments_shifts` FOREIGN KEY (`shift_id`) REFERENCES `shifts` (`id`),
+    CONSTRAINT `fk_shift_assignments_volunteers` FOREIGN KEY (`volunteer_id`) REFERENCES `volunteer_details` (`id`),
+    CONSTRAINT `fk_shift_assignments_assigners` FOREIGN KEY (`assigned_by`) REFERENCES `users` (`id`)
+);
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS `skills` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `name` VARCHAR(100) NOT NULL,
+    `category` VARCHAR(20) NOT NULL COMMENT 'skill\nlanguage\ncertification',
+    `status` TINYINT NOT NULL COMMENT '0: inactive\n1: active',
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY `skills_category_name_uq` (`category`, `name`)
+);
+
+CREATE TABLE IF NOT EXISTS `volunteer_skills` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `volunteer_id` INT NOT NULL,
+    `skill_id` INT NOT NULL,
+    `level` TINYINT NOT NULL COMMENT '1: basic\n5: expert',
+    `verified_by` INT DEFAULT NULL,
+    `verified_at` DATETIME DEFAULT NULL,
+    `expires_at` DATE DEFAULT NULL COMMENT 'certifications only',
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY `volunteer_skills_volunteer_skill_uq` (`volunteer_id`, `skill_id`),
+    KEY `fk_volunteer_skills_skills_idx` (`skill_id`),
+    CONSTRAINT `fk_volunteer_skills_volunteers` FOREIGN KEY (`volunteer_id`) REFERENCES `volunteer_details` (`id`),
+    CONSTRAINT `fk_volunteer_skills_skills` FOREIGN KEY (`skill_id`) REFERENCES `skills` (`id`),
+    CONSTRAINT `fk_volunteer_skills_verifiers` FOREIGN KEY (`verified_by`) REFERENCES `users` (`id`)
+);
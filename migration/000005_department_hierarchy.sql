@@ -0,0 +1,18 @@
+ALTER TABLE `departments`
+    ADD COLUMN `parent_id` INT DEFAULT NULL AFTER `id`,
+    ADD KEY `fk_departments_parents_idx` (`parent_id`),
+    ADD CONSTRAINT `fk_departments_parents` FOREIGN KEY (`parent_id`) REFERENCES `departments` (`id`);
+
+CREATE TABLE IF NOT EXISTS `department_managers` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `department_id` INT NOT NULL,
+    `user_id` INT NOT NULL,
+    `volunteer_id` INT NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY `department_managers_department_user_uq` (`department_id`, `user_id`),
+    KEY `fk_department_managers_users_idx` (`user_id`),
+    KEY `fk_department_managers_volunteers_idx` (`volunteer_id`),
+    CONSTRAINT `fk_department_managers_depts` FOREIGN KEY (`department_id`) REFERENCES `departments` (`id`),
+    CONSTRAINT `fk_department_managers_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`),
+    CONSTRAINT `fk_department_managers_volunteers` FOREIGN KEY (`volunteer_id`) REFERENCES `volunteer_details` (`id`)
+);
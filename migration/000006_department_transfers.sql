@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS `department_transfers` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `volunteer_id` INT NOT NULL,
+    `from_department_id` INT NOT NULL,
+    `to_department_id` INT NOT NULL,
+    `requested_by` INT NOT NULL,
+    `reason` VARCHAR(255) DEFAULT NULL,
+    `status` TINYINT NOT NULL DEFAULT 0 COMMENT '0: pending\n1: approved\n2: rejected\n3: cancelled',
+    `source_approved_by` INT DEFAULT NULL,
+    `source_approved_at` DATETIME DEFAULT NULL,
+    `target_approved_by` INT DEFAULT NULL,
+    `target_approved_at` DATETIME DEFAULT NULL,
+    `decided_by` INT DEFAULT NULL,
+    `decided_at` DATETIME DEFAULT NULL,
+    `reject_notes` VARCHAR(255) DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    KEY `fk_department_transfers_volunteers_idx` (`volunteer_id`),
+    KEY `fk_department_transfers_from_depts_idx` (`from_department_id`),
+    KEY `fk_department_transfers_to_depts_idx` (`to_department_id`),
+    CONSTRAINT `fk_department_transfers_volunteers` FOREIGN KEY (`volunteer_id`) REFERENCES `volunteer_details` (`id`),
+    CONSTRAINT `fk_department_transfers_from_depts` FOREIGN KEY (`from_department_id`) REFERENCES `departments` (`id`),
+    CONSTRAINT `fk_department_transfers_to_depts` FOREIGN KEY (`to_department_id`) REFERENCES `departments` (`id`),
+    CONSTRAINT `fk_department_transfers_requesters` FOREIGN KEY (`requested_by`) REFERENCES `users` (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `department_memberships` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `volunteer_id` INT NOT NULL,
+    `department_id` INT NOT NULL,
+    `start_date` DATE NOT NULL,
+    `end_date` DATE DEFAULT NULL COMMENT 'NULL: current membership',
+    `transfer_id` INT DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    KEY `fk_department_memberships_volunteers_idx` (`volunteer_id`),
+    KEY `fk_department_memberships_depts_idx` (`department_id`),
+    CONSTRAINT `fk_department_memberships_volunteers` FOREIGN KEY (`volunteer_id`) REFERENCES `volunteer_details` (`id`),
+    CONSTRAINT `fk_department_memberships_depts` FOREIGN KEY (`department_id`) REFERENCES `departments` (`id`),
+    CONSTRAINT `fk_department_memberships_transfers` FOREIGN KEY (`transfer_id`) REFERENCES `department_transfers` (`id`)
+);
+
+INSERT INTO `department_memberships` (`volunteer_id`, `department_id`, `start_date`)
+SELECT `id`, `department_id`, DATE(`created_at`) FROM `volunteer_details`;
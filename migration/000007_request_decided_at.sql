@@ -0,0 +1,7 @@
+ALTER TABLE `requests`
+    ADD COLUMN `decided_at` DATETIME DEFAULT NULL AFTER `verifier_id`,
+    ADD KEY `requests_decided_at_idx` (`decided_at`);
+
+UPDATE `requests` SET `decided_at` = `updated_at` WHERE `status` IN (1, 2);
+
+CREATE INDEX `users_created_at_idx` ON `users` (`created_at`);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `user_invites` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `user_id` INT NOT NULL,
+    `token_hash` CHAR(64) NOT NULL COMMENT 'hex SHA-256 of the token handed to the user',
+    `expires_at` DATETIME NOT NULL,
+    `used_at` DATETIME DEFAULT NULL COMMENT 'NULL: the user did not set their password yet',
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY `user_invites_token_hash_uq` (`token_hash`),
+    KEY `fk_user_invites_users_idx` (`user_id`),
+    CONSTRAINT `fk_user_invites_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+);
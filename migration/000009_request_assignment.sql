@@ -0,0 +1,8 @@
+ALTER TABLE `requests`
+    ADD COLUMN `assignee_id` INT DEFAULT NULL AFTER `verifier_id`,
+    ADD COLUMN `claim_expires_at` DATETIME DEFAULT NULL AFTER `assignee_id`,
+    ADD COLUMN `viewed_at` DATETIME DEFAULT NULL AFTER `claim_expires_at`,
+    ADD COLUMN `viewed_by` INT DEFAULT NULL AFTER `viewed_at`,
+    ADD KEY `fk_requests_assignees_idx` (`assignee_id`, `claim_expires_at`),
+    ADD CONSTRAINT `fk_requests_assignees` FOREIGN KEY (`assignee_id`) REFERENCES `users` (`id`),
+    ADD CONSTRAINT `fk_requests_viewers` FOREIGN KEY (`viewed_by`) REFERENCES `users` (`id`);
@@ -0,0 +1,68 @@
+CREATE TABLE IF NOT EXISTS `rejection_reasons` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `code` VARCHAR(50) NOT NULL,
+    `label` VARCHAR(255) NOT NULL COMMENT 'shown to reviewers',
+    `applicant_message` VARCHAR(500) NOT NULL COMMENT 'shown to applicants',
+    `request_type` VARCHAR(45) DEFAULT NULL COMMENT 'NULL: every request type',
+    `status` TINYINT NOT NULL COMMENT '0: inactive\n1: active',
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY `rejection_reasons_code_uq` (`code`)
+);
+
+CREATE TABLE IF NOT EXISTS `request_rejection_reasons` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `request_id` INT NOT NULL,
+    `rejection_reason_id` INT NOT NULL,
+    `field` VARCHAR(100) DEFAULT NULL COMMENT 'the field or document the reason points at, e.g. dob',
+    `comment` VARCHAR(500) DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    KEY `fk_request_rejection_reasons_requests_idx` (`request_id`),
+    KEY `fk_request_rejection_reasons_reasons_idx` (`rejection_reason_id`),
+    CONSTRAINT `fk_request_rejection_reasons_requests` FOREIGN KEY (`request_id`) REFERENCES `requests` (`id`),
+    CONSTRAINT `fk_request_rejection_reasons_reasons` FOREIGN KEY (`rejection_reason_id`) REFERENCES `rejection_reasons` (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `checklist_items` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `request_type` VARCHAR(45) NOT NULL,
+    `label` VARCHAR(255) NOT NULL,
+    `position` INT NOT NULL DEFAULT 0,
+    `status` TINYINT NOT NULL COMMENT '0: inactive\n1: active',
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    KEY `checklist_items_request_type_idx` (`request_type`, `status`)
+);
+
+CREATE TABLE IF NOT EXISTS `request_checklist_items` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `request_id` INT NOT NULL,
+    `checklist_item_id` INT NOT NULL,
+    `checked` TINYINT(1) NOT NULL DEFAULT 0,
+    `checked_by` INT DEFAULT NULL,
+    `checked_at` DATETIME DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY `request_checklist_items_request_item_uq` (`request_id`, `checklist_item_id`),
+    KEY `fk_request_checklist_items_items_idx` (`checklist_item_id`),
+    CONSTRAINT `fk_request_checklist_items_requests` FOREIGN KEY (`request_id`) REFERENCES `requests` (`id`),
+    CONSTRAINT `fk_request_checklist_items_items` FOREIGN KEY (`checklist_item_id`) REFERENCES `checklist_items` (`id`),
+    CONSTRAINT `fk_request_checklist_items_checkers` FOREIGN KEY (`checked_by`) REFERENCES `users` (`id`)
+);
+
+INSERT INTO `rejection_reasons` (`code`, `label`, `applicant_message`, `request_type`, `status`) VALUES
+    ('INCOMPLETE_PROFILE', 'Incomplete profile', 'Some required information is missing from your profile.', NULL, 1),
+    ('INVALID_DOB', 'Invalid date of birth', 'The date of birth you entered is not valid.', NULL, 1),
+    ('NOT_ELIGIBLE', 'Not eligible', 'You do not meet the eligibility requirements.', NULL, 1),
+    ('DUPLICATE', 'Duplicate application', 'We already have an application from you.', NULL, 1),
+    ('DOCUMENT_UNREADABLE', 'Identity document unreadable', 'We could not read your identity document, please upload a clearer copy.', 'verification', 1),
+    ('DOCUMENT_EXPIRED', 'Identity document expired', 'Your identity document has expired.', 'verification', 1),
+    ('DOCUMENT_MISMATCH', 'Identity document does not match profile', 'The details on your identity document do not match your profile.', 'verification', 1);
+
+INSERT INTO `checklist_items` (`request_type`, `label`, `position`, `status`) VALUES
+    ('registration', 'Profile information is complete', 1, 1),
+    ('registration', 'Date of birth is valid', 2, 1),
+    ('registration', 'Department choice is appropriate', 3, 1),
+    ('verification', 'Identity document is readable', 1, 1),
+    ('verification', 'Identity document is not expired', 2, 1),
+    ('verification', 'Identity document matches the profile', 3, 1);
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS `request_comments` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `request_id` INT NOT NULL,
+    `parent_id` INT DEFAULT NULL,
+    `author_id` INT NOT NULL,
+    `visibility` TINYINT NOT NULL COMMENT '0: internal, reviewers only\n1: external, visible to the requester',
+    `body` TEXT NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    KEY `fk_request_comments_requests_idx` (`request_id`),
+    KEY `fk_request_comments_parents_idx` (`parent_id`),
+    KEY `fk_request_comments_authors_idx` (`author_id`),
+    CONSTRAINT `fk_request_comments_requests` FOREIGN KEY (`request_id`) REFERENCES `requests` (`id`),
+    CONSTRAINT `fk_request_comments_parents` FOREIGN KEY (`parent_id`) REFERENCES `request_comments` (`id`),
+    CONSTRAINT `fk_request_comments_authors` FOREIGN KEY (`author_id`) REFERENCES `users` (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `comment_attachments` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `comment_id` INT NOT NULL,
+    `file_name` VARCHAR(255) NOT NULL,
+    `content_type` VARCHAR(100) NOT NULL,
+    `size` BIGINT NOT NULL,
+    `storage_path` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    KEY `fk_comment_attachments_comments_idx` (`comment_id`),
+    CONSTRAINT `fk_comment_attachments_comments` FOREIGN KEY (`comment_id`) REFERENCES `request_comments` (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `comment_reads` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `comment_id` INT NOT NULL,
+    `user_id` INT NOT NULL,
+    `read_at` DATETIME NOT NULL,
+    UNIQUE KEY `comment_reads_comment_user_uq` (`comment_id`, `user_id`),
+    KEY `fk_comment_reads_users_idx` (`user_id`),
+    CONSTRAINT `fk_comment_reads_comments` FOREIGN KEY (`comment_id`) REFERENCES `request_comments` (`id`),
+    CONSTRAINT `fk_comment_reads_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+);
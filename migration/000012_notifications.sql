@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `notifications` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `user_id` INT NOT NULL,
+    `type` VARCHAR(45) NOT NULL COMMENT 'request_decided\ncomment\ntransfer\nshift_assigned',
+    `title` VARCHAR(255) NOT NULL,
+    `body` VARCHAR(1000) DEFAULT NULL,
+    `resource_type` VARCHAR(45) DEFAULT NULL COMMENT 'request\ntransfer\nshift',
+    `resource_id` INT DEFAULT NULL,
+    `read_at` DATETIME DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    KEY `fk_notifications_users_idx` (`user_id`, `read_at`),
+    CONSTRAINT `fk_notifications_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+);
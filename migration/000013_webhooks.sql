@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS `webhook_subscriptions` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `url` VARCHAR(500) NOT NULL,
+    `secret` VARCHAR(255) NOT NULL,
+    `events` VARCHAR(1000) NOT NULL COMMENT 'comma separated, request.approved\nrequest.rejected\nvolunteer.created\nvolunteer.activated\nvolunteer.deactivated\nvolunteer.deleted',
+    `description` VARCHAR(255) DEFAULT NULL,
+    `active` TINYINT(1) NOT NULL DEFAULT 1,
+    `created_by` INT DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT `fk_webhook_subscriptions_users` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `subscription_id` INT NOT NULL,
+    `event_id` VARCHAR(64) NOT NULL COMMENT 'same for every delivery and redelivery of one event',
+    `event` VARCHAR(64) NOT NULL,
+    `payload` TEXT NOT NULL,
+    `status` VARCHAR(16) NOT NULL COMMENT 'pending\nsucceeded\nfailed',
+    `attempts` INT NOT NULL DEFAULT 0,
+    `next_attempt_at` DATETIME DEFAULT NULL,
+    `last_status_code` INT DEFAULT NULL,
+    `last_error` VARCHAR(1000) DEFAULT NULL,
+    `last_response` VARCHAR(1000) DEFAULT NULL,
+    `delivered_at` DATETIME DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
+    KEY `fk_webhook_deliveries_subscriptions_idx` (`subscription_id`, `id`),
+    KEY `webhook_deliveries_due_idx` (`status`, `next_attempt_at`),
+    CONSTRAINT `fk_webhook_deliveries_subscriptions` FOREIGN KEY (`subscription_id`) REFERENCES `webhook_subscriptions` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `audit_logs` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `event` VARCHAR(64) NOT NULL,
+    `payload` TEXT NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    KEY `audit_logs_event_idx` (`event`, `created_at`)
+);
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS `scheduled_jobs` (
+    `name` VARCHAR(64) PRIMARY KEY,
+    `schedule` VARCHAR(64) NOT NULL COMMENT 'cron expression the next run is computed from',
+    `next_run_at` DATETIME NOT NULL,
+    `locked_by` VARCHAR(128) DEFAULT NULL COMMENT 'instance running the job, the lock expires at locked_until',
+    `locked_until` DATETIME DEFAULT NULL,
+    `last_run_at` DATETIME DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS `job_runs` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `job` VARCHAR(64) NOT NULL,
+    `trigger` VARCHAR(16) NOT NULL COMMENT 'schedule\nmanual',
+    `triggered_by` INT DEFAULT NULL,
+    `instance` VARCHAR(128) NOT NULL,
+    `status` VARCHAR(16) NOT NULL COMMENT 'running\nsucceeded\nfailed',
+    `error` VARCHAR(1000) DEFAULT NULL,
+    `started_at` DATETIME NOT NULL,
+    `finished_at` DATETIME DEFAULT NULL,
+    KEY `job_runs_job_idx` (`job`, `id`),
+    KEY `job_runs_started_at_idx` (`started_at`),
+    CONSTRAINT `fk_job_runs_users` FOREIGN KEY (`triggered_by`) REFERENCES `users` (`id`)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `request_slas` (
+    `request_type` VARCHAR(45) PRIMARY KEY,
+    `target_hours` INT NOT NULL COMMENT 'a pending request breaches its SLA this long after it was submitted',
+    `reminder_hours` INT NOT NULL DEFAULT 0 COMMENT 'the assigned reviewer is reminded this long before the deadline\n0: no reminder',
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP
+);
+
+INSERT INTO `request_slas` (`request_type`, `target_hours`, `reminder_hours`) VALUES
+    ('registration', 48, 8),
+    ('verification', 72, 12);
+
+ALTER TABLE `requests`
+    ADD COLUMN `sla_reminded_at` DATETIME DEFAULT NULL COMMENT 'when the assigned reviewer was reminded of the deadline' AFTER `decided_at`,
+    ADD COLUMN `sla_escalated_at` DATETIME DEFAULT NULL COMMENT 'when the department managers were told the SLA was breached' AFTER `sla_reminded_at`,
+    ADD KEY `requests_status_created_at_idx` (`status`, `created_at`);